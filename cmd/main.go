@@ -15,7 +15,8 @@ import (
 )
 
 func main() {
-	fmt.Println("Backend Interview Challenges Runner")
+	// The banner goes to stderr so commands such as graph export can be redirected to a file
+	fmt.Fprintln(os.Stderr, "Backend Interview Challenges Runner")
 
 	if len(os.Args) < 2 {
 		ShowUsage()
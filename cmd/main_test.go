@@ -0,0 +1,72 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/datastructures/graph"
+)
+
+// runnerEnv makes the test binary act as the runner, so tests can run it as a command
+const runnerEnv = "INTERVIEW_CHALLENGES_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runnerEnv) == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runCLI runs the runner with arguments and returns what it wrote to stdout
+func runCLI(t *testing.T, args ...string) []byte {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), runnerEnv+"=1")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("runner failed: %v\n%s", err, stderr.String())
+	}
+	return stdout.Bytes()
+}
+
+func TestGraphExportRoundTrip(t *testing.T) {
+	input := filepath.Join("..", "problems", "datastructures", "graph", "testdata", "network.txt")
+	original, err := graph.ReadFile(input)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	// ReadFile picks the format from the file extension
+	extensions := map[string]string{"dot": ".dot", "json": ".json", "edgelist": ".txt"}
+	for format, extension := range extensions {
+		t.Run(format, func(t *testing.T) {
+			exported := filepath.Join(t.TempDir(), "network"+extension)
+			if err := os.WriteFile(exported, runCLI(t, "datastructures", "graph", "--input", input, "export", format), 0o644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+
+			read, err := graph.ReadFile(exported)
+			if err != nil {
+				t.Fatalf("Reading the exported graph failed: %v", err)
+			}
+			if !reflect.DeepEqual(read.GetEdges(), original.GetEdges()) {
+				t.Errorf("Expected edges %v, got %v", original.GetEdges(), read.GetEdges())
+			}
+		})
+	}
+}
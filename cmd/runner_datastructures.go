@@ -10,7 +10,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/datastructures/binarysearchtree"
 	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/datastructures/graph"
@@ -31,7 +34,7 @@ func RunDataStructureProblem(problem string, args []string) {
 	case "hashtable":
 		runHashTable()
 	case "graph":
-		runGraph()
+		runGraph(args)
 	default:
 		fmt.Printf("Unknown problem: %s\n", problem)
 		fmt.Println("Use 'interview-challenges list' to see available problems")
@@ -58,7 +61,178 @@ func runHashTable() {
 	hashtable.RunExample()
 }
 
-func runGraph() {
-	fmt.Println("Running Graph example...")
-	graph.RunExample()
+func runGraph(args []string) {
+	// Pull out --input <file>; everything else is the subcommand and its arguments
+	input := ""
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--input" && i+1 < len(args):
+			input = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--input="):
+			input = strings.TrimPrefix(args[i], "--input=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	if len(rest) == 0 && input == "" {
+		fmt.Println("Running Graph example...")
+		graph.RunExample()
+		return
+	}
+	if len(rest) == 0 {
+		rest = []string{"print"}
+	}
+
+	command, rest := rest[0], rest[1:]
+
+	// flow and matching also accept the file as their first argument
+	if input == "" && (command == "flow" || command == "matching") && len(rest) > 0 {
+		input, rest = rest[0], rest[1:]
+	}
+	if input == "" {
+		fmt.Println("Usage: interview-challenges datastructures graph --input <file> <command> [arguments...]")
+		return
+	}
+
+	g, err := graph.ReadFile(input)
+	if err != nil {
+		fmt.Printf("Error reading graph: %v\n", err)
+		return
+	}
+
+	switch command {
+	case "print":
+		fmt.Print(g)
+	case "bfs", "dfs":
+		runGraphTraversal(g, command, rest)
+	case "shortest":
+		runGraphShortest(g, rest)
+	case "cycle":
+		runGraphCycle(g)
+	case "export":
+		runGraphExport(g, rest)
+	case "flow":
+		runGraphFlow(g, rest)
+	case "matching":
+		runGraphMatching(g)
+	default:
+		fmt.Printf("Unknown graph command: %s\n", command)
+		fmt.Println("Commands: print, bfs, dfs, shortest, cycle, export, flow, matching")
+	}
+}
+
+func runGraphTraversal(g *graph.Graph[string, string], command string, args []string) {
+	if len(args) < 1 {
+		fmt.Printf("Usage: interview-challenges datastructures graph --input <file> %s <start>\n", command)
+		return
+	}
+
+	traverse := g.BFS
+	if command == "dfs" {
+		traverse = g.DFS
+	}
+
+	order, err := traverse(args[0])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("%s from %s: %s\n", strings.ToUpper(command), args[0], strings.Join(order, " "))
+}
+
+func runGraphShortest(g *graph.Graph[string, string], args []string) {
+	if len(args) < 2 {
+		fmt.Println("Usage: interview-challenges datastructures graph --input <file> shortest <from> <to>")
+		return
+	}
+
+	path, cost, err := g.ShortestPath(args[0], args[1])
+	if errors.Is(err, graph.ErrNegativeWeight) {
+		var tree *graph.ShortestPathTree[string]
+		if tree, err = g.BellmanFord(args[0]); err == nil {
+			path, cost, err = tree.PathTo(args[1])
+		}
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Shortest path from %s to %s (cost %g): %s\n", args[0], args[1], cost, strings.Join(path, " -> "))
+}
+
+func runGraphCycle(g *graph.Graph[string, string]) {
+	if !g.HasCycle() {
+		fmt.Println("The graph has no cycle")
+		return
+	}
+	fmt.Println("The graph has a cycle")
+}
+
+func runGraphExport(g *graph.Graph[string, string], args []string) {
+	format := "dot"
+	if len(args) > 0 {
+		format = args[0]
+	}
+
+	var err error
+	switch format {
+	case "dot":
+		err = g.WriteDOT(os.Stdout)
+	case "json":
+		err = g.WriteJSON(os.Stdout)
+	case "edgelist":
+		err = g.WriteEdgeList(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format: %s (expected dot, json or edgelist)\n", format)
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+}
+
+func runGraphFlow(g *graph.Graph[string, string], args []string) {
+	if len(args) < 2 {
+		fmt.Println("Usage: interview-challenges datastructures graph flow <file> <source> <sink>")
+		return
+	}
+
+	source, sink := args[0], args[1]
+	result, err := g.Dinic(source, sink)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Maximum flow from %s to %s: %g\n", source, sink, result.Value)
+	fmt.Println("Edge flows:")
+	for _, edge := range g.GetEdges() {
+		if f := result.Flow(edge.From, edge.To); f > 0 {
+			fmt.Printf("  %s -> %s: %g/%g\n", edge.From, edge.To, f, edge.Weight)
+		}
+	}
+
+	cut, _ := g.MinCut(source, sink)
+	fmt.Printf("Minimum cut (capacity %g):\n", cut.Capacity)
+	for _, edge := range cut.Edges {
+		fmt.Printf("  %s -> %s (%g)\n", edge.From, edge.To, edge.Weight)
+	}
+}
+
+func runGraphMatching(g *graph.Graph[string, string]) {
+	left, right, err := g.Bipartition()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Left side: %v\nRight side: %v\n", left, right)
+
+	matching, _ := g.MaximumMatching()
+	fmt.Printf("Maximum matching (%d pairs):\n", len(matching))
+	for _, edge := range matching {
+		fmt.Printf("  %s - %s\n", edge.From, edge.To)
+	}
 }
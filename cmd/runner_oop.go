@@ -10,7 +10,15 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/bankingsystem"
 	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/factorypattern"
@@ -25,7 +33,7 @@ func RunOOPProblem(problem string, args []string) {
 	case "shapehierarchy":
 		runShapeHierarchy()
 	case "bankingsystem":
-		runBankingSystem()
+		runBankingSystem(args)
 	case "factorypattern":
 		runFactoryPattern()
 	case "observerpattern":
@@ -43,9 +51,141 @@ func runShapeHierarchy() {
 	shapehierarchy.RunExample()
 }
 
-func runBankingSystem() {
-	fmt.Println("Running Banking System example...")
-	bankingsystem.RunExample()
+func runBankingSystem(args []string) {
+	if len(args) == 0 {
+		fmt.Println("Running Banking System example...")
+		bankingsystem.RunExample()
+		return
+	}
+	if args[0] != "serve" {
+		fmt.Println("Usage: interview-challenges oop bankingsystem [serve [--addr host:port] [--data file] [--rules file] [--rates file]]")
+		return
+	}
+
+	addr := "localhost:8080"
+	dataFile := ""
+	rulesFile := ""
+	ratesFile := ""
+	for i := 1; i < len(args); i++ {
+		switch {
+		case args[i] == "--addr" && i+1 < len(args):
+			addr = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--addr="):
+			addr = strings.TrimPrefix(args[i], "--addr=")
+		case args[i] == "--data" && i+1 < len(args):
+			dataFile = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--data="):
+			dataFile = strings.TrimPrefix(args[i], "--data=")
+		case args[i] == "--rules" && i+1 < len(args):
+			rulesFile = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--rules="):
+			rulesFile = strings.TrimPrefix(args[i], "--rules=")
+		case args[i] == "--rates" && i+1 < len(args):
+			ratesFile = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--rates="):
+			ratesFile = strings.TrimPrefix(args[i], "--rates=")
+		}
+	}
+
+	bank := bankingsystem.NewBank("Go Banking")
+	var repo bankingsystem.Repository
+	if dataFile != "" {
+		repo = bankingsystem.NewFileRepository(dataFile)
+		loaded, err := bankingsystem.LoadBank(bank.Name, repo)
+		if err != nil {
+			fmt.Printf("Error loading %s: %v\n", dataFile, err)
+			return
+		}
+		bank = loaded
+		fmt.Printf("Keeping the bank's state in %s\n", dataFile)
+	}
+
+	if rulesFile != "" {
+		rules, err := bankingsystem.LoadRules(rulesFile)
+		if err == nil {
+			err = bank.Rules.SetRules(rules)
+		}
+		if err != nil {
+			fmt.Printf("Error loading %s: %v\n", rulesFile, err)
+			return
+		}
+		fmt.Printf("Screening money movements with %d rules from %s\n", len(rules), rulesFile)
+	}
+
+	if ratesFile != "" {
+		rates, err := bankingsystem.LoadRates(ratesFile)
+		if err != nil {
+			fmt.Printf("Error loading %s: %v\n", ratesFile, err)
+			return
+		}
+		bank.Rates = rates
+		fmt.Printf("Converting currencies at the rates in %s\n", ratesFile)
+	}
+
+	// Run the bank's daily and monthly jobs, saving the bank after any job has run
+	scheduler := bankingsystem.NewScheduler(bank, nil)
+	if repo != nil {
+		scheduler.OnRun = func([]bankingsystem.JobRun) { saveBank(bank, repo) }
+	}
+	stopScheduler := scheduler.Start(time.Minute, func(err error) {
+		fmt.Printf("Scheduled job error: %v\n", err)
+	})
+
+	var handler http.Handler = bankingsystem.NewServer(bank)
+	if repo != nil {
+		handler = savingHandler(handler, bank, repo)
+	}
+	server := &http.Server{Addr: addr, Handler: handler}
+
+	// On Ctrl+C or SIGTERM, finish the requests in flight before stopping
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(timeout); err != nil {
+			fmt.Printf("Shutdown error: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("Serving the %s API on http://%s\n", bank.Name, addr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		fmt.Printf("Server error: %v\n", err)
+		stopSignals()
+	}
+	<-shutdown
+
+	stopScheduler()
+	if repo != nil {
+		saveBank(bank, repo)
+	}
+	fmt.Println("Server stopped")
+}
+
+// saveBank saves the bank to a repository, reporting any error
+func saveBank(bank *bankingsystem.Bank, repo bankingsystem.Repository) {
+	if err := bank.Save(repo); err != nil {
+		fmt.Printf("Error saving the bank: %v\n", err)
+	}
+}
+
+// savingHandler saves the bank to a repository after every request that may
+// have changed it
+func savingHandler(next http.Handler, bank *bankingsystem.Bank, repo bankingsystem.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			return
+		}
+		saveBank(bank, repo)
+	})
 }
 
 func runFactoryPattern() {
@@ -22,6 +22,11 @@ func ShowUsage() {
 	fmt.Println("  interview-challenges algorithms stringreversal \"hello world\"")
 	fmt.Println("  interview-challenges algorithms twosum \"[2,7,11,15]\" 9")
 	fmt.Println("  interview-challenges oop shapehierarchy")
+	fmt.Println("  interview-challenges oop bankingsystem serve --addr localhost:8080")
+	fmt.Println("  interview-challenges oop bankingsystem serve --data bank.json --rules rules.json --rates rates.json")
 	fmt.Println("  interview-challenges datastructures linkedlist")
+	fmt.Println("  interview-challenges datastructures graph --input graph.dot shortest a b")
+	fmt.Println("  interview-challenges datastructures graph flow network.txt s t")
+	fmt.Println("  interview-challenges datastructures graph matching assignments.txt")
 	fmt.Println("  interview-challenges list")
 }
@@ -0,0 +1,384 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNotConverged is returned by iterative algorithms that hit their iteration limit
+var ErrNotConverged = errors.New("algorithm did not converge")
+
+// ErrZeroWeightCycle is returned when zero-weight edges form a cycle, giving a
+// vertex infinitely many shortest paths
+var ErrZeroWeightCycle = errors.New("graph has a cycle of zero-weight edges")
+
+// shortestPathDAG holds the shortest paths from one source: the distance to
+// and number of shortest paths to every reachable vertex, each vertex's
+// predecessors on those paths and the vertices in an order where every vertex
+// follows its predecessors. Vertices on a zero-weight cycle have no number of
+// paths and are left out of order.
+type shortestPathDAG[V comparable] struct {
+	order []V
+	dist  map[V]float64
+	sigma map[V]float64
+	preds map[V][]V
+}
+
+// shortestPathsFrom counts the shortest paths from source, by hop count or,
+// if weighted, by edge weight using Dijkstra's algorithm. Weighted searches
+// require non-negative weights. Paths are only counted once every distance
+// is known, because a zero-weight edge can lead to a vertex at the same
+// distance that Dijkstra's algorithm has already settled.
+func (g *Graph[V, E]) shortestPathsFrom(source V, weighted bool) *shortestPathDAG[V] {
+	dag := &shortestPathDAG[V]{
+		dist:  map[V]float64{source: 0},
+		sigma: map[V]float64{source: 1},
+		preds: make(map[V][]V),
+	}
+
+	weight := func(u, v V) float64 { return 1 }
+	if weighted {
+		weight = g.weightOf
+	}
+
+	// Lazy-deletion priority queue; with unit weights it settles vertices in BFS order
+	pq := PriorityQueue[V]{}
+	heap.Push(&pq, &Item[V]{vertex: source})
+	settled := make(map[V]bool)
+	reached := make([]V, 0)
+
+	for pq.Len() > 0 {
+		item := heap.Pop(&pq).(*Item[V])
+		u := item.vertex
+		if settled[u] || item.priority > dag.dist[u] {
+			continue
+		}
+		settled[u] = true
+		reached = append(reached, u)
+
+		for _, v := range g.neighbors[u] {
+			alt := dag.dist[u] + weight(u, v)
+			if current, seen := dag.dist[v]; !seen || alt < current {
+				dag.dist[v] = alt
+				heap.Push(&pq, &Item[V]{vertex: v, priority: alt})
+			}
+		}
+	}
+
+	// Every edge that keeps to a shortest distance joins a predecessor to a successor
+	successors := make(map[V][]V)
+	for _, u := range reached {
+		for _, v := range g.neighbors[u] {
+			if dag.dist[u]+weight(u, v) == dag.dist[v] {
+				dag.preds[v] = append(dag.preds[v], u)
+				successors[u] = append(successors[u], v)
+			}
+		}
+	}
+
+	// Count paths in topological order, taking each vertex once all of its
+	// predecessors have been counted
+	waiting := make(map[V]int, len(reached))
+	for _, v := range reached {
+		waiting[v] = len(dag.preds[v])
+	}
+	if waiting[source] > 0 {
+		// The source is on a zero-weight cycle
+		return dag
+	}
+	dag.order = append(dag.order, source)
+	for i := 0; i < len(dag.order); i++ {
+		u := dag.order[i]
+		for _, v := range successors[u] {
+			dag.sigma[v] += dag.sigma[u]
+			if waiting[v]--; waiting[v] == 0 {
+				dag.order = append(dag.order, v)
+			}
+		}
+	}
+
+	return dag
+}
+
+// checkWeights rejects negative weights for weighted path-based metrics
+func (g *Graph[V, E]) checkWeights(weighted bool) error {
+	if weighted && g.hasNegativeWeight() {
+		return ErrNegativeWeight
+	}
+	return nil
+}
+
+// DegreeCentrality returns the number of edges at each vertex divided by
+// n-1, the most it could have. In a directed graph both incoming and
+// outgoing edges count.
+func (g *Graph[V, E]) DegreeCentrality() map[V]float64 {
+	in := g.InDegreeCentrality()
+	out := g.OutDegreeCentrality()
+
+	result := make(map[V]float64, len(g.vertices))
+	for v := range g.vertices {
+		result[v] = out[v]
+		if g.isDirected {
+			result[v] += in[v]
+		}
+	}
+	return result
+}
+
+// InDegreeCentrality returns the number of incoming edges of each vertex divided by n-1
+func (g *Graph[V, E]) InDegreeCentrality() map[V]float64 {
+	inDegree := g.inDegrees()
+
+	result := make(map[V]float64, len(g.vertices))
+	for v := range g.vertices {
+		result[v] = normalizeDegree(inDegree[v], len(g.vertices))
+	}
+	return result
+}
+
+// OutDegreeCentrality returns the number of outgoing edges of each vertex divided by n-1
+func (g *Graph[V, E]) OutDegreeCentrality() map[V]float64 {
+	result := make(map[V]float64, len(g.vertices))
+	for v := range g.vertices {
+		result[v] = normalizeDegree(len(g.neighbors[v]), len(g.vertices))
+	}
+	return result
+}
+
+func normalizeDegree(degree, n int) float64 {
+	if n <= 1 {
+		return 0
+	}
+	return float64(degree) / float64(n-1)
+}
+
+// ClosenessCentrality returns, for each vertex, how close it is to the
+// vertices it can reach: the number of reachable vertices divided by the
+// sum of distances to them. The value is scaled by the fraction of the
+// graph that is reachable (the Wasserman-Faust variant), so it stays
+// comparable in disconnected graphs. Distances follow edge direction and are
+// hop counts, or edge weights if weighted is set.
+func (g *Graph[V, E]) ClosenessCentrality(weighted bool) (map[V]float64, error) {
+	if err := g.checkWeights(weighted); err != nil {
+		return nil, err
+	}
+
+	vertices := g.GetVertices()
+	n := len(vertices)
+	result := make(map[V]float64, n)
+
+	for _, v := range vertices {
+		paths := g.shortestPathsFrom(v, weighted)
+
+		// Sum in a fixed order so results don't change between runs
+		total := 0.0
+		for _, w := range vertices {
+			total += paths.dist[w]
+		}
+		reachable := float64(len(paths.dist) - 1)
+		if total > 0 && n > 1 {
+			result[v] = (reachable / total) * (reachable / float64(n-1))
+		} else {
+			result[v] = 0
+		}
+	}
+
+	return result, nil
+}
+
+// BetweennessCentrality returns, for each vertex, the fraction of shortest
+// paths between other pairs of vertices that pass through it, using Brandes'
+// algorithm in O(V * E) time for unweighted graphs. Values are normalized to
+// [0, 1] by the number of pairs that don't include the vertex. Paths are
+// measured in hops, or by edge weight if weighted is set. Zero weights are
+// allowed, but ErrZeroWeightCycle is returned if they form a cycle.
+func (g *Graph[V, E]) BetweennessCentrality(weighted bool) (map[V]float64, error) {
+	if err := g.checkWeights(weighted); err != nil {
+		return nil, err
+	}
+
+	result := make(map[V]float64, len(g.vertices))
+	for v := range g.vertices {
+		result[v] = 0
+	}
+
+	// Sources are taken in a fixed order so the sums don't change between runs
+	for _, s := range g.GetVertices() {
+		paths := g.shortestPathsFrom(s, weighted)
+		if len(paths.order) < len(paths.dist) {
+			return nil, ErrZeroWeightCycle
+		}
+
+		// Accumulate dependencies in order of decreasing distance
+		delta := make(map[V]float64, len(paths.order))
+		for i := len(paths.order) - 1; i >= 0; i-- {
+			w := paths.order[i]
+			for _, v := range paths.preds[w] {
+				delta[v] += paths.sigma[v] / paths.sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				result[w] += delta[w]
+			}
+		}
+	}
+
+	n := float64(len(g.vertices))
+	if n <= 2 {
+		return result, nil
+	}
+
+	// Every ordered pair of other vertices was counted once, so an undirected
+	// pair is counted from both ends; either way there are (n-1)(n-2) of them
+	scale := 1 / ((n - 1) * (n - 2))
+	for v := range result {
+		result[v] *= scale
+	}
+
+	return result, nil
+}
+
+// PageRank ranks vertices by the stationary distribution of a random surfer
+// who follows an outgoing edge with probability damping and jumps to a
+// random vertex otherwise. Edges are followed in proportion to their weight,
+// and vertices without outgoing edges spread their rank over all vertices.
+// Iteration stops once the total change in rank drops below tolerance;
+// ErrNotConverged is returned with the latest ranks if that takes more than
+// maxIterations. Ranks sum to 1.
+func (g *Graph[V, E]) PageRank(damping, tolerance float64, maxIterations int) (map[V]float64, error) {
+	if damping < 0 || damping >= 1 {
+		return nil, fmt.Errorf("damping must be in [0, 1), got %g", damping)
+	}
+	if g.hasNegativeWeight() {
+		return nil, ErrNegativeWeight
+	}
+
+	vertices := g.GetVertices()
+	n := float64(len(vertices))
+	rank := make(map[V]float64, len(vertices))
+	if len(vertices) == 0 {
+		return rank, nil
+	}
+
+	// Total outgoing weight of every vertex
+	outWeight := make(map[V]float64, len(vertices))
+	for _, v := range vertices {
+		for _, w := range g.neighbors[v] {
+			outWeight[v] += g.weightOf(v, w)
+		}
+	}
+
+	for _, v := range vertices {
+		rank[v] = 1 / n
+	}
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		// Rank held by dangling vertices is spread evenly
+		dangling := 0.0
+		for _, v := range vertices {
+			if outWeight[v] == 0 {
+				dangling += rank[v]
+			}
+		}
+
+		base := (1-damping)/n + damping*dangling/n
+		next := make(map[V]float64, len(vertices))
+		for _, v := range vertices {
+			next[v] += base
+			if outWeight[v] == 0 {
+				continue
+			}
+			for _, w := range g.neighbors[v] {
+				next[w] += damping * rank[v] * g.weightOf(v, w) / outWeight[v]
+			}
+		}
+
+		change := 0.0
+		for _, v := range vertices {
+			change += math.Abs(next[v] - rank[v])
+		}
+		rank = next
+
+		if change < tolerance {
+			return rank, nil
+		}
+	}
+
+	return rank, ErrNotConverged
+}
+
+// ClusteringCoefficient returns the fraction of pairs of a vertex's
+// neighbours that are themselves connected, ignoring edge direction.
+// Vertices with fewer than two neighbours have a coefficient of 0.
+func (g *Graph[V, E]) ClusteringCoefficient(vertex V) (float64, error) {
+	if !g.HasVertex(vertex) {
+		return 0, fmt.Errorf("vertex %v does not exist", vertex)
+	}
+	return g.clustering(vertex, g.undirectedNeighbors()), nil
+}
+
+// AverageClustering returns the mean clustering coefficient over all vertices
+func (g *Graph[V, E]) AverageClustering() float64 {
+	if len(g.vertices) == 0 {
+		return 0
+	}
+
+	adjacency := g.undirectedNeighbors()
+	total := 0.0
+	for _, v := range g.GetVertices() {
+		total += g.clustering(v, adjacency)
+	}
+	return total / float64(len(g.vertices))
+}
+
+// clustering computes a local clustering coefficient from undirected adjacency
+func (g *Graph[V, E]) clustering(vertex V, adjacency map[V][]V) float64 {
+	neighbors := adjacency[vertex]
+	k := len(neighbors)
+	if k < 2 {
+		return 0
+	}
+
+	links := 0
+	for i := 0; i < k; i++ {
+		for j := i + 1; j < k; j++ {
+			if g.HasEdge(neighbors[i], neighbors[j]) || g.HasEdge(neighbors[j], neighbors[i]) {
+				links++
+			}
+		}
+	}
+
+	return 2 * float64(links) / float64(k*(k-1))
+}
+
+// Diameter returns the longest shortest-path distance between any two
+// vertices, in hops or, if weighted is set, by edge weight. Every vertex must
+// be able to reach every other one, otherwise ErrGraphNotConnected is returned.
+func (g *Graph[V, E]) Diameter(weighted bool) (float64, error) {
+	if err := g.checkWeights(weighted); err != nil {
+		return 0, err
+	}
+
+	diameter := 0.0
+	for v := range g.vertices {
+		paths := g.shortestPathsFrom(v, weighted)
+		if len(paths.dist) < len(g.vertices) {
+			return 0, ErrGraphNotConnected
+		}
+		for _, d := range paths.dist {
+			diameter = math.Max(diameter, d)
+		}
+	}
+
+	return diameter, nil
+}
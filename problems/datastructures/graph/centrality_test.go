@@ -0,0 +1,241 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// approxEqual compares floats with a small tolerance
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// assertScores checks a centrality map against expected values
+func assertScores(t *testing.T, name string, got, expected map[string]float64) {
+	t.Helper()
+	for v, want := range expected {
+		if !approxEqual(got[v], want) {
+			t.Errorf("%s[%s]: expected %.4f, got %.4f", name, v, want, got[v])
+		}
+	}
+}
+
+// buildUndirected creates an undirected graph from vertex pairs
+func buildUndirected(pairs ...[2]string) *Graph[string, struct{}] {
+	g := New[string, struct{}](false)
+	for _, p := range pairs {
+		g.AddVertex(p[0])
+		g.AddVertex(p[1])
+		g.AddEdge(p[0], p[1])
+	}
+	return g
+}
+
+func TestDegreeCentrality(t *testing.T) {
+	star := buildUndirected([2]string{"hub", "a"}, [2]string{"hub", "b"}, [2]string{"hub", "c"})
+	assertScores(t, "degree", star.DegreeCentrality(), map[string]float64{"hub": 1, "a": 1.0 / 3})
+
+	g := New[string, struct{}](true)
+	for _, v := range []string{"a", "b", "c"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("a", "b")
+	g.AddEdge("c", "b")
+
+	assertScores(t, "in", g.InDegreeCentrality(), map[string]float64{"a": 0, "b": 1})
+	assertScores(t, "out", g.OutDegreeCentrality(), map[string]float64{"a": 0.5, "b": 0})
+	assertScores(t, "degree", g.DegreeCentrality(), map[string]float64{"a": 0.5, "b": 1})
+}
+
+func TestClosenessCentrality(t *testing.T) {
+	path := buildUndirected([2]string{"a", "b"}, [2]string{"b", "c"})
+	path.AddVertex("isolated")
+
+	scores, err := path.ClosenessCentrality(false)
+	if err != nil {
+		t.Fatalf("ClosenessCentrality failed: %v", err)
+	}
+
+	// b reaches 2 of 3 other vertices at total distance 2
+	assertScores(t, "closeness", scores, map[string]float64{
+		"b":        (2.0 / 2) * (2.0 / 3),
+		"a":        (2.0 / 3) * (2.0 / 3),
+		"isolated": 0,
+	})
+
+	path.AddEdge("a", "b", 4)
+	weighted, _ := path.ClosenessCentrality(true)
+	assertScores(t, "weighted closeness", weighted, map[string]float64{"a": (2.0 / 9) * (2.0 / 3)})
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	star := buildUndirected([2]string{"hub", "a"}, [2]string{"hub", "b"}, [2]string{"hub", "c"})
+	scores, err := star.BetweennessCentrality(false)
+	if err != nil {
+		t.Fatalf("BetweennessCentrality failed: %v", err)
+	}
+	assertScores(t, "star", scores, map[string]float64{"hub": 1, "a": 0})
+
+	// Two equal routes from a to d share the load
+	square := buildUndirected([2]string{"a", "b"}, [2]string{"b", "d"}, [2]string{"a", "c"}, [2]string{"c", "d"})
+	scores, _ = square.BetweennessCentrality(false)
+	assertScores(t, "square", scores, map[string]float64{"b": 1.0 / 6, "c": 1.0 / 6})
+
+	// Making c-d heavy sends weighted paths from c to d through a and b
+	square.AddEdge("c", "d", 10)
+	scores, _ = square.BetweennessCentrality(true)
+	assertScores(t, "weighted square", scores, map[string]float64{"a": 2.0 / 3, "b": 2.0 / 3, "c": 0})
+
+	chain := New[string, struct{}](true)
+	for _, v := range []string{"a", "b", "c"} {
+		chain.AddVertex(v)
+	}
+	chain.AddEdge("a", "b")
+	chain.AddEdge("b", "c")
+	scores, _ = chain.BetweennessCentrality(false)
+	assertScores(t, "directed chain", scores, map[string]float64{"a": 0, "b": 0.5, "c": 0})
+
+	chain.AddEdge("a", "c", -1)
+	if _, err := chain.BetweennessCentrality(true); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestBetweennessZeroWeights(t *testing.T) {
+	g := New[string, struct{}](true)
+	for _, v := range []string{"s", "x", "y", "p", "q"} {
+		g.AddVertex(v)
+	}
+
+	// Each zero-weight edge adds a second shortest path of length 1, whichever
+	// of its ends is settled first
+	g.AddEdge("s", "x", 1)
+	g.AddEdge("s", "y", 1)
+	g.AddEdge("x", "y", 0)
+	g.AddEdge("s", "p", 1)
+	g.AddEdge("s", "q", 1)
+	g.AddEdge("q", "p", 0)
+
+	scores, err := g.BetweennessCentrality(true)
+	if err != nil {
+		t.Fatalf("BetweennessCentrality failed: %v", err)
+	}
+	assertScores(t, "zero weights", scores, map[string]float64{"x": 1.0 / 24, "q": 1.0 / 24, "y": 0, "p": 0})
+
+	g.AddEdge("y", "x", 0)
+	if _, err := g.BetweennessCentrality(true); !errors.Is(err, ErrZeroWeightCycle) {
+		t.Errorf("Expected ErrZeroWeightCycle, got %v", err)
+	}
+}
+
+func TestCentralityIsDeterministic(t *testing.T) {
+	g := New[int, struct{}](true)
+	for i := 0; i < 40; i++ {
+		g.AddVertex(i)
+	}
+	for i := 0; i < 40; i++ {
+		g.AddEdge(i, (i*7+3)%40, float64(i%5)+0.1)
+		g.AddEdge(i, (i*11+5)%40, float64(i%3)+0.7)
+	}
+
+	betweenness, _ := g.BetweennessCentrality(true)
+	closeness, _ := g.ClosenessCentrality(true)
+	clustering := g.AverageClustering()
+	for run := 0; run < 10; run++ {
+		again, _ := g.BetweennessCentrality(true)
+		for v, score := range betweenness {
+			if again[v] != score {
+				t.Fatalf("Betweenness of %d changed between runs: %v and %v", v, score, again[v])
+			}
+		}
+		again, _ = g.ClosenessCentrality(true)
+		for v, score := range closeness {
+			if again[v] != score {
+				t.Fatalf("Closeness of %d changed between runs: %v and %v", v, score, again[v])
+			}
+		}
+		if again := g.AverageClustering(); again != clustering {
+			t.Fatalf("Average clustering changed between runs: %v and %v", clustering, again)
+		}
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	g := New[string, struct{}](true)
+	for _, v := range []string{"a", "b", "c", "d"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "a")
+
+	ranks, err := g.PageRank(0.85, 1e-10, 200)
+	if err != nil {
+		t.Fatalf("PageRank failed: %v", err)
+	}
+
+	total := 0.0
+	for _, r := range ranks {
+		total += r
+	}
+	if !approxEqual(total, 1) {
+		t.Errorf("Expected ranks to sum to 1, got %.6f", total)
+	}
+	if !approxEqual(ranks["a"], ranks["b"]) || ranks["a"] <= ranks["d"] {
+		t.Errorf("Expected equal ranks on the cycle above the dangling vertex, got %v", ranks)
+	}
+
+	// A heavier edge draws more rank
+	g.AddEdge("d", "a", 1)
+	g.AddEdge("d", "b", 9)
+	ranks, _ = g.PageRank(0.85, 1e-10, 200)
+	if ranks["b"] <= ranks["a"] {
+		t.Errorf("Expected b to outrank a, got %v", ranks)
+	}
+
+	if _, err := g.PageRank(0.85, 1e-12, 1); !errors.Is(err, ErrNotConverged) {
+		t.Errorf("Expected ErrNotConverged, got %v", err)
+	}
+	if _, err := g.PageRank(1, 1e-6, 100); err == nil {
+		t.Error("PageRank should reject damping of 1")
+	}
+}
+
+func TestClusteringAndDiameter(t *testing.T) {
+	// Triangle a-b-c with a tail c-d
+	g := buildUndirected([2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"}, [2]string{"c", "d"})
+
+	tests := map[string]float64{"a": 1, "c": 1.0 / 3, "d": 0}
+	for v, expected := range tests {
+		got, err := g.ClusteringCoefficient(v)
+		if err != nil || !approxEqual(got, expected) {
+			t.Errorf("ClusteringCoefficient(%s): expected %.4f, got %.4f (err: %v)", v, expected, got, err)
+		}
+	}
+	if avg := g.AverageClustering(); !approxEqual(avg, (1+1+1.0/3)/4) {
+		t.Errorf("Unexpected average clustering %.4f", avg)
+	}
+
+	if d, err := g.Diameter(false); err != nil || d != 2 {
+		t.Errorf("Expected diameter 2, got %v (err: %v)", d, err)
+	}
+	g.AddEdge("c", "d", 5)
+	if d, _ := g.Diameter(true); d != 6 {
+		t.Errorf("Expected weighted diameter 6, got %v", d)
+	}
+
+	g.AddVertex("e")
+	if _, err := g.Diameter(false); !errors.Is(err, ErrGraphNotConnected) {
+		t.Errorf("Expected ErrGraphNotConnected, got %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// ConcurrentGraph is a graph that is safe for concurrent use. Reads share an
+// RWMutex read lock and writes take it exclusively. Algorithms that are not
+// wrapped here can run under the read lock with Read, or on a private copy
+// taken with Snapshot.
+type ConcurrentGraph[V comparable, E any] struct {
+	mu    sync.RWMutex
+	graph *Graph[V, E]
+}
+
+// NewConcurrentGraph creates a new concurrent graph (directed or undirected)
+func NewConcurrentGraph[V comparable, E any](isDirected bool) *ConcurrentGraph[V, E] {
+	return &ConcurrentGraph[V, E]{graph: New[V, E](isDirected)}
+}
+
+// NewConcurrentGraphFrom wraps a copy of an existing graph
+func NewConcurrentGraphFrom[V comparable, E any](g *Graph[V, E]) *ConcurrentGraph[V, E] {
+	return &ConcurrentGraph[V, E]{graph: g.Clone()}
+}
+
+// AddVertex adds a vertex to the graph
+func (c *ConcurrentGraph[V, E]) AddVertex(vertex V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.graph.AddVertex(vertex)
+}
+
+// AddEdge adds an edge between two vertices with an optional weight
+func (c *ConcurrentGraph[V, E]) AddEdge(from, to V, weight ...float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.graph.AddEdge(from, to, weight...)
+}
+
+// AddLabeledEdge adds an edge carrying a label between two vertices
+func (c *ConcurrentGraph[V, E]) AddLabeledEdge(from, to V, label E, weight ...float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.graph.AddLabeledEdge(from, to, label, weight...)
+}
+
+// RemoveEdge removes an edge between two vertices
+func (c *ConcurrentGraph[V, E]) RemoveEdge(from, to V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.graph.RemoveEdge(from, to)
+}
+
+// RemoveVertex removes a vertex and all its edges
+func (c *ConcurrentGraph[V, E]) RemoveVertex(vertex V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.graph.RemoveVertex(vertex)
+}
+
+// Update applies several changes atomically; readers never see a partial update
+func (c *ConcurrentGraph[V, E]) Update(fn func(g *Graph[V, E]) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fn(c.graph)
+}
+
+// HasVertex checks if a vertex exists in the graph
+func (c *ConcurrentGraph[V, E]) HasVertex(vertex V) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.HasVertex(vertex)
+}
+
+// HasEdge checks if an edge exists between two vertices
+func (c *ConcurrentGraph[V, E]) HasEdge(from, to V) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.HasEdge(from, to)
+}
+
+// GetNeighbors returns the neighbours of a vertex
+func (c *ConcurrentGraph[V, E]) GetNeighbors(vertex V) ([]V, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.GetNeighbors(vertex)
+}
+
+// GetVertices returns all vertices in sorted order
+func (c *ConcurrentGraph[V, E]) GetVertices() []V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.GetVertices()
+}
+
+// GetEdges returns all edges in the graph
+func (c *ConcurrentGraph[V, E]) GetEdges() []Edge[V, E] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.GetEdges()
+}
+
+// VertexCount returns the number of vertices
+func (c *ConcurrentGraph[V, E]) VertexCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.VertexCount()
+}
+
+// EdgeCount returns the number of edges
+func (c *ConcurrentGraph[V, E]) EdgeCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.EdgeCount()
+}
+
+// BFS performs breadth-first search from a starting vertex
+func (c *ConcurrentGraph[V, E]) BFS(start V) ([]V, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.BFS(start)
+}
+
+// ParallelBFS performs a level-synchronous parallel breadth-first search
+func (c *ConcurrentGraph[V, E]) ParallelBFS(start V, workers int) ([]V, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.ParallelBFS(start, workers)
+}
+
+// Read runs fn under the read lock. fn must not modify the graph or keep
+// a reference to it after returning.
+func (c *ConcurrentGraph[V, E]) Read(fn func(g *Graph[V, E])) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	fn(c.graph)
+}
+
+// Snapshot returns a private copy of the graph that later writes don't affect
+func (c *ConcurrentGraph[V, E]) Snapshot() *Graph[V, E] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.Clone()
+}
+
+// ParallelBFS performs breadth-first search one level at a time, splitting
+// each frontier between worker goroutines. Workers only read the graph; the
+// discovered neighbours are merged in frontier order, so the result is
+// identical to BFS. With workers <= 0 it uses GOMAXPROCS workers.
+//
+// The graph must not be modified while the search runs; use ConcurrentGraph
+// when writers may be active.
+func (g *Graph[V, E]) ParallelBFS(start V, workers int) ([]V, error) {
+	if !g.HasVertex(start) {
+		return nil, fmt.Errorf("vertex %v does not exist", start)
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	visited := map[V]bool{start: true}
+	result := []V{start}
+	frontier := []V{start}
+
+	for len(frontier) > 0 {
+		// candidates[i] holds the unvisited neighbours of frontier[i]
+		candidates := make([][]V, len(frontier))
+		chunk := (len(frontier) + workers - 1) / workers
+
+		var wg sync.WaitGroup
+		for lo := 0; lo < len(frontier); lo += chunk {
+			hi := min(lo+chunk, len(frontier))
+			wg.Add(1)
+			go func(lo, hi int) {
+				defer wg.Done()
+				for i := lo; i < hi; i++ {
+					for _, neighbor := range g.neighbors[frontier[i]] {
+						// visited is only written between levels, so reading it here is safe
+						if !visited[neighbor] {
+							candidates[i] = append(candidates[i], neighbor)
+						}
+					}
+				}
+			}(lo, hi)
+		}
+		wg.Wait()
+
+		// Merge sequentially so that a vertex reachable from several frontier
+		// vertices is claimed by the first one, exactly as in BFS
+		next := make([]V, 0)
+		for _, group := range candidates {
+			for _, v := range group {
+				if !visited[v] {
+					visited[v] = true
+					next = append(next, v)
+				}
+			}
+		}
+
+		result = append(result, next...)
+		frontier = next
+	}
+
+	return result, nil
+}
@@ -0,0 +1,130 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// buildRandomGraph creates a reproducible random graph
+func buildRandomGraph(directed bool, vertices, edges int, seed int64) *Graph[int, struct{}] {
+	rng := rand.New(rand.NewSource(seed))
+	g := New[int, struct{}](directed)
+	for i := 0; i < vertices; i++ {
+		g.AddVertex(i)
+	}
+	for i := 0; i < edges; i++ {
+		g.AddEdge(rng.Intn(vertices), rng.Intn(vertices))
+	}
+	return g
+}
+
+func TestParallelBFSMatchesBFS(t *testing.T) {
+	for _, directed := range []bool{true, false} {
+		g := buildRandomGraph(directed, 2000, 6000, 42)
+
+		expected, _ := g.BFS(0)
+		for _, workers := range []int{0, 1, 3, 16} {
+			got, err := g.ParallelBFS(0, workers)
+			if err != nil {
+				t.Fatalf("ParallelBFS failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("directed=%v workers=%d: ParallelBFS order differs from BFS", directed, workers)
+			}
+		}
+	}
+
+	if _, err := New[int, struct{}](true).ParallelBFS(0, 4); err == nil {
+		t.Error("ParallelBFS should fail for a missing vertex")
+	}
+}
+
+func TestConcurrentGraphOperations(t *testing.T) {
+	c := NewConcurrentGraph[string, string](true)
+	c.AddVertex("a")
+	c.AddVertex("b")
+	c.AddLabeledEdge("a", "b", "link", 2)
+
+	if !c.HasEdge("a", "b") || c.VertexCount() != 2 || c.EdgeCount() != 1 {
+		t.Errorf("Unexpected graph state: %v", c.GetEdges())
+	}
+
+	snapshot := c.Snapshot()
+	c.RemoveVertex("b")
+	if !snapshot.HasEdge("a", "b") {
+		t.Error("Snapshot should not see later writes")
+	}
+	if c.HasVertex("b") {
+		t.Error("Expected b to be removed")
+	}
+
+	err := c.Update(func(g *Graph[string, string]) error {
+		g.AddVertex("c")
+		return g.AddEdge("a", "c")
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	var order []string
+	c.Read(func(g *Graph[string, string]) {
+		order, _ = g.DFS("a")
+	})
+	if !reflect.DeepEqual(order, []string{"a", "c"}) {
+		t.Errorf("Expected [a c], got %v", order)
+	}
+}
+
+// TestConcurrentGraphReadersAndWriters is meant to be run with -race
+func TestConcurrentGraphReadersAndWriters(t *testing.T) {
+	c := NewConcurrentGraphFrom(buildRandomGraph(false, 500, 1500, 7))
+	expected, _ := c.BFS(0)
+
+	var wg sync.WaitGroup
+
+	// Writers only touch vertices that are unreachable from 0
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				v := 1000 + w*100 + i
+				c.AddVertex(v)
+				if i > 0 {
+					c.AddEdge(v-1, v)
+				}
+			}
+		}(w)
+	}
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				got, err := c.ParallelBFS(0, 4)
+				if err != nil || !reflect.DeepEqual(got, expected) {
+					t.Errorf("ParallelBFS result changed under concurrent writes")
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if c.VertexCount() != 900 {
+		t.Errorf("Expected 900 vertices, got %d", c.VertexCount())
+	}
+}
@@ -0,0 +1,374 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import "sort"
+
+// Components is a partition of vertices into groups, such as the strongly
+// connected components of a graph. Each group is sorted, and groups are
+// ordered by their first vertex.
+type Components[V comparable] struct {
+	Groups [][]V
+	index  map[V]int
+}
+
+// newComponents sorts the groups and indexes their members
+func newComponents[V comparable](groups [][]V, less func(a, b V) bool) *Components[V] {
+	for _, group := range groups {
+		sortVertices(group, less)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return less(groups[i][0], groups[j][0])
+	})
+
+	index := make(map[V]int)
+	for i, group := range groups {
+		for _, v := range group {
+			index[v] = i
+		}
+	}
+
+	return &Components[V]{Groups: groups, index: index}
+}
+
+// Count returns the number of components
+func (c *Components[V]) Count() int {
+	return len(c.Groups)
+}
+
+// ComponentOf returns the index in Groups of the component containing a vertex
+func (c *Components[V]) ComponentOf(vertex V) (int, bool) {
+	i, exists := c.index[vertex]
+	return i, exists
+}
+
+// SameComponent checks if two vertices belong to the same component
+func (c *Components[V]) SameComponent(a, b V) bool {
+	i, okA := c.index[a]
+	j, okB := c.index[b]
+	return okA && okB && i == j
+}
+
+// TarjanSCC finds the strongly connected components of the graph with
+// Tarjan's single-pass algorithm. In an undirected graph these are simply
+// the connected components.
+func (g *Graph[V, E]) TarjanSCC() *Components[V] {
+	index := make(map[V]int)
+	low := make(map[V]int)
+	onStack := make(map[V]bool)
+	stack := make([]V, 0)
+	parent := make(map[V]V)
+	groups := make([][]V, 0)
+	counter := 0
+
+	g.WalkDFSAll(Visitor[V]{
+		PreVisit: func(v V) bool {
+			index[v] = counter
+			low[v] = counter
+			counter++
+			stack = append(stack, v)
+			onStack[v] = true
+			return true
+		},
+		Edge: func(v, w V, kind EdgeType) bool {
+			if kind == TreeEdge {
+				parent[w] = v
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+			return true
+		},
+		PostVisit: func(v V) bool {
+			// v is the root of a component: pop it off the stack
+			if low[v] == index[v] {
+				group := make([]V, 0)
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					group = append(group, w)
+					if w == v {
+						break
+					}
+				}
+				groups = append(groups, group)
+			}
+			if p, ok := parent[v]; ok {
+				low[p] = min(low[p], low[v])
+			}
+			return true
+		},
+	})
+
+	return newComponents(groups, g.less)
+}
+
+// KosarajuSCC finds the strongly connected components of the graph with
+// Kosaraju's two-pass algorithm: a DFS to order vertices by finish time,
+// then a DFS over the reversed graph in reverse finish order.
+func (g *Graph[V, E]) KosarajuSCC() *Components[V] {
+	vertices := g.GetVertices()
+
+	// First pass: record vertices in order of DFS completion
+	finished := make([]V, 0, len(vertices))
+	g.WalkDFSAll(Visitor[V]{
+		PostVisit: func(v V) bool {
+			finished = append(finished, v)
+			return true
+		},
+	})
+
+	// Build the reversed graph
+	reversed := make(map[V][]V, len(vertices))
+	for _, v := range vertices {
+		for _, w := range g.neighbors[v] {
+			reversed[w] = append(reversed[w], v)
+		}
+	}
+
+	// Second pass: each search over the reversed graph collects one component.
+	// Only membership matters here, so any traversal order will do.
+	assigned := make(map[V]bool)
+	groups := make([][]V, 0)
+
+	for i := len(finished) - 1; i >= 0; i-- {
+		v := finished[i]
+		if assigned[v] {
+			continue
+		}
+
+		group := make([]V, 0)
+		stack := []V{v}
+		assigned[v] = true
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			group = append(group, u)
+			for _, w := range reversed[u] {
+				if !assigned[w] {
+					assigned[w] = true
+					stack = append(stack, w)
+				}
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	return newComponents(groups, g.less)
+}
+
+// IsStronglyConnected checks if every vertex can reach every other vertex
+func (g *Graph[V, E]) IsStronglyConnected() bool {
+	return g.TarjanSCC().Count() <= 1
+}
+
+// undirectedNeighbors returns the sorted neighbours of every vertex when edge
+// direction is ignored. Self-loops are dropped.
+func (g *Graph[V, E]) undirectedNeighbors() map[V][]V {
+	result := make(map[V][]V, len(g.vertices))
+	for _, v := range g.GetVertices() {
+		for _, w := range g.neighbors[v] {
+			if v == w {
+				continue
+			}
+			result[v] = append(result[v], w)
+			if g.isDirected && !g.HasEdge(w, v) {
+				result[w] = append(result[w], v)
+			}
+		}
+	}
+
+	if g.isDirected {
+		for v := range result {
+			sortVertices(result[v], g.less)
+		}
+	}
+
+	return result
+}
+
+// WeaklyConnectedComponents finds the connected components of the graph when
+// edge direction is ignored. For undirected graphs these are the connected components.
+func (g *Graph[V, E]) WeaklyConnectedComponents() *Components[V] {
+	adjacency := g.undirectedNeighbors()
+	visited := make(map[V]bool)
+	groups := make([][]V, 0)
+
+	for _, v := range g.GetVertices() {
+		if visited[v] {
+			continue
+		}
+
+		group := make([]V, 0)
+		stack := []V{v}
+		visited[v] = true
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			group = append(group, u)
+			for _, w := range adjacency[u] {
+				if !visited[w] {
+					visited[w] = true
+					stack = append(stack, w)
+				}
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	return newComponents(groups, g.less)
+}
+
+// biconnectivity holds the result of a Hopcroft-Tarjan lowlink search
+type biconnectivity[V comparable] struct {
+	articulationPoints []V
+	bridges            [][2]V
+	components         [][]V
+}
+
+// biconnectivity runs a single lowlink DFS over the graph with edge direction
+// ignored, collecting articulation points, bridges and biconnected components
+func (g *Graph[V, E]) biconnectivity() biconnectivity[V] {
+	adjacency := g.undirectedNeighbors()
+	disc := make(map[V]int)
+	low := make(map[V]int)
+	isArticulation := make(map[V]bool)
+	edgeStack := make([][2]V, 0)
+	result := biconnectivity[V]{}
+	timer := 0
+
+	// popComponent pops edges up to and including (u, v) and records their vertices
+	popComponent := func(u, v V) {
+		seen := make(map[V]bool)
+		component := make([]V, 0)
+		for {
+			edge := edgeStack[len(edgeStack)-1]
+			edgeStack = edgeStack[:len(edgeStack)-1]
+			for _, x := range edge {
+				if !seen[x] {
+					seen[x] = true
+					component = append(component, x)
+				}
+			}
+			if edge[0] == u && edge[1] == v {
+				break
+			}
+		}
+		sortVertices(component, g.less)
+		result.components = append(result.components, component)
+	}
+
+	parent := make(map[V]V)
+	children := make(map[V]int)
+	visitor := Visitor[V]{
+		PreVisit: func(u V) bool {
+			disc[u] = timer
+			low[u] = timer
+			timer++
+			return true
+		},
+		Edge: func(u, v V, kind EdgeType) bool {
+			switch kind {
+			case TreeEdge:
+				children[u]++
+				parent[v] = u
+				edgeStack = append(edgeStack, [2]V{u, v})
+			case BackEdge:
+				// The walk skips the edge back to the parent, so this reaches an ancestor
+				edgeStack = append(edgeStack, [2]V{u, v})
+				low[u] = min(low[u], disc[v])
+			}
+			return true
+		},
+		PostVisit: func(v V) bool {
+			u, hasParent := parent[v]
+			if !hasParent {
+				return true
+			}
+			low[u] = min(low[u], low[v])
+
+			// Nothing below v reaches above u: u separates v's subtree
+			if low[v] >= disc[u] {
+				if _, uHasParent := parent[u]; uHasParent || children[u] > 1 {
+					isArticulation[u] = true
+				}
+				popComponent(u, v)
+			}
+			if low[v] > disc[u] {
+				result.bridges = append(result.bridges, [2]V{u, v})
+			}
+			return true
+		},
+	}
+
+	state := newDFSState[V]()
+	for _, v := range g.GetVertices() {
+		if state.color[v] == white {
+			walkDFS(v, adjacency, false, visitor, state)
+		}
+	}
+
+	for _, v := range g.GetVertices() {
+		if isArticulation[v] {
+			result.articulationPoints = append(result.articulationPoints, v)
+		}
+	}
+
+	return result
+}
+
+// ArticulationPoints returns the vertices whose removal disconnects part of
+// the graph, ignoring edge direction. In a service dependency graph these are
+// single points of failure.
+func (g *Graph[V, E]) ArticulationPoints() []V {
+	return g.biconnectivity().articulationPoints
+}
+
+// Bridges returns the edges whose removal disconnects part of the graph,
+// ignoring edge direction. Each edge is reported in a direction it exists in.
+func (g *Graph[V, E]) Bridges() []Edge[V, E] {
+	bridges := make([]Edge[V, E], 0)
+
+	for _, pair := range g.biconnectivity().bridges {
+		from, to := pair[0], pair[1]
+		if !g.HasEdge(from, to) || (!g.isDirected && g.less(to, from)) {
+			from, to = to, from
+		}
+		data := g.adjacencyList[from][to]
+		bridges = append(bridges, Edge[V, E]{From: from, To: to, Weight: data.weight, Label: data.label})
+	}
+
+	sort.SliceStable(bridges, func(i, j int) bool {
+		if bridges[i].From != bridges[j].From {
+			return g.less(bridges[i].From, bridges[j].From)
+		}
+		return g.less(bridges[i].To, bridges[j].To)
+	})
+
+	return bridges
+}
+
+// BiconnectedComponents groups the vertices into maximal subgraphs that stay
+// connected after removing any single vertex, ignoring edge direction.
+// Articulation points belong to more than one component, so the result is
+// not a partition; isolated vertices belong to none.
+func (g *Graph[V, E]) BiconnectedComponents() [][]V {
+	components := g.biconnectivity().components
+	sort.SliceStable(components, func(i, j int) bool {
+		a, b := components[i], components[j]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return g.less(a[k], b[k])
+			}
+		}
+		return len(a) < len(b)
+	})
+	return components
+}
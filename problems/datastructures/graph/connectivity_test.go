@@ -0,0 +1,179 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+// buildServiceGraph creates a directed service dependency graph with two
+// strongly connected groups joined through the gateway
+//
+//	auth <-> users -> gateway -> orders <-> payments
+//	                     |
+//	                     v
+//	                  metrics
+func buildServiceGraph() *Graph[string, struct{}] {
+	g := New[string, struct{}](true)
+	for _, v := range []string{"auth", "users", "gateway", "orders", "payments", "metrics"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("auth", "users")
+	g.AddEdge("users", "auth")
+	g.AddEdge("users", "gateway")
+	g.AddEdge("gateway", "orders")
+	g.AddEdge("orders", "payments")
+	g.AddEdge("payments", "orders")
+	g.AddEdge("gateway", "metrics")
+	return g
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := buildServiceGraph()
+
+	expected := [][]string{
+		{"auth", "users"},
+		{"gateway"},
+		{"metrics"},
+		{"orders", "payments"},
+	}
+
+	tarjan := g.TarjanSCC()
+	kosaraju := g.KosarajuSCC()
+
+	if !reflect.DeepEqual(tarjan.Groups, expected) {
+		t.Errorf("Tarjan: expected %v, got %v", expected, tarjan.Groups)
+	}
+	if !reflect.DeepEqual(kosaraju.Groups, expected) {
+		t.Errorf("Kosaraju: expected %v, got %v", expected, kosaraju.Groups)
+	}
+
+	if !tarjan.SameComponent("orders", "payments") || tarjan.SameComponent("users", "gateway") {
+		t.Error("Unexpected component membership")
+	}
+	if i, ok := tarjan.ComponentOf("metrics"); !ok || i != 2 {
+		t.Errorf("Expected metrics in component 2, got %d (found: %v)", i, ok)
+	}
+
+	if g.IsStronglyConnected() {
+		t.Error("Service graph should not be strongly connected")
+	}
+	g.AddEdge("metrics", "auth")
+	g.AddEdge("payments", "auth")
+	if !g.IsStronglyConnected() {
+		t.Error("Service graph should be strongly connected after adding back edges")
+	}
+}
+
+func TestWeaklyConnectedComponents(t *testing.T) {
+	g := buildServiceGraph()
+	g.AddVertex("billing")
+	g.AddVertex("invoices")
+	g.AddEdge("invoices", "billing")
+
+	components := g.WeaklyConnectedComponents()
+	if components.Count() != 2 {
+		t.Fatalf("Expected 2 weakly connected components, got %v", components.Groups)
+	}
+	if !components.SameComponent("auth", "metrics") || components.SameComponent("auth", "billing") {
+		t.Error("Unexpected weak component membership")
+	}
+}
+
+func TestArticulationPointsAndBridges(t *testing.T) {
+	g := buildServiceGraph()
+
+	points := g.ArticulationPoints()
+	expectedPoints := []string{"gateway", "orders", "users"}
+	if !reflect.DeepEqual(points, expectedPoints) {
+		t.Errorf("Expected articulation points %v, got %v", expectedPoints, points)
+	}
+
+	bridges := g.Bridges()
+	expectedBridges := [][2]string{
+		{"gateway", "metrics"},
+		{"gateway", "orders"},
+		{"auth", "users"},
+		{"orders", "payments"},
+		{"users", "gateway"},
+	}
+	if len(bridges) != len(expectedBridges) {
+		t.Fatalf("Expected %d bridges, got %v", len(expectedBridges), bridges)
+	}
+	found := make(map[[2]string]bool)
+	for _, b := range bridges {
+		if !g.HasEdge(b.From, b.To) {
+			t.Errorf("Bridge %v is not an edge of the graph", b)
+		}
+		found[[2]string{b.From, b.To}] = true
+	}
+	for _, b := range expectedBridges {
+		if !found[b] {
+			t.Errorf("Expected bridge %v in %v", b, bridges)
+		}
+	}
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	// Two triangles sharing vertex C, plus a tail C - F
+	g := New[string, struct{}](false)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F", "G"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("A", "B")
+	g.AddEdge("B", "C")
+	g.AddEdge("C", "A")
+	g.AddEdge("C", "D")
+	g.AddEdge("D", "E")
+	g.AddEdge("E", "C")
+	g.AddEdge("C", "F")
+
+	components := g.BiconnectedComponents()
+	expected := [][]string{
+		{"A", "B", "C"},
+		{"C", "D", "E"},
+		{"C", "F"},
+	}
+	if !reflect.DeepEqual(components, expected) {
+		t.Errorf("Expected biconnected components %v, got %v", expected, components)
+	}
+
+	if points := g.ArticulationPoints(); !reflect.DeepEqual(points, []string{"C"}) {
+		t.Errorf("Expected articulation point C, got %v", points)
+	}
+
+	bridges := g.Bridges()
+	if len(bridges) != 1 || bridges[0].From != "C" || bridges[0].To != "F" {
+		t.Errorf("Expected bridge C - F, got %v", bridges)
+	}
+}
+
+func TestConnectivityLongChain(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long chain")
+	}
+	const n = 200000
+	limitStack(t)
+
+	directed := buildChain(n, true)
+	if count := directed.TarjanSCC().Count(); count != n {
+		t.Errorf("Tarjan: expected %d components, got %d", n, count)
+	}
+	if count := directed.KosarajuSCC().Count(); count != n {
+		t.Errorf("Kosaraju: expected %d components, got %d", n, count)
+	}
+
+	result := buildChain(n, false).biconnectivity()
+	if len(result.articulationPoints) != n-2 || len(result.bridges) != n-1 {
+		t.Errorf("Expected %d articulation points and %d bridges, got %d and %d",
+			n-2, n-1, len(result.articulationPoints), len(result.bridges))
+	}
+}
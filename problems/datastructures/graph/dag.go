@@ -0,0 +1,289 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrUndirectedGraph is returned by algorithms that require a directed graph
+var ErrUndirectedGraph = errors.New("graph must be directed")
+
+// CycleError is returned when an algorithm that requires a DAG finds a cycle.
+// Cycle lists the vertices of the cycle in order, starting and ending with the same vertex.
+type CycleError[V comparable] struct {
+	Cycle []V
+}
+
+func (e *CycleError[V]) Error() string {
+	return fmt.Sprintf("graph has a cycle: %v", e.Cycle)
+}
+
+// Clone returns a deep copy of the graph's structure and vertex attributes
+func (g *Graph[V, E]) Clone() *Graph[V, E] {
+	clone := NewWithOrder[V, E](g.isDirected, g.less)
+	clone.defaultWeight = g.defaultWeight
+
+	for v := range g.vertices {
+		clone.vertices[v] = true
+		clone.adjacencyList[v] = make(map[V]edgeData[E], len(g.adjacencyList[v]))
+		for to, data := range g.adjacencyList[v] {
+			clone.adjacencyList[v][to] = data
+		}
+		clone.neighbors[v] = append([]V(nil), g.neighbors[v]...)
+		if g.attributes[v] != nil {
+			clone.attributes[v], _ = g.GetVertexAttributes(v)
+		}
+	}
+
+	return clone
+}
+
+// inDegrees returns the number of incoming edges of every vertex
+func (g *Graph[V, E]) inDegrees() map[V]int {
+	inDegree := make(map[V]int, len(g.vertices))
+	for v := range g.vertices {
+		for _, n := range g.neighbors[v] {
+			inDegree[n]++
+		}
+	}
+	return inDegree
+}
+
+// TopologicalSort orders the vertices of a directed acyclic graph using Kahn's
+// algorithm, so that every edge goes from an earlier to a later vertex.
+// If the graph has a cycle a *CycleError describing it is returned.
+func (g *Graph[V, E]) TopologicalSort() ([]V, error) {
+	if !g.isDirected {
+		return nil, ErrUndirectedGraph
+	}
+
+	inDegree := g.inDegrees()
+
+	// Start with all vertices that have no incoming edges
+	queue := make([]V, 0)
+	for _, v := range g.GetVertices() {
+		if inDegree[v] == 0 {
+			queue = append(queue, v)
+		}
+	}
+
+	order := make([]V, 0, len(g.vertices))
+	for len(queue) > 0 {
+		vertex := queue[0]
+		queue = queue[1:]
+		order = append(order, vertex)
+
+		for _, neighbor := range g.neighbors[vertex] {
+			inDegree[neighbor]--
+			if inDegree[neighbor] == 0 {
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	// Any vertex left over lies on or behind a cycle
+	if len(order) != len(g.vertices) {
+		return nil, &CycleError[V]{Cycle: g.FindCycle()}
+	}
+
+	return order, nil
+}
+
+// TopologicalSortDFS orders the vertices of a directed acyclic graph using
+// depth-first search, emitting each vertex after all of its descendants.
+// If the graph has a cycle a *CycleError describing it is returned.
+func (g *Graph[V, E]) TopologicalSortDFS() ([]V, error) {
+	if !g.isDirected {
+		return nil, ErrUndirectedGraph
+	}
+
+	if cycle := g.FindCycle(); cycle != nil {
+		return nil, &CycleError[V]{Cycle: cycle}
+	}
+
+	postOrder := make([]V, 0, len(g.vertices))
+	g.WalkDFSAll(Visitor[V]{
+		PostVisit: func(vertex V) bool {
+			postOrder = append(postOrder, vertex)
+			return true
+		},
+	})
+
+	// Reverse post-order is a topological order
+	order := make([]V, len(postOrder))
+	for i, v := range postOrder {
+		order[len(postOrder)-1-i] = v
+	}
+
+	return order, nil
+}
+
+// FindCycle returns the vertices of a cycle in the graph, starting and ending
+// with the same vertex, or nil if the graph is acyclic
+func (g *Graph[V, E]) FindCycle() []V {
+	parent := make(map[V]V)
+
+	var cycle []V
+	g.WalkDFSAll(Visitor[V]{
+		Edge: func(from, to V, kind EdgeType) bool {
+			switch kind {
+			case TreeEdge:
+				parent[to] = from
+			case BackEdge:
+				// Walk back up the DFS path from from to to
+				cycle = []V{to}
+				for v := from; v != to; v = parent[v] {
+					cycle = append(cycle, v)
+				}
+				cycle = append(cycle, to)
+				for i, j := 1, len(cycle)-2; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				return false
+			}
+			return true
+		},
+	})
+
+	return cycle
+}
+
+// LongestPath finds the path with the largest total weight in a directed
+// acyclic graph, returning the vertices on the path and its total weight.
+// With unweighted edges this is the critical path of a job graph.
+func (g *Graph[V, E]) LongestPath() ([]V, float64, error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(order) == 0 {
+		return nil, 0, nil
+	}
+
+	// Every vertex starts a path of length zero
+	dist := make(map[V]float64, len(order))
+	prev := make(map[V]V)
+	for _, v := range order {
+		dist[v] = 0
+	}
+
+	for _, u := range order {
+		for _, v := range g.neighbors[u] {
+			if alt := dist[u] + g.adjacencyList[u][v].weight; alt > dist[v] {
+				dist[v] = alt
+				prev[v] = u
+			}
+		}
+	}
+
+	// Pick the vertex where the longest path ends
+	end := order[0]
+	best := math.Inf(-1)
+	for _, v := range order {
+		if dist[v] > best {
+			best = dist[v]
+			end = v
+		}
+	}
+
+	path := []V{end}
+	for {
+		p, ok := prev[path[0]]
+		if !ok {
+			break
+		}
+		path = append([]V{p}, path...)
+	}
+
+	return path, best, nil
+}
+
+// TransitiveReduction returns a copy of a directed acyclic graph with every
+// edge removed that is implied by a longer path, leaving the fewest edges with
+// the same reachability. For a dependency graph this drops redundant dependencies.
+func (g *Graph[V, E]) TransitiveReduction() (*Graph[V, E], error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return nil, err
+	}
+
+	reduced := g.Clone()
+	for _, u := range order {
+		// Anything reachable from a neighbour through a path of length >= 1
+		// makes the direct edge from u redundant
+		reachable := make(map[V]bool)
+		for _, n := range g.neighbors[u] {
+			stack := append([]V(nil), g.neighbors[n]...)
+			for len(stack) > 0 {
+				v := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				if reachable[v] {
+					continue
+				}
+				reachable[v] = true
+				stack = append(stack, g.neighbors[v]...)
+			}
+		}
+
+		for _, n := range g.neighbors[u] {
+			if reachable[n] {
+				reduced.RemoveEdge(u, n)
+			}
+		}
+	}
+
+	return reduced, nil
+}
+
+// Layers groups the vertices of a directed acyclic graph into stages, where
+// every vertex depends only on vertices in earlier stages. Vertices within a
+// stage are independent of each other and can be processed in parallel.
+func (g *Graph[V, E]) Layers() ([][]V, error) {
+	if !g.isDirected {
+		return nil, ErrUndirectedGraph
+	}
+
+	inDegree := g.inDegrees()
+
+	current := make([]V, 0)
+	for _, v := range g.GetVertices() {
+		if inDegree[v] == 0 {
+			current = append(current, v)
+		}
+	}
+
+	layers := make([][]V, 0)
+	processed := 0
+	for len(current) > 0 {
+		layers = append(layers, current)
+		processed += len(current)
+
+		next := make([]V, 0)
+		for _, vertex := range current {
+			for _, neighbor := range g.neighbors[vertex] {
+				inDegree[neighbor]--
+				if inDegree[neighbor] == 0 {
+					next = append(next, neighbor)
+				}
+			}
+		}
+		sortVertices(next, g.less)
+		current = next
+	}
+
+	if processed != len(g.vertices) {
+		return nil, &CycleError[V]{Cycle: g.FindCycle()}
+	}
+
+	return layers, nil
+}
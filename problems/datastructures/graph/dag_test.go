@@ -0,0 +1,235 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+// buildJobGraph creates the dependency graph of a small build:
+//
+//	fetch -> compile -> test -> package
+//	fetch -> lint ----------------^
+//	compile -> package
+func buildJobGraph() *Graph[string, struct{}] {
+	g := New[string, struct{}](true)
+	for _, v := range []string{"fetch", "compile", "lint", "test", "package"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("fetch", "compile", 2)
+	g.AddEdge("fetch", "lint", 1)
+	g.AddEdge("compile", "test", 5)
+	g.AddEdge("compile", "package", 1)
+	g.AddEdge("lint", "package", 1)
+	g.AddEdge("test", "package", 1)
+	return g
+}
+
+// assertTopologicalOrder checks that every edge goes forward in order
+func assertTopologicalOrder(t *testing.T, g *Graph[string, struct{}], order []string) {
+	t.Helper()
+
+	if len(order) != g.VertexCount() {
+		t.Fatalf("Expected %d vertices in order, got %v", g.VertexCount(), order)
+	}
+
+	position := make(map[string]int)
+	for i, v := range order {
+		position[v] = i
+	}
+	for _, edge := range g.GetEdges() {
+		if position[edge.From] >= position[edge.To] {
+			t.Errorf("Edge %s -> %s goes backwards in %v", edge.From, edge.To, order)
+		}
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := buildJobGraph()
+
+	order, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort failed: %v", err)
+	}
+	assertTopologicalOrder(t, g, order)
+
+	order, err = g.TopologicalSortDFS()
+	if err != nil {
+		t.Fatalf("TopologicalSortDFS failed: %v", err)
+	}
+	assertTopologicalOrder(t, g, order)
+}
+
+func TestTopologicalSortReportsCycle(t *testing.T) {
+	g := buildJobGraph()
+	g.AddEdge("package", "compile")
+
+	for name, sortFn := range map[string]func() ([]string, error){
+		"Kahn": g.TopologicalSort,
+		"DFS":  g.TopologicalSortDFS,
+	} {
+		_, err := sortFn()
+
+		var cycleErr *CycleError[string]
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("%s: expected CycleError, got %v", name, err)
+		}
+
+		cycle := cycleErr.Cycle
+		if len(cycle) < 2 || cycle[0] != cycle[len(cycle)-1] {
+			t.Fatalf("%s: cycle should start and end with the same vertex: %v", name, cycle)
+		}
+		for i := 0; i+1 < len(cycle); i++ {
+			if !g.HasEdge(cycle[i], cycle[i+1]) {
+				t.Errorf("%s: cycle %v uses missing edge %s -> %s", name, cycle, cycle[i], cycle[i+1])
+			}
+		}
+	}
+}
+
+func TestTopologicalSortRequiresDirectedGraph(t *testing.T) {
+	g := New[string, struct{}](false)
+	g.AddVertex("A")
+
+	if _, err := g.TopologicalSort(); err != ErrUndirectedGraph {
+		t.Errorf("Expected ErrUndirectedGraph, got %v", err)
+	}
+	if _, err := g.Layers(); err != ErrUndirectedGraph {
+		t.Errorf("Expected ErrUndirectedGraph, got %v", err)
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	g := New[string, struct{}](false)
+	for _, v := range []string{"A", "B", "C"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("A", "B")
+	g.AddEdge("B", "C")
+
+	if cycle := g.FindCycle(); cycle != nil {
+		t.Errorf("Expected no cycle in a path, got %v", cycle)
+	}
+
+	g.AddEdge("C", "A")
+	cycle := g.FindCycle()
+	if !reflect.DeepEqual(cycle, []string{"A", "B", "C", "A"}) {
+		t.Errorf("Expected cycle [A B C A], got %v", cycle)
+	}
+}
+
+func TestLongestPath(t *testing.T) {
+	g := buildJobGraph()
+
+	path, length, err := g.LongestPath()
+	if err != nil {
+		t.Fatalf("LongestPath failed: %v", err)
+	}
+
+	expectedPath := []string{"fetch", "compile", "test", "package"}
+	if !reflect.DeepEqual(path, expectedPath) {
+		t.Errorf("Expected critical path %v, got %v", expectedPath, path)
+	}
+	if length != 8 {
+		t.Errorf("Expected length 8, got %.1f", length)
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	g := buildJobGraph()
+	g.AddEdge("fetch", "package")
+
+	reduced, err := g.TransitiveReduction()
+	if err != nil {
+		t.Fatalf("TransitiveReduction failed: %v", err)
+	}
+
+	// fetch -> package and compile -> package are implied by longer paths
+	if reduced.HasEdge("fetch", "package") || reduced.HasEdge("compile", "package") {
+		t.Errorf("Redundant edges should be removed:\n%v", reduced)
+	}
+	if reduced.EdgeCount() != 5 {
+		t.Errorf("Expected 5 edges after reduction, got %d", reduced.EdgeCount())
+	}
+
+	// The original graph is left untouched
+	if !g.HasEdge("fetch", "package") || g.EdgeCount() != 7 {
+		t.Error("TransitiveReduction should not modify the original graph")
+	}
+}
+
+func TestLayers(t *testing.T) {
+	g := buildJobGraph()
+
+	layers, err := g.Layers()
+	if err != nil {
+		t.Fatalf("Layers failed: %v", err)
+	}
+
+	expected := [][]string{
+		{"fetch"},
+		{"compile", "lint"},
+		{"test"},
+		{"package"},
+	}
+	if !reflect.DeepEqual(layers, expected) {
+		t.Errorf("Expected layers %v, got %v", expected, layers)
+	}
+
+	g.AddEdge("package", "fetch")
+	var cycleErr *CycleError[string]
+	if _, err := g.Layers(); !errors.As(err, &cycleErr) {
+		t.Errorf("Expected CycleError, got %v", err)
+	}
+}
+
+// buildChain creates the path 0 -> 1 -> ... -> n-1
+func buildChain(n int, directed bool) *Graph[int, struct{}] {
+	g := New[int, struct{}](directed)
+	for i := 0; i < n; i++ {
+		g.AddVertex(i)
+	}
+	for i := 0; i+1 < n; i++ {
+		g.AddEdge(i, i+1)
+	}
+	return g
+}
+
+// limitStack caps goroutine stacks for the rest of a test, so a search that
+// recurses once per vertex of a long chain crashes instead of passing
+func limitStack(t *testing.T) {
+	previous := debug.SetMaxStack(1 << 20)
+	t.Cleanup(func() { debug.SetMaxStack(previous) })
+}
+
+func TestDAGLongChain(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long chain")
+	}
+	const n = 200000
+	limitStack(t)
+
+	g := buildChain(n, true)
+	order, err := g.TopologicalSortDFS()
+	if err != nil {
+		t.Fatalf("TopologicalSortDFS failed: %v", err)
+	}
+	if len(order) != n || order[0] != 0 || order[n-1] != n-1 {
+		t.Errorf("Expected the chain's %d vertices in order", n)
+	}
+
+	g.AddEdge(n-1, 0)
+	if cycle := g.FindCycle(); len(cycle) != n+1 {
+		t.Errorf("Expected a cycle through all %d vertices, got %d", n, len(cycle))
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+// DisjointSet is a union-find structure that tracks a partition of elements
+// into disjoint sets. It uses union by rank and path compression, so every
+// operation runs in nearly constant amortized time.
+type DisjointSet[T comparable] struct {
+	parent map[T]T
+	rank   map[T]int
+	count  int
+}
+
+// NewDisjointSet creates a new disjoint set containing each element in its own set
+func NewDisjointSet[T comparable](elements ...T) *DisjointSet[T] {
+	ds := &DisjointSet[T]{
+		parent: make(map[T]T, len(elements)),
+		rank:   make(map[T]int, len(elements)),
+	}
+
+	for _, e := range elements {
+		ds.MakeSet(e)
+	}
+
+	return ds
+}
+
+// MakeSet adds an element in a set of its own. It returns false if the element already exists.
+func (ds *DisjointSet[T]) MakeSet(element T) bool {
+	if _, exists := ds.parent[element]; exists {
+		return false
+	}
+
+	ds.parent[element] = element
+	ds.rank[element] = 0
+	ds.count++
+	return true
+}
+
+// Find returns the representative of the set containing an element, and
+// false if the element is unknown
+func (ds *DisjointSet[T]) Find(element T) (T, bool) {
+	parent, exists := ds.parent[element]
+	if !exists {
+		return element, false
+	}
+
+	if parent == element {
+		return element, true
+	}
+
+	// Path compression: point every element on the way directly at the root
+	root, _ := ds.Find(parent)
+	ds.parent[element] = root
+	return root, true
+}
+
+// Union merges the sets containing a and b. It returns false if either
+// element is unknown or they are already in the same set.
+func (ds *DisjointSet[T]) Union(a, b T) bool {
+	rootA, okA := ds.Find(a)
+	rootB, okB := ds.Find(b)
+	if !okA || !okB || rootA == rootB {
+		return false
+	}
+
+	// Union by rank: attach the shorter tree under the taller one
+	switch {
+	case ds.rank[rootA] < ds.rank[rootB]:
+		ds.parent[rootA] = rootB
+	case ds.rank[rootA] > ds.rank[rootB]:
+		ds.parent[rootB] = rootA
+	default:
+		ds.parent[rootB] = rootA
+		ds.rank[rootA]++
+	}
+
+	ds.count--
+	return true
+}
+
+// Connected checks if two elements are in the same set
+func (ds *DisjointSet[T]) Connected(a, b T) bool {
+	rootA, okA := ds.Find(a)
+	rootB, okB := ds.Find(b)
+	return okA && okB && rootA == rootB
+}
+
+// Count returns the number of disjoint sets
+func (ds *DisjointSet[T]) Count() int {
+	return ds.count
+}
+
+// Size returns the number of elements
+func (ds *DisjointSet[T]) Size() int {
+	return len(ds.parent)
+}
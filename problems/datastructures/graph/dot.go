@@ -0,0 +1,371 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WriteDOT writes the graph in Graphviz DOT format. Edge weights are written
+// as the weight attribute, edge labels as the label attribute and vertex
+// attributes as node attributes.
+func (g *Graph[V, E]) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	keyword, connector := "graph", "--"
+	if g.isDirected {
+		keyword, connector = "digraph", "->"
+	}
+	fmt.Fprintf(bw, "%s G {\n", keyword)
+
+	for _, v := range g.GetVertices() {
+		fmt.Fprintf(bw, "  %s", dotQuote(fmt.Sprintf("%v", v)))
+		if attrs := g.attributes[v]; len(attrs) > 0 {
+			keys := make([]string, 0, len(attrs))
+			for key := range attrs {
+				keys = append(keys, key)
+			}
+			sortVertices(keys, func(a, b string) bool { return a < b })
+
+			parts := make([]string, len(keys))
+			for i, key := range keys {
+				parts[i] = fmt.Sprintf("%s=%s", dotQuote(key), dotQuote(fmt.Sprintf("%v", attrs[key])))
+			}
+			fmt.Fprintf(bw, " [%s]", strings.Join(parts, ", "))
+		}
+		fmt.Fprintln(bw, ";")
+	}
+
+	for _, edge := range g.GetEdges() {
+		attrs := []string{"weight=" + strconv.FormatFloat(edge.Weight, 'g', -1, 64)}
+		if label, ok := labelString(edge.Label); ok {
+			attrs = append(attrs, "label="+dotQuote(label))
+		}
+		fmt.Fprintf(bw, "  %s %s %s [%s];\n",
+			dotQuote(fmt.Sprintf("%v", edge.From)), connector,
+			dotQuote(fmt.Sprintf("%v", edge.To)), strings.Join(attrs, ", "))
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// labelString formats an edge label, reporting false for the zero value
+func labelString[E any](label E) (string, bool) {
+	var zero E
+	s := fmt.Sprintf("%v", label)
+	return s, s != fmt.Sprintf("%v", zero)
+}
+
+// dotIdentifier and dotNumeral match DOT identifiers that need no quotes
+var (
+	dotIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z_0-9]*$`)
+	dotNumeral    = regexp.MustCompile(`^-?(\.[0-9]+|[0-9]+(\.[0-9]*)?)$`)
+	dotKeywords   = map[string]bool{"graph": true, "digraph": true, "subgraph": true, "node": true, "edge": true, "strict": true}
+)
+
+// dotQuote returns s as a DOT identifier, quoting it unless it is a plain word or number
+func dotQuote(s string) string {
+	if (dotIdentifier.MatchString(s) && !dotKeywords[strings.ToLower(s)]) || dotNumeral.MatchString(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+// dotToken is a lexical token of the DOT language
+type dotToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeDOT splits DOT source into identifiers, quoted strings and punctuation,
+// dropping comments. A '#' only starts a comment as the first thing on a line.
+func tokenizeDOT(src string) ([]dotToken, error) {
+	tokens := make([]dotToken, 0)
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/', r == '#' && atLineStart(runes, i):
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			j := i + 2
+			for j+1 < len(runes) && !(runes[j] == '*' && runes[j+1] == '/') {
+				j++
+			}
+			if j+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i = j + 2
+		case r == '"':
+			start := i
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			i++
+			tokens = append(tokens, dotToken{text: dotUnquote(string(runes[start:i])), quoted: true})
+		case r == '-' && i+1 < len(runes) && (runes[i+1] == '>' || runes[i+1] == '-'):
+			tokens = append(tokens, dotToken{text: string(runes[i : i+2])})
+			i += 2
+		case strings.ContainsRune("{}[];,=:", r):
+			tokens = append(tokens, dotToken{text: string(r)})
+			i++
+		case r == '_' || r == '.' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || runes[i] == '.' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) ||
+				(runes[i] == '-' && i == start)) {
+				i++
+			}
+			tokens = append(tokens, dotToken{text: string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+// atLineStart reports whether only whitespace precedes position i on its line
+func atLineStart(runes []rune, i int) bool {
+	for j := i - 1; j >= 0 && runes[j] != '\n'; j-- {
+		if !unicode.IsSpace(runes[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// dotUnquote decodes a quoted string, including its surrounding quotes. WriteDOT
+// quotes with Go escapes, so those are decoded; strings that aren't valid Go,
+// such as Graphviz labels using \l, only have their escaped quotes decoded.
+func dotUnquote(quoted string) string {
+	if s, err := strconv.Unquote(quoted); err == nil {
+		return s
+	}
+	return strings.ReplaceAll(quoted[1:len(quoted)-1], `\"`, `"`)
+}
+
+// dotParser reads statements from a token stream
+type dotParser struct {
+	tokens []dotToken
+	pos    int
+}
+
+// peek returns the next token text, or "" at the end
+func (p *dotParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	if p.tokens[p.pos].quoted {
+		// Quoted strings never match punctuation or keywords
+		return "\x00" + p.tokens[p.pos].text
+	}
+	return p.tokens[p.pos].text
+}
+
+// next consumes and returns the next token
+func (p *dotParser) next() (dotToken, error) {
+	if p.pos >= len(p.tokens) {
+		return dotToken{}, io.ErrUnexpectedEOF
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+// expect consumes the next token and checks that it is the given punctuation
+func (p *dotParser) expect(text string) error {
+	if p.peek() != text {
+		return fmt.Errorf("expected %q, got %q", text, strings.TrimPrefix(p.peek(), "\x00"))
+	}
+	p.pos++
+	return nil
+}
+
+// id consumes an identifier or quoted string
+func (p *dotParser) id() (string, error) {
+	tok, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	if !tok.quoted && (strings.ContainsAny(tok.text, "{}[];,=:") || tok.text == "->" || tok.text == "--") {
+		return "", fmt.Errorf("expected identifier, got %q", tok.text)
+	}
+	return tok.text, nil
+}
+
+// attributes parses an optional attribute list such as [weight=2, label="x"]
+func (p *dotParser) attributes() (map[string]string, error) {
+	attrs := make(map[string]string)
+	for p.peek() == "[" {
+		p.pos++
+		for p.peek() != "]" {
+			key, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			value, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			attrs[key] = value
+			if p.peek() == "," || p.peek() == ";" {
+				p.pos++
+			}
+		}
+		p.pos++
+	}
+	return attrs, nil
+}
+
+// ReadDOT reads a graph from a subset of the Graphviz DOT language: a single
+// graph or digraph containing node statements, edge statements (including
+// chains such as a -> b -> c) and attribute lists. The weight attribute of an
+// edge sets its weight, the label attribute its label, and node attributes
+// become vertex attributes. Subgraphs and ports are not supported.
+func ReadDOT(r io.Reader) (*Graph[string, string], error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := tokenizeDOT(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("dot: %v", err)
+	}
+	p := &dotParser{tokens: tokens}
+
+	if strings.EqualFold(p.peek(), "strict") {
+		p.pos++
+	}
+
+	var g *Graph[string, string]
+	connector := ""
+	switch strings.ToLower(p.peek()) {
+	case "digraph":
+		g, connector = New[string, string](true), "->"
+	case "graph":
+		g, connector = New[string, string](false), "--"
+	default:
+		return nil, fmt.Errorf("dot: expected graph or digraph, got %q", p.peek())
+	}
+	p.pos++
+
+	// Optional graph name
+	if p.peek() != "{" {
+		if _, err := p.id(); err != nil {
+			return nil, fmt.Errorf("dot: %v", err)
+		}
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, fmt.Errorf("dot: %v", err)
+	}
+
+	for p.peek() != "}" {
+		if p.peek() == "" {
+			return nil, fmt.Errorf("dot: missing closing brace")
+		}
+		if p.peek() == ";" {
+			p.pos++
+			continue
+		}
+
+		// Default attribute statements don't affect the structure
+		switch strings.ToLower(p.peek()) {
+		case "graph", "node", "edge":
+			p.pos++
+			if _, err := p.attributes(); err != nil {
+				return nil, fmt.Errorf("dot: %v", err)
+			}
+			continue
+		}
+
+		first, err := p.id()
+		if err != nil {
+			return nil, fmt.Errorf("dot: %v", err)
+		}
+
+		// Graph attribute such as rankdir=LR
+		if p.peek() == "=" {
+			p.pos++
+			if _, err := p.id(); err != nil {
+				return nil, fmt.Errorf("dot: %v", err)
+			}
+			continue
+		}
+
+		chain := []string{first}
+		for p.peek() == "->" || p.peek() == "--" {
+			if p.peek() != connector {
+				return nil, fmt.Errorf("dot: %s edge in a graph using %s", p.peek(), connector)
+			}
+			p.pos++
+			to, err := p.id()
+			if err != nil {
+				return nil, fmt.Errorf("dot: %v", err)
+			}
+			chain = append(chain, to)
+		}
+
+		attrs, err := p.attributes()
+		if err != nil {
+			return nil, fmt.Errorf("dot: %v", err)
+		}
+
+		for _, v := range chain {
+			g.AddVertex(v)
+		}
+
+		if len(chain) == 1 {
+			for key, value := range attrs {
+				g.SetVertexAttribute(first, key, value)
+			}
+			continue
+		}
+
+		weights := make([]float64, 0, 1)
+		if value, exists := attrs["weight"]; exists {
+			w, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("dot: invalid weight %q", value)
+			}
+			weights = append(weights, w)
+		}
+		for i := 0; i+1 < len(chain); i++ {
+			if err := g.AddLabeledEdge(chain[i], chain[i+1], attrs["label"], weights...); err != nil {
+				return nil, fmt.Errorf("dot: %v", err)
+			}
+		}
+	}
+	p.pos++ // The closing brace
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("dot: unexpected %q after the closing brace", p.tokens[p.pos].text)
+	}
+	return g, nil
+}
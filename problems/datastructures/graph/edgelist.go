@@ -0,0 +1,141 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ReadEdgeList reads a graph with string vertices and labels from a plain text edge list.
+// Blank lines and lines starting with '#' are ignored. The first remaining
+// line may be "directed" or "undirected" (the default is directed). Every
+// other line is either "from to [weight]" for an edge or a single vertex name
+// for an isolated vertex.
+//
+//	# capacities of a small flow network
+//	directed
+//	s a 10
+//	a t 5
+func ReadEdgeList(r io.Reader) (*Graph[string, string], error) {
+	scanner := bufio.NewScanner(r)
+	var g *Graph[string, string]
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if g == nil {
+			switch strings.ToLower(line) {
+			case "directed":
+				g = New[string, string](true)
+				continue
+			case "undirected":
+				g = New[string, string](false)
+				continue
+			default:
+				g = New[string, string](true)
+			}
+		}
+
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 1:
+			g.AddVertex(fields[0])
+		case 2, 3:
+			g.AddVertex(fields[0])
+			g.AddVertex(fields[1])
+
+			weights := make([]float64, 0, 1)
+			if len(fields) == 3 {
+				w, err := strconv.ParseFloat(fields[2], 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid weight %q", lineNumber, fields[2])
+				}
+				weights = append(weights, w)
+			}
+
+			if err := g.AddEdge(fields[0], fields[1], weights...); err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: expected \"from to [weight]\", got %q", lineNumber, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if g == nil {
+		g = New[string, string](true)
+	}
+
+	return g, nil
+}
+
+// WriteEdgeList writes the graph in the plain text format read by ReadEdgeList.
+// Vertices are written with their default formatting, so names containing
+// whitespace can't be read back. Edge labels are not written.
+func (g *Graph[V, E]) WriteEdgeList(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	if g.isDirected {
+		fmt.Fprintln(bw, "directed")
+	} else {
+		fmt.Fprintln(bw, "undirected")
+	}
+
+	// Vertices without any edge need a line of their own
+	hasEdge := make(map[V]bool)
+	for _, edge := range g.GetEdges() {
+		hasEdge[edge.From] = true
+		hasEdge[edge.To] = true
+	}
+	for _, v := range g.GetVertices() {
+		if !hasEdge[v] {
+			fmt.Fprintf(bw, "%v\n", v)
+		}
+	}
+
+	for _, edge := range g.GetEdges() {
+		fmt.Fprintf(bw, "%v %v %s\n", edge.From, edge.To, strconv.FormatFloat(edge.Weight, 'g', -1, 64))
+	}
+
+	return bw.Flush()
+}
+
+// ReadFile reads a graph from a file, choosing the format by extension:
+// .dot and .gv for Graphviz DOT, .json for JSON and anything else for an edge list
+func ReadFile(path string) (*Graph[string, string], error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".dot", ".gv":
+		return ReadDOT(file)
+	case ".json":
+		return ReadJSON(file)
+	default:
+		return ReadEdgeList(file)
+	}
+}
@@ -0,0 +1,509 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// flowEpsilon is the smallest capacity treated as non-zero
+const flowEpsilon = 1e-9
+
+// ErrNotBipartite is returned when a graph's vertices can't be split into two independent sides
+var ErrNotBipartite = errors.New("graph is not bipartite")
+
+// flowNetwork is a residual network over vertex indices. Every edge is stored
+// as a forward arc with its capacity and a reverse arc with capacity zero.
+type flowNetwork struct {
+	arcs     [][]int // Arc indices leaving each vertex
+	to       []int
+	residual []float64
+	capacity []float64
+}
+
+// addArc adds an arc and its reverse arc
+func (n *flowNetwork) addArc(from, to int, capacity float64) {
+	n.arcs[from] = append(n.arcs[from], len(n.to))
+	n.to = append(n.to, to)
+	n.residual = append(n.residual, capacity)
+	n.capacity = append(n.capacity, capacity)
+
+	n.arcs[to] = append(n.arcs[to], len(n.to))
+	n.to = append(n.to, from)
+	n.residual = append(n.residual, 0)
+	n.capacity = append(n.capacity, 0)
+}
+
+// FlowResult is a maximum flow from a source to a sink
+type FlowResult[V comparable] struct {
+	Source V
+	Sink   V
+	Value  float64
+	flow   map[V]map[V]float64
+}
+
+// Flow returns the net flow sent along the edge from one vertex to another
+func (f *FlowResult[V]) Flow(from, to V) float64 {
+	return f.flow[from][to]
+}
+
+// flowProblem holds the residual network of a graph for one source and sink
+type flowProblem[V comparable, E any] struct {
+	graph    *Graph[V, E]
+	vertices []V
+	index    map[V]int
+	network  *flowNetwork
+	source   int
+	sink     int
+}
+
+// newFlowProblem builds the residual network, treating edge weights as capacities
+func (g *Graph[V, E]) newFlowProblem(source, sink V) (*flowProblem[V, E], error) {
+	if !g.HasVertex(source) {
+		return nil, fmt.Errorf("source vertex %v does not exist", source)
+	}
+	if !g.HasVertex(sink) {
+		return nil, fmt.Errorf("sink vertex %v does not exist", sink)
+	}
+	if source == sink {
+		return nil, errors.New("source and sink must be different vertices")
+	}
+	if g.hasNegativeWeight() {
+		return nil, errors.New("capacities must not be negative")
+	}
+
+	vertices := g.GetVertices()
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+
+	network := &flowNetwork{arcs: make([][]int, len(vertices))}
+	for i, u := range vertices {
+		for _, v := range g.neighbors[u] {
+			if u != v {
+				network.addArc(i, index[v], g.weightOf(u, v))
+			}
+		}
+	}
+
+	return &flowProblem[V, E]{
+		graph:    g,
+		vertices: vertices,
+		index:    index,
+		network:  network,
+		source:   index[source],
+		sink:     index[sink],
+	}, nil
+}
+
+// result collects the net flow along every edge
+func (p *flowProblem[V, E]) result(value float64) *FlowResult[V] {
+	n := p.network
+	gross := make(map[V]map[V]float64)
+	for arc := 0; arc < len(n.to); arc += 2 {
+		used := n.capacity[arc] - n.residual[arc]
+		if used <= flowEpsilon {
+			continue
+		}
+		from := p.vertices[n.to[arc+1]]
+		to := p.vertices[n.to[arc]]
+		if gross[from] == nil {
+			gross[from] = make(map[V]float64)
+		}
+		gross[from][to] += used
+	}
+
+	// Flow in both directions between two vertices cancels out
+	net := make(map[V]map[V]float64)
+	for from, targets := range gross {
+		for to, f := range targets {
+			if f -= gross[to][from]; f > flowEpsilon {
+				if net[from] == nil {
+					net[from] = make(map[V]float64)
+				}
+				net[from][to] = f
+			}
+		}
+	}
+
+	return &FlowResult[V]{
+		Source: p.vertices[p.source],
+		Sink:   p.vertices[p.sink],
+		Value:  value,
+		flow:   net,
+	}
+}
+
+// EdmondsKarp computes a maximum flow from source to sink, treating edge
+// weights as capacities, by repeatedly augmenting along shortest paths found
+// with BFS. It runs in O(V * E^2).
+func (g *Graph[V, E]) EdmondsKarp(source, sink V) (*FlowResult[V], error) {
+	p, err := g.newFlowProblem(source, sink)
+	if err != nil {
+		return nil, err
+	}
+
+	n := p.network
+	total := 0.0
+	parentArc := make([]int, len(p.vertices))
+
+	for {
+		for i := range parentArc {
+			parentArc[i] = -1
+		}
+
+		// BFS for the shortest augmenting path
+		queue := []int{p.source}
+		visited := make([]bool, len(p.vertices))
+		visited[p.source] = true
+		for len(queue) > 0 && !visited[p.sink] {
+			u := queue[0]
+			queue = queue[1:]
+			for _, arc := range n.arcs[u] {
+				if v := n.to[arc]; !visited[v] && n.residual[arc] > flowEpsilon {
+					visited[v] = true
+					parentArc[v] = arc
+					queue = append(queue, v)
+				}
+			}
+		}
+
+		if !visited[p.sink] {
+			break
+		}
+
+		// Find the bottleneck and push flow along the path
+		bottleneck := math.Inf(1)
+		for v := p.sink; v != p.source; v = n.to[parentArc[v]^1] {
+			bottleneck = math.Min(bottleneck, n.residual[parentArc[v]])
+		}
+		for v := p.sink; v != p.source; v = n.to[parentArc[v]^1] {
+			n.residual[parentArc[v]] -= bottleneck
+			n.residual[parentArc[v]^1] += bottleneck
+		}
+		total += bottleneck
+	}
+
+	return p.result(total), nil
+}
+
+// Dinic computes a maximum flow from source to sink, treating edge weights as
+// capacities, by building BFS level graphs and saturating each with blocking
+// flows. It runs in O(V^2 * E) and is usually faster than EdmondsKarp.
+func (g *Graph[V, E]) Dinic(source, sink V) (*FlowResult[V], error) {
+	p, err := g.newFlowProblem(source, sink)
+	if err != nil {
+		return nil, err
+	}
+
+	total := p.dinic()
+	return p.result(total), nil
+}
+
+// dinic runs Dinic's algorithm on the residual network and returns the flow value
+func (p *flowProblem[V, E]) dinic() float64 {
+	n := p.network
+	level := make([]int, len(p.vertices))
+	next := make([]int, len(p.vertices))
+	total := 0.0
+
+	// buildLevels labels vertices with their BFS distance from the source
+	buildLevels := func() bool {
+		for i := range level {
+			level[i] = -1
+		}
+		level[p.source] = 0
+		queue := []int{p.source}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, arc := range n.arcs[u] {
+				if v := n.to[arc]; level[v] < 0 && n.residual[arc] > flowEpsilon {
+					level[v] = level[u] + 1
+					queue = append(queue, v)
+				}
+			}
+		}
+		return level[p.sink] >= 0
+	}
+
+	// push finds a path from the source to the sink along the level graph and
+	// sends its bottleneck along it. The path is kept on a stack of arcs rather
+	// than the call stack, so long paths don't overflow it. Dead ends are
+	// skipped for the rest of the phase by moving their parent's next arc on.
+	path := make([]int, 0)
+	push := func() float64 {
+		path = path[:0]
+		u := p.source
+		for u != p.sink {
+			advanced := false
+			for ; next[u] < len(n.arcs[u]); next[u]++ {
+				arc := n.arcs[u][next[u]]
+				if v := n.to[arc]; level[v] == level[u]+1 && n.residual[arc] > flowEpsilon {
+					path = append(path, arc)
+					u = v
+					advanced = true
+					break
+				}
+			}
+			if advanced {
+				continue
+			}
+			if len(path) == 0 {
+				return 0
+			}
+			u = n.to[path[len(path)-1]^1]
+			path = path[:len(path)-1]
+			next[u]++
+		}
+
+		bottleneck := math.Inf(1)
+		for _, arc := range path {
+			bottleneck = math.Min(bottleneck, n.residual[arc])
+		}
+		for _, arc := range path {
+			n.residual[arc] -= bottleneck
+			n.residual[arc^1] += bottleneck
+		}
+		return bottleneck
+	}
+
+	for buildLevels() {
+		for i := range next {
+			next[i] = 0
+		}
+		for {
+			pushed := push()
+			if pushed <= flowEpsilon {
+				break
+			}
+			total += pushed
+		}
+	}
+
+	return total
+}
+
+// Cut is a partition of the vertices into a source side and a sink side
+type Cut[V comparable, E any] struct {
+	SourceSide []V
+	SinkSide   []V
+	Edges      []Edge[V, E]
+	Capacity   float64
+}
+
+// MinCut finds a minimum capacity set of edges whose removal separates sink
+// from source. By the max-flow min-cut theorem its capacity equals the maximum flow.
+func (g *Graph[V, E]) MinCut(source, sink V) (*Cut[V, E], error) {
+	p, err := g.newFlowProblem(source, sink)
+	if err != nil {
+		return nil, err
+	}
+	p.dinic()
+
+	// Vertices still reachable in the residual network form the source side
+	n := p.network
+	reachable := make([]bool, len(p.vertices))
+	reachable[p.source] = true
+	stack := []int{p.source}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, arc := range n.arcs[u] {
+			if v := n.to[arc]; !reachable[v] && n.residual[arc] > flowEpsilon {
+				reachable[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+
+	cut := &Cut[V, E]{}
+	for i, u := range p.vertices {
+		if !reachable[i] {
+			cut.SinkSide = append(cut.SinkSide, u)
+			continue
+		}
+		cut.SourceSide = append(cut.SourceSide, u)
+		for _, v := range g.neighbors[u] {
+			if !reachable[p.index[v]] {
+				data := g.adjacencyList[u][v]
+				cut.Edges = append(cut.Edges, Edge[V, E]{From: u, To: v, Weight: data.weight, Label: data.label})
+				cut.Capacity += data.weight
+			}
+		}
+	}
+
+	return cut, nil
+}
+
+// Bipartition splits the vertices into two sides so that every edge joins
+// the two sides, ignoring edge direction. It returns ErrNotBipartite if the
+// graph has an odd cycle.
+func (g *Graph[V, E]) Bipartition() ([]V, []V, error) {
+	adjacency := g.undirectedNeighbors()
+	side := make(map[V]int)
+	left := make([]V, 0)
+	right := make([]V, 0)
+
+	for _, start := range g.GetVertices() {
+		if _, colored := side[start]; colored {
+			continue
+		}
+
+		side[start] = 0
+		queue := []V{start}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range adjacency[u] {
+				s, colored := side[v]
+				if !colored {
+					side[v] = 1 - side[u]
+					queue = append(queue, v)
+				} else if s == side[u] {
+					return nil, nil, ErrNotBipartite
+				}
+			}
+		}
+	}
+
+	// Self-loops are dropped from undirectedNeighbors but still break bipartiteness
+	for _, v := range g.GetVertices() {
+		if g.HasEdge(v, v) {
+			return nil, nil, ErrNotBipartite
+		}
+		if side[v] == 0 {
+			left = append(left, v)
+		} else {
+			right = append(right, v)
+		}
+	}
+
+	return left, right, nil
+}
+
+// IsBipartite checks if the vertices can be split into two sides so that
+// every edge joins the two sides
+func (g *Graph[V, E]) IsBipartite() bool {
+	_, _, err := g.Bipartition()
+	return err == nil
+}
+
+// MaximumMatching finds a largest set of edges no two of which share a vertex
+// in a bipartite graph, using the Hopcroft-Karp algorithm in O(E * sqrt(V)).
+// Edge direction is ignored; each matched edge is reported from its vertex on
+// the left side of Bipartition.
+func (g *Graph[V, E]) MaximumMatching() ([]Edge[V, E], error) {
+	left, _, err := g.Bipartition()
+	if err != nil {
+		return nil, err
+	}
+
+	adjacency := g.undirectedNeighbors()
+	matchLeft := make(map[V]V)
+	matchRight := make(map[V]V)
+	dist := make(map[V]int)
+	const unmatched = -1
+
+	// bfs layers the left vertices by alternating path length from free left vertices
+	bfs := func() bool {
+		queue := make([]V, 0)
+		for _, u := range left {
+			if _, matched := matchLeft[u]; !matched {
+				dist[u] = 0
+				queue = append(queue, u)
+			} else {
+				dist[u] = unmatched
+			}
+		}
+
+		found := false
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range adjacency[u] {
+				w, matched := matchRight[v]
+				if !matched {
+					found = true
+				} else if dist[w] == unmatched {
+					dist[w] = dist[u] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return found
+	}
+
+	// augment looks for an augmenting path from the free vertex start along the
+	// BFS layers and flips the matching along it. Each stack frame holds a left
+	// vertex and the index of the neighbor it is trying, so long paths don't
+	// overflow the call stack. A vertex with no path left is taken out of the
+	// layers for the rest of the phase.
+	type frame struct {
+		u    V
+		next int
+	}
+	augment := func(start V) {
+		stack := []frame{{u: start}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(adjacency[top.u]) {
+				dist[top.u] = unmatched
+				stack = stack[:len(stack)-1]
+				if len(stack) > 0 {
+					stack[len(stack)-1].next++
+				}
+				continue
+			}
+
+			v := adjacency[top.u][top.next]
+			w, matched := matchRight[v]
+			if !matched {
+				for _, f := range stack {
+					v := adjacency[f.u][f.next]
+					matchLeft[f.u] = v
+					matchRight[v] = f.u
+				}
+				return
+			}
+			if dist[w] == dist[top.u]+1 {
+				stack = append(stack, frame{u: w})
+				continue
+			}
+			top.next++
+		}
+	}
+
+	for bfs() {
+		for _, u := range left {
+			if _, matched := matchLeft[u]; !matched {
+				augment(u)
+			}
+		}
+	}
+
+	matching := make([]Edge[V, E], 0, len(matchLeft))
+	for _, u := range left {
+		v, matched := matchLeft[u]
+		if !matched {
+			continue
+		}
+		data, exists := g.adjacencyList[u][v]
+		if !exists {
+			data = g.adjacencyList[v][u]
+		}
+		matching = append(matching, Edge[V, E]{From: u, To: v, Weight: data.weight, Label: data.label})
+	}
+
+	return matching, nil
+}
@@ -0,0 +1,220 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// flowNetworkInput is the classic textbook flow network with a maximum flow of 23
+const flowNetworkInput = `
+# source s, sink t
+directed
+s v1 16
+s v2 13
+v1 v3 12
+v2 v1 4
+v2 v4 14
+v3 v2 9
+v3 t 20
+v4 v3 7
+v4 t 4
+`
+
+func TestReadEdgeList(t *testing.T) {
+	g, err := ReadEdgeList(strings.NewReader(flowNetworkInput))
+	if err != nil {
+		t.Fatalf("ReadEdgeList failed: %v", err)
+	}
+
+	if !g.IsDirected() || g.VertexCount() != 6 || g.EdgeCount() != 9 {
+		t.Errorf("Unexpected graph: %v", g)
+	}
+	if w, _ := g.GetEdgeWeight("v2", "v4"); w != 14 {
+		t.Errorf("Expected weight 14, got %.1f", w)
+	}
+
+	g, err = ReadEdgeList(strings.NewReader("undirected\na b\nc\n"))
+	if err != nil {
+		t.Fatalf("ReadEdgeList failed: %v", err)
+	}
+	if g.IsDirected() || !g.HasEdge("b", "a") || !g.HasVertex("c") {
+		t.Errorf("Unexpected graph: %v", g)
+	}
+
+	if _, err := ReadEdgeList(strings.NewReader("a b ten\n")); err == nil {
+		t.Error("ReadEdgeList should reject an invalid weight")
+	}
+	if _, err := ReadEdgeList(strings.NewReader("a b 1 2\n")); err == nil {
+		t.Error("ReadEdgeList should reject a line with too many fields")
+	}
+}
+
+func TestMaxFlow(t *testing.T) {
+	g, _ := ReadEdgeList(strings.NewReader(flowNetworkInput))
+
+	edmondsKarp, err := g.EdmondsKarp("s", "t")
+	if err != nil {
+		t.Fatalf("EdmondsKarp failed: %v", err)
+	}
+	dinic, err := g.Dinic("s", "t")
+	if err != nil {
+		t.Fatalf("Dinic failed: %v", err)
+	}
+
+	for name, result := range map[string]*FlowResult[string]{"EdmondsKarp": edmondsKarp, "Dinic": dinic} {
+		if result.Value != 23 {
+			t.Errorf("%s: expected max flow 23, got %.1f", name, result.Value)
+		}
+
+		// Flow respects capacities and is conserved at every inner vertex
+		balance := make(map[string]float64)
+		for _, edge := range g.GetEdges() {
+			f := result.Flow(edge.From, edge.To)
+			if f < 0 || f > edge.Weight+flowEpsilon {
+				t.Errorf("%s: flow %.1f on %s -> %s exceeds capacity %.1f", name, f, edge.From, edge.To, edge.Weight)
+			}
+			balance[edge.From] -= f
+			balance[edge.To] += f
+		}
+		for v, b := range balance {
+			if v != "s" && v != "t" && math.Abs(b) > flowEpsilon {
+				t.Errorf("%s: flow not conserved at %s (%.1f)", name, v, b)
+			}
+		}
+		if math.Abs(balance["t"]-23) > flowEpsilon {
+			t.Errorf("%s: expected 23 units into t, got %.1f", name, balance["t"])
+		}
+	}
+
+	if _, err := g.Dinic("s", "s"); err == nil {
+		t.Error("Max flow with source equal to sink should return error")
+	}
+	if _, err := g.EdmondsKarp("s", "x"); err == nil {
+		t.Error("Max flow to a missing sink should return error")
+	}
+}
+
+func TestMinCut(t *testing.T) {
+	g, _ := ReadEdgeList(strings.NewReader(flowNetworkInput))
+
+	cut, err := g.MinCut("s", "t")
+	if err != nil {
+		t.Fatalf("MinCut failed: %v", err)
+	}
+
+	if cut.Capacity != 23 {
+		t.Errorf("Expected cut capacity 23, got %.1f", cut.Capacity)
+	}
+	if !reflect.DeepEqual(cut.SourceSide, []string{"s", "v1", "v2", "v4"}) {
+		t.Errorf("Unexpected source side: %v", cut.SourceSide)
+	}
+	if !reflect.DeepEqual(cut.SinkSide, []string{"t", "v3"}) {
+		t.Errorf("Unexpected sink side: %v", cut.SinkSide)
+	}
+
+	// Removing the cut edges must disconnect t from s
+	for _, edge := range cut.Edges {
+		g.RemoveEdge(edge.From, edge.To)
+	}
+	if _, _, err := g.ShortestPath("s", "t"); err == nil {
+		t.Error("Sink should be unreachable after removing the cut edges")
+	}
+}
+
+func TestBipartiteMatching(t *testing.T) {
+	// Workers on the left, jobs on the right
+	g := New[string, struct{}](false)
+	for _, v := range []string{"alice", "bob", "carol", "dave", "build", "deploy", "review", "test"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("alice", "build")
+	g.AddEdge("alice", "test")
+	g.AddEdge("bob", "build")
+	g.AddEdge("carol", "test")
+	g.AddEdge("carol", "review")
+	g.AddEdge("dave", "review")
+
+	left, right, err := g.Bipartition()
+	if err != nil {
+		t.Fatalf("Bipartition failed: %v", err)
+	}
+	if len(left)+len(right) != g.VertexCount() {
+		t.Errorf("Bipartition lost vertices: %v | %v", left, right)
+	}
+	for _, edge := range g.GetEdges() {
+		if slices.Contains(left, edge.From) == slices.Contains(left, edge.To) {
+			t.Errorf("Edge %s - %s does not cross the partition", edge.From, edge.To)
+		}
+	}
+
+	matching, err := g.MaximumMatching()
+	if err != nil {
+		t.Fatalf("MaximumMatching failed: %v", err)
+	}
+	if len(matching) != 3 {
+		t.Errorf("Expected a matching of size 3, got %v", matching)
+	}
+	used := make(map[string]bool)
+	for _, edge := range matching {
+		if used[edge.From] || used[edge.To] {
+			t.Errorf("Vertex matched twice in %v", matching)
+		}
+		used[edge.From], used[edge.To] = true, true
+		if !g.HasEdge(edge.From, edge.To) {
+			t.Errorf("Matched edge %v does not exist", edge)
+		}
+	}
+
+	// A triangle has an odd cycle
+	g.AddEdge("alice", "bob")
+	if g.IsBipartite() {
+		t.Error("Graph with an odd cycle should not be bipartite")
+	}
+	if _, err := g.MaximumMatching(); !errors.Is(err, ErrNotBipartite) {
+		t.Errorf("Expected ErrNotBipartite, got %v", err)
+	}
+}
+
+func TestFlowLongPath(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long path")
+	}
+	const n = 200000
+	limitStack(t)
+
+	result, err := buildChain(n, true).Dinic(0, n-1)
+	if err != nil || result.Value != 1 {
+		t.Errorf("Expected a flow of 1 along the chain, got %v (err: %v)", result, err)
+	}
+
+	// Right vertex -j joins left vertices j-1 and j. The first phase matches
+	// each -j with j-1, leaving a single augmenting path through every vertex.
+	g := New[int, struct{}](false)
+	for i := 1; i <= n/2; i++ {
+		g.AddVertex(i)
+		g.AddVertex(-i)
+	}
+	for i := 1; i <= n/2; i++ {
+		g.AddEdge(i, -i)
+		if i < n/2 {
+			g.AddEdge(i, -(i + 1))
+		}
+	}
+	matching, err := g.MaximumMatching()
+	if err != nil || len(matching) != n/2 {
+		t.Errorf("Expected a perfect matching of %d edges, got %d (err: %v)", n/2, len(matching), err)
+	}
+}
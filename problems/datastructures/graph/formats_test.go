@@ -0,0 +1,239 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// buildLabeledGraph creates a small directed graph with labels and attributes
+func buildLabeledGraph() *Graph[string, string] {
+	g := New[string, string](true)
+	for _, v := range []string{"api", "db", "cache", "lonely node"} {
+		g.AddVertex(v)
+	}
+	g.SetVertexAttribute("api", "team", "core")
+	g.AddLabeledEdge("api", "db", "queries", 2.5)
+	g.AddLabeledEdge("api", "cache", "reads")
+	g.AddEdge("cache", "db", -1)
+	return g
+}
+
+// assertSameGraph checks that two graphs have the same vertices and edges
+func assertSameGraph(t *testing.T, expected, actual *Graph[string, string], withLabels bool) {
+	t.Helper()
+
+	if expected.IsDirected() != actual.IsDirected() {
+		t.Errorf("Expected directed=%v, got %v", expected.IsDirected(), actual.IsDirected())
+	}
+	if !reflect.DeepEqual(expected.GetVertices(), actual.GetVertices()) {
+		t.Errorf("Expected vertices %v, got %v", expected.GetVertices(), actual.GetVertices())
+	}
+
+	expectedEdges := expected.GetEdges()
+	actualEdges := actual.GetEdges()
+	if !withLabels {
+		for i := range expectedEdges {
+			expectedEdges[i].Label = ""
+		}
+	}
+	if !reflect.DeepEqual(expectedEdges, actualEdges) {
+		t.Errorf("Expected edges %v, got %v", expectedEdges, actualEdges)
+	}
+}
+
+func TestDOTRoundTrip(t *testing.T) {
+	g := buildLabeledGraph()
+
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT failed: %v", err)
+	}
+
+	loaded, err := ReadDOT(&buf)
+	if err != nil {
+		t.Fatalf("ReadDOT failed: %v\n%s", err, buf.String())
+	}
+	assertSameGraph(t, g, loaded, true)
+
+	if team, _ := loaded.GetVertexAttribute("api", "team"); team != "core" {
+		t.Errorf("Expected team attribute core, got %v", team)
+	}
+}
+
+func TestReadDOT(t *testing.T) {
+	src := `
+/* build graph */
+strict digraph "build" {
+  rankdir=LR;
+  node [shape=box];
+  fetch -> compile -> test [weight=3];
+  compile -> "package" // quoted keyword
+  # preprocessor-style comment
+  docs [label="Documentation"]
+}`
+
+	g, err := ReadDOT(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ReadDOT failed: %v", err)
+	}
+
+	if !g.IsDirected() || g.VertexCount() != 5 || g.EdgeCount() != 3 {
+		t.Errorf("Unexpected graph: %v", g)
+	}
+	if w, _ := g.GetEdgeWeight("compile", "test"); w != 3 {
+		t.Errorf("Expected weight 3 on chained edge, got %.1f", w)
+	}
+	if label, _ := g.GetVertexAttribute("docs", "label"); label != "Documentation" {
+		t.Errorf("Expected docs label attribute, got %v", label)
+	}
+
+	undirected, err := ReadDOT(strings.NewReader("graph { a -- b }"))
+	if err != nil || undirected.IsDirected() || !undirected.HasEdge("b", "a") {
+		t.Errorf("Expected undirected edge a -- b, got %v (err: %v)", undirected, err)
+	}
+
+	for _, bad := range []string{
+		"digraph { a -- b }",
+		"digraph { a -> }",
+		"digraph { a -> b [weight=heavy] }",
+		"digraph { a -> b",
+		"tree { a }",
+		"digraph { a -> b } c",
+		"digraph { a } digraph { b }",
+		"digraph { a -> b # not a comment mid-line\n}",
+	} {
+		if _, err := ReadDOT(strings.NewReader(bad)); err == nil {
+			t.Errorf("ReadDOT should reject %q", bad)
+		}
+	}
+}
+
+func TestDOTEscapes(t *testing.T) {
+	g := New[string, string](true)
+	for _, v := range []string{"line\nbreak", `say "hi"`, `back\slash`, "tab\there"} {
+		g.AddVertex(v)
+	}
+	g.SetVertexAttribute("tab\there", "note", "first\nsecond")
+	g.AddLabeledEdge("line\nbreak", `say "hi"`, "a\tb", 2)
+	g.AddLabeledEdge(`back\slash`, "tab\there", `quote " and \n`)
+
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT failed: %v", err)
+	}
+
+	loaded, err := ReadDOT(&buf)
+	if err != nil {
+		t.Fatalf("ReadDOT failed: %v\n%s", err, buf.String())
+	}
+	assertSameGraph(t, g, loaded, true)
+
+	if note, _ := loaded.GetVertexAttribute("tab\there", "note"); note != "first\nsecond" {
+		t.Errorf("Expected note attribute with a newline, got %q", note)
+	}
+
+	// Graphviz escapes that Go doesn't know are kept, except for the quotes
+	loaded, err = ReadDOT(strings.NewReader(`digraph { a [label="left\l\"x\""] }`))
+	if err != nil {
+		t.Fatalf("ReadDOT failed: %v", err)
+	}
+	if label, _ := loaded.GetVertexAttribute("a", "label"); label != `left\l"x"` {
+		t.Errorf("Expected label left\\l\"x\", got %q", label)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	g := buildLabeledGraph()
+
+	var buf bytes.Buffer
+	if err := g.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+
+	loaded, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+	assertSameGraph(t, g, loaded, true)
+
+	if team, _ := loaded.GetVertexAttribute("api", "team"); team != "core" {
+		t.Errorf("Expected team attribute core, got %v", team)
+	}
+
+	// Nodes may be omitted and weights default to 1
+	loaded, err = ReadJSON(strings.NewReader(`{"edges": [{"from": "a", "to": "b"}]}`))
+	if err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+	if loaded.IsDirected() || !loaded.HasEdge("b", "a") {
+		t.Errorf("Unexpected graph: %v", loaded)
+	}
+
+	if _, err := ReadJSON(strings.NewReader(`{"edges": [{"from": "a"}]}`)); err == nil {
+		t.Error("ReadJSON should reject an edge without a target")
+	}
+}
+
+func TestEdgeListRoundTrip(t *testing.T) {
+	g := New[string, string](false)
+	for _, v := range []string{"a", "b", "c", "d"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("a", "b", 0.5)
+	g.AddEdge("b", "c")
+
+	var buf bytes.Buffer
+	if err := g.WriteEdgeList(&buf); err != nil {
+		t.Fatalf("WriteEdgeList failed: %v", err)
+	}
+
+	loaded, err := ReadEdgeList(&buf)
+	if err != nil {
+		t.Fatalf("ReadEdgeList failed: %v", err)
+	}
+	assertSameGraph(t, g, loaded, false)
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	g := buildLabeledGraph()
+
+	writers := map[string]func(*bytes.Buffer) error{
+		"graph.dot":  func(b *bytes.Buffer) error { return g.WriteDOT(b) },
+		"graph.json": func(b *bytes.Buffer) error { return g.WriteJSON(b) },
+	}
+	for name, write := range writers {
+		var buf bytes.Buffer
+		write(&buf)
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, buf.Bytes(), 0o644)
+
+		loaded, err := ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) failed: %v", name, err)
+		}
+		assertSameGraph(t, g, loaded, true)
+	}
+
+	loaded, err := ReadFile(filepath.Join("testdata", "network.txt"))
+	if err != nil || loaded.EdgeCount() != 9 {
+		t.Errorf("Expected 9 edges from edge list, got %v (err: %v)", loaded, err)
+	}
+
+	if _, err := ReadFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("ReadFile should fail for a missing file")
+	}
+}
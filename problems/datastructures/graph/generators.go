@@ -0,0 +1,170 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// The generators below build graphs on the vertices 0..n-1 with default
+// weights. Random generators take a seed, so the same arguments always
+// produce the same graph.
+
+// newIntGraph creates a graph with vertices 0..n-1
+func newIntGraph(n int, directed bool) *Graph[int, struct{}] {
+	g := New[int, struct{}](directed)
+	for i := 0; i < n; i++ {
+		g.AddVertex(i)
+	}
+	return g
+}
+
+// ErdosRenyi generates a G(n, p) random graph: every possible edge between
+// two distinct vertices is added independently with probability p
+func ErdosRenyi(n int, p float64, directed bool, seed int64) (*Graph[int, struct{}], error) {
+	if p < 0 || p > 1 {
+		return nil, fmt.Errorf("probability must be in [0, 1], got %g", p)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	g := newIntGraph(n, directed)
+
+	for i := 0; i < n; i++ {
+		start := i + 1
+		if directed {
+			start = 0
+		}
+		for j := start; j < n; j++ {
+			if i != j && rng.Float64() < p {
+				g.AddEdge(i, j)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// BarabasiAlbert generates an undirected scale-free graph by preferential
+// attachment: starting from a complete graph on m+1 vertices, each new vertex
+// connects to m existing vertices chosen with probability proportional to
+// their degree
+func BarabasiAlbert(n, m int, seed int64) (*Graph[int, struct{}], error) {
+	if m < 1 || m >= n {
+		return nil, fmt.Errorf("need 1 <= m < n, got m=%d, n=%d", m, n)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	g := Complete(m+1, false)
+
+	// Every vertex appears once per incident edge, so a uniform pick is degree-weighted
+	endpoints := make([]int, 0, 2*m*n)
+	for _, edge := range g.GetEdges() {
+		endpoints = append(endpoints, edge.From, edge.To)
+	}
+
+	for v := m + 1; v < n; v++ {
+		g.AddVertex(v)
+
+		targets := make(map[int]bool, m)
+		for len(targets) < m {
+			targets[endpoints[rng.Intn(len(endpoints))]] = true
+		}
+
+		for t := 0; t < v; t++ {
+			if targets[t] {
+				g.AddEdge(v, t)
+				endpoints = append(endpoints, v, t)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// Grid generates an undirected rows x cols grid where vertex r*cols+c is
+// connected to its horizontal and vertical neighbours
+func Grid(rows, cols int) *Graph[int, struct{}] {
+	g := newIntGraph(rows*cols, false)
+
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			v := r*cols + c
+			if c+1 < cols {
+				g.AddEdge(v, v+1)
+			}
+			if r+1 < rows {
+				g.AddEdge(v, v+cols)
+			}
+		}
+	}
+
+	return g
+}
+
+// Complete generates a graph with an edge between every pair of distinct vertices
+func Complete(n int, directed bool) *Graph[int, struct{}] {
+	g := newIntGraph(n, directed)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && (directed || i < j) {
+				g.AddEdge(i, j)
+			}
+		}
+	}
+
+	return g
+}
+
+// RandomTree generates an undirected random recursive tree: each vertex
+// after the first is attached to a uniformly chosen earlier vertex
+func RandomTree(n int, seed int64) *Graph[int, struct{}] {
+	rng := rand.New(rand.NewSource(seed))
+	g := newIntGraph(n, false)
+
+	for v := 1; v < n; v++ {
+		g.AddEdge(rng.Intn(v), v)
+	}
+
+	return g
+}
+
+// RandomDAG generates a directed acyclic graph: vertices are placed in a
+// random order and each edge from an earlier to a later vertex is added
+// with probability p
+func RandomDAG(n int, p float64, seed int64) (*Graph[int, struct{}], error) {
+	if p < 0 || p > 1 {
+		return nil, fmt.Errorf("probability must be in [0, 1], got %g", p)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	g := newIntGraph(n, true)
+	order := rng.Perm(n)
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if rng.Float64() < p {
+				g.AddEdge(order[i], order[j])
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// AssignRandomWeights gives every edge a weight drawn uniformly from [low, high)
+func AssignRandomWeights[V comparable, E any](g *Graph[V, E], low, high float64, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+
+	for _, edge := range g.GetEdges() {
+		g.AddLabeledEdge(edge.From, edge.To, edge.Label, low+rng.Float64()*(high-low))
+	}
+}
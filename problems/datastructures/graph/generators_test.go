@@ -0,0 +1,221 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGenerators(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    *Graph[int, struct{}]
+		vertices int
+		edges    int
+	}{
+		{"Grid", Grid(3, 4), 12, 17},
+		{"Complete undirected", Complete(5, false), 5, 10},
+		{"Complete directed", Complete(5, true), 5, 20},
+		{"RandomTree", RandomTree(50, 1), 50, 49},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.graph.VertexCount() != tt.vertices || tt.graph.EdgeCount() != tt.edges {
+				t.Errorf("Expected %d vertices and %d edges, got %d and %d",
+					tt.vertices, tt.edges, tt.graph.VertexCount(), tt.graph.EdgeCount())
+			}
+		})
+	}
+
+	tree := RandomTree(50, 1)
+	if !tree.IsConnected() || tree.HasCycle() {
+		t.Error("Expected RandomTree to be connected and acyclic")
+	}
+
+	ba, err := BarabasiAlbert(100, 2, 1)
+	if err != nil {
+		t.Fatalf("BarabasiAlbert failed: %v", err)
+	}
+	// m+1 vertices start complete, every later vertex adds m edges
+	if ba.EdgeCount() != 3+97*2 || !ba.IsConnected() {
+		t.Errorf("Unexpected Barabasi-Albert graph with %d edges", ba.EdgeCount())
+	}
+	if _, err := BarabasiAlbert(3, 3, 1); err == nil {
+		t.Error("BarabasiAlbert should reject m >= n")
+	}
+
+	if _, err := ErdosRenyi(10, 1.5, false, 1); err == nil {
+		t.Error("ErdosRenyi should reject p > 1")
+	}
+	full, _ := ErdosRenyi(6, 1, true, 1)
+	if full.EdgeCount() != 30 {
+		t.Errorf("Expected G(6, 1) to be complete, got %d edges", full.EdgeCount())
+	}
+
+	// The same seed gives the same graph
+	a, _ := ErdosRenyi(30, 0.2, true, 99)
+	b, _ := ErdosRenyi(30, 0.2, true, 99)
+	if a.String() != b.String() {
+		t.Error("Expected identical graphs for the same seed")
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		dag, _ := RandomDAG(30, 0.3, seed)
+		if dag.HasCycle() {
+			t.Fatalf("seed %d: RandomDAG produced a cycle", seed)
+		}
+	}
+}
+
+// bruteForceShortest finds the cheapest simple path by trying all of them
+func bruteForceShortest(g *Graph[int, struct{}], from, to int) float64 {
+	best := math.Inf(1)
+	onPath := map[int]bool{from: true}
+
+	var extend func(v int, cost float64)
+	extend = func(v int, cost float64) {
+		if v == to {
+			best = math.Min(best, cost)
+			return
+		}
+		for _, w := range g.neighbors[v] {
+			if !onPath[w] {
+				onPath[w] = true
+				extend(w, cost+g.weightOf(v, w))
+				onPath[w] = false
+			}
+		}
+	}
+	extend(from, 0)
+
+	return best
+}
+
+// bruteForceReachable computes which vertices each vertex reaches in one or more steps
+func bruteForceReachable(g *Graph[int, struct{}]) [][]bool {
+	n := g.VertexCount()
+	reach := make([][]bool, n)
+	for i := range reach {
+		reach[i] = make([]bool, n)
+		for _, j := range g.neighbors[i] {
+			reach[i][j] = true
+		}
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				reach[i][j] = reach[i][j] || (reach[i][k] && reach[k][j])
+			}
+		}
+	}
+	return reach
+}
+
+func TestShortestPathProperty(t *testing.T) {
+	for seed := int64(0); seed < 30; seed++ {
+		g, _ := ErdosRenyi(8, 0.35, seed%2 == 0, seed)
+		AssignRandomWeights(g, 0, 10, seed)
+
+		for to := 1; to < 8; to++ {
+			expected := bruteForceShortest(g, 0, to)
+			_, cost, err := g.ShortestPath(0, to)
+
+			if math.IsInf(expected, 1) {
+				if err == nil {
+					t.Errorf("seed %d: expected no path from 0 to %d", seed, to)
+				}
+				continue
+			}
+			if err != nil || math.Abs(cost-expected) > 1e-9 {
+				t.Errorf("seed %d: path 0 -> %d cost %.4f, expected %.4f (err: %v)", seed, to, cost, expected, err)
+			}
+		}
+	}
+}
+
+func TestHasCycleProperty(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		directed := seed%2 == 0
+		g, _ := ErdosRenyi(9, 0.15, directed, seed)
+
+		expected := false
+		if directed {
+			// A directed cycle exists if some vertex reaches itself
+			reach := bruteForceReachable(g)
+			for v := range reach {
+				expected = expected || reach[v][v]
+			}
+		} else {
+			// A forest has exactly V - components edges
+			expected = g.EdgeCount() > g.VertexCount()-g.WeaklyConnectedComponents().Count()
+		}
+
+		if got := g.HasCycle(); got != expected {
+			t.Errorf("seed %d (directed=%v): HasCycle = %v, expected %v", seed, directed, got, expected)
+		}
+	}
+}
+
+func TestIsConnectedProperty(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		directed := seed%2 == 0
+		g, _ := ErdosRenyi(10, 0.2, directed, seed)
+
+		// IsConnected checks that every vertex is reachable from the first one
+		reach := bruteForceReachable(g)
+		expected := true
+		for v := 1; v < 10; v++ {
+			expected = expected && reach[0][v]
+		}
+
+		if got := g.IsConnected(); got != expected {
+			t.Errorf("seed %d (directed=%v): IsConnected = %v, expected %v", seed, directed, got, expected)
+		}
+	}
+}
+
+func BenchmarkShortestPath(b *testing.B) {
+	g, _ := BarabasiAlbert(5000, 3, 1)
+	AssignRandomWeights(g, 1, 100, 1)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.ShortestPath(0, 4999)
+	}
+}
+
+func BenchmarkHasCycle(b *testing.B) {
+	g, _ := RandomDAG(2000, 0.01, 1)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.HasCycle()
+	}
+}
+
+func BenchmarkIsConnected(b *testing.B) {
+	g := Grid(200, 200)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.IsConnected()
+	}
+}
+
+func BenchmarkParallelBFS(b *testing.B) {
+	g := Grid(200, 200)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.ParallelBFS(0, 0)
+	}
+}
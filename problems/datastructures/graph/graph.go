@@ -19,49 +19,195 @@ import (
 )
 
 // Edge represents an edge in the graph
-type Edge struct {
-	From   interface{}
-	To     interface{}
+type Edge[V comparable, E any] struct {
+	From   V
+	To     V
 	Weight float64
+	Label  E
 }
 
-// Graph represents a graph using adjacency list
-type Graph struct {
-	vertices      map[interface{}]bool
-	adjacencyList map[interface{}]map[interface{}]float64
+// edgeData is the payload stored for every edge in the adjacency list
+type edgeData[E any] struct {
+	weight float64
+	label  E
+}
+
+// Attributes holds arbitrary named attributes attached to a vertex
+type Attributes map[string]interface{}
+
+// Graph represents a graph using adjacency list.
+// V is the vertex type and E is the type of the label carried by each edge.
+// Neighbours are kept ordered by the graph's ordering function, so every
+// traversal visits vertices in a reproducible order.
+type Graph[V comparable, E any] struct {
+	vertices      map[V]bool
+	attributes    map[V]Attributes
+	adjacencyList map[V]map[V]edgeData[E]
+	neighbors     map[V][]V // Sorted neighbours of each vertex
 	isDirected    bool
 	defaultWeight float64
+	less          func(a, b V) bool
+}
+
+// UntypedGraph is a graph whose vertices and edge labels can be any value
+type UntypedGraph = Graph[interface{}, interface{}]
+
+// NewGraph creates a new untyped graph (directed or undirected)
+func NewGraph(isDirected bool) *UntypedGraph {
+	return New[interface{}, interface{}](isDirected)
 }
 
-// NewGraph creates a new graph (directed or undirected)
-func NewGraph(isDirected bool) *Graph {
-	return &Graph{
-		vertices:      make(map[interface{}]bool),
-		adjacencyList: make(map[interface{}]map[interface{}]float64),
+// New creates a new typed graph (directed or undirected).
+// Vertices are ordered naturally for strings and numbers, and by their
+// formatted value otherwise.
+func New[V comparable, E any](isDirected bool) *Graph[V, E] {
+	return NewWithOrder[V, E](isDirected, defaultLess[V])
+}
+
+// NewWithOrder creates a new typed graph that orders vertices with less
+func NewWithOrder[V comparable, E any](isDirected bool, less func(a, b V) bool) *Graph[V, E] {
+	return &Graph[V, E]{
+		vertices:      make(map[V]bool),
+		attributes:    make(map[V]Attributes),
+		adjacencyList: make(map[V]map[V]edgeData[E]),
+		neighbors:     make(map[V][]V),
 		isDirected:    isDirected,
 		defaultWeight: 1.0, // Default weight for unweighted edges
+		less:          less,
 	}
 }
 
+// defaultLess orders strings and numbers naturally and falls back to
+// comparing the formatted values for anything else. Values that format the
+// same, such as 1 and "1", are ordered by type name and then by their Go
+// syntax, so the ordering is total.
+func defaultLess[V comparable](a, b V) bool {
+	switch x := any(a).(type) {
+	case string:
+		if y, ok := any(b).(string); ok {
+			return x < y
+		}
+	case int:
+		if y, ok := any(b).(int); ok {
+			return x < y
+		}
+	case int64:
+		if y, ok := any(b).(int64); ok {
+			return x < y
+		}
+	case int32:
+		if y, ok := any(b).(int32); ok {
+			return x < y
+		}
+	case uint:
+		if y, ok := any(b).(uint); ok {
+			return x < y
+		}
+	case uint64:
+		if y, ok := any(b).(uint64); ok {
+			return x < y
+		}
+	case float64:
+		if y, ok := any(b).(float64); ok {
+			return x < y
+		}
+	}
+	if x, y := fmt.Sprintf("%v", a), fmt.Sprintf("%v", b); x != y {
+		return x < y
+	}
+	if x, y := fmt.Sprintf("%T", a), fmt.Sprintf("%T", b); x != y {
+		return x < y
+	}
+	return fmt.Sprintf("%#v", a) < fmt.Sprintf("%#v", b)
+}
+
+// insertNeighbor adds to into the sorted neighbour list of from
+func (g *Graph[V, E]) insertNeighbor(from, to V) {
+	ns := g.neighbors[from]
+	i := sort.Search(len(ns), func(i int) bool { return !g.less(ns[i], to) })
+	ns = append(ns, to)
+	copy(ns[i+1:], ns[i:])
+	ns[i] = to
+	g.neighbors[from] = ns
+}
+
+// removeNeighbor removes to from the sorted neighbour list of from
+func (g *Graph[V, E]) removeNeighbor(from, to V) {
+	ns := g.neighbors[from]
+	for i, n := range ns {
+		if n == to {
+			g.neighbors[from] = append(ns[:i], ns[i+1:]...)
+			return
+		}
+	}
+}
+
+// setEdge stores a single directed edge, keeping the neighbour order in sync
+func (g *Graph[V, E]) setEdge(from, to V, data edgeData[E]) {
+	if _, exists := g.adjacencyList[from][to]; !exists {
+		g.insertNeighbor(from, to)
+	}
+	g.adjacencyList[from][to] = data
+}
+
 // AddVertex adds a vertex to the graph
-func (g *Graph) AddVertex(vertex interface{}) bool {
+func (g *Graph[V, E]) AddVertex(vertex V) bool {
 	if g.HasVertex(vertex) {
 		return false // Vertex already exists
 	}
 
 	g.vertices[vertex] = true
-	g.adjacencyList[vertex] = make(map[interface{}]float64)
+	g.adjacencyList[vertex] = make(map[V]edgeData[E])
+	g.neighbors[vertex] = nil
 	return true
 }
 
 // HasVertex checks if a vertex exists in the graph
-func (g *Graph) HasVertex(vertex interface{}) bool {
+func (g *Graph[V, E]) HasVertex(vertex V) bool {
 	_, exists := g.vertices[vertex]
 	return exists
 }
 
+// SetVertexAttribute sets a named attribute on a vertex
+func (g *Graph[V, E]) SetVertexAttribute(vertex V, key string, value interface{}) error {
+	if !g.HasVertex(vertex) {
+		return fmt.Errorf("vertex %v does not exist", vertex)
+	}
+
+	if g.attributes[vertex] == nil {
+		g.attributes[vertex] = make(Attributes)
+	}
+	g.attributes[vertex][key] = value
+	return nil
+}
+
+// GetVertexAttribute returns a named attribute of a vertex
+func (g *Graph[V, E]) GetVertexAttribute(vertex V, key string) (interface{}, bool) {
+	value, exists := g.attributes[vertex][key]
+	return value, exists
+}
+
+// GetVertexAttributes returns a copy of all attributes of a vertex
+func (g *Graph[V, E]) GetVertexAttributes(vertex V) (Attributes, error) {
+	if !g.HasVertex(vertex) {
+		return nil, fmt.Errorf("vertex %v does not exist", vertex)
+	}
+
+	attributes := make(Attributes, len(g.attributes[vertex]))
+	for key, value := range g.attributes[vertex] {
+		attributes[key] = value
+	}
+	return attributes, nil
+}
+
 // AddEdge adds an edge between two vertices
-func (g *Graph) AddEdge(from, to interface{}, weight ...float64) error {
+func (g *Graph[V, E]) AddEdge(from, to V, weight ...float64) error {
+	var label E
+	return g.AddLabeledEdge(from, to, label, weight...)
+}
+
+// AddLabeledEdge adds an edge carrying a label between two vertices
+func (g *Graph[V, E]) AddLabeledEdge(from, to V, label E, weight ...float64) error {
 	// Check if vertices exist
 	if !g.HasVertex(from) {
 		return fmt.Errorf("vertex %v does not exist", from)
@@ -74,24 +220,25 @@ func (g *Graph) AddEdge(from, to interface{}, weight ...float64) error {
 	w := g.defaultWeight
 	if len(weight) > 0 {
 		w = weight[0]
-		if w <= 0 {
-			return errors.New("weight must be positive")
+		if math.IsNaN(w) || math.IsInf(w, 0) {
+			return errors.New("weight must be a finite number")
 		}
 	}
 
 	// Add edge
-	g.adjacencyList[from][to] = w
+	data := edgeData[E]{weight: w, label: label}
+	g.setEdge(from, to, data)
 
 	// If undirected, add the reverse edge
 	if !g.isDirected {
-		g.adjacencyList[to][from] = w
+		g.setEdge(to, from, data)
 	}
 
 	return nil
 }
 
 // RemoveEdge removes an edge between two vertices
-func (g *Graph) RemoveEdge(from, to interface{}) error {
+func (g *Graph[V, E]) RemoveEdge(from, to V) error {
 	// Check if vertices exist
 	if !g.HasVertex(from) {
 		return fmt.Errorf("vertex %v does not exist", from)
@@ -107,17 +254,19 @@ func (g *Graph) RemoveEdge(from, to interface{}) error {
 
 	// Remove edge
 	delete(g.adjacencyList[from], to)
+	g.removeNeighbor(from, to)
 
 	// If undirected, remove the reverse edge
 	if !g.isDirected {
 		delete(g.adjacencyList[to], from)
+		g.removeNeighbor(to, from)
 	}
 
 	return nil
 }
 
 // HasEdge checks if an edge exists between two vertices
-func (g *Graph) HasEdge(from, to interface{}) bool {
+func (g *Graph[V, E]) HasEdge(from, to V) bool {
 	if !g.HasVertex(from) || !g.HasVertex(to) {
 		return false
 	}
@@ -127,7 +276,7 @@ func (g *Graph) HasEdge(from, to interface{}) bool {
 }
 
 // GetEdgeWeight returns the weight of an edge between two vertices
-func (g *Graph) GetEdgeWeight(from, to interface{}) (float64, error) {
+func (g *Graph[V, E]) GetEdgeWeight(from, to V) (float64, error) {
 	if !g.HasVertex(from) {
 		return 0, fmt.Errorf("vertex %v does not exist", from)
 	}
@@ -135,16 +284,34 @@ func (g *Graph) GetEdgeWeight(from, to interface{}) (float64, error) {
 		return 0, fmt.Errorf("vertex %v does not exist", to)
 	}
 
-	weight, exists := g.adjacencyList[from][to]
+	data, exists := g.adjacencyList[from][to]
 	if !exists {
 		return 0, fmt.Errorf("edge from %v to %v does not exist", from, to)
 	}
 
-	return weight, nil
+	return data.weight, nil
+}
+
+// GetEdgeLabel returns the label of an edge between two vertices
+func (g *Graph[V, E]) GetEdgeLabel(from, to V) (E, error) {
+	var zero E
+	if !g.HasVertex(from) {
+		return zero, fmt.Errorf("vertex %v does not exist", from)
+	}
+	if !g.HasVertex(to) {
+		return zero, fmt.Errorf("vertex %v does not exist", to)
+	}
+
+	data, exists := g.adjacencyList[from][to]
+	if !exists {
+		return zero, fmt.Errorf("edge from %v to %v does not exist", from, to)
+	}
+
+	return data.label, nil
 }
 
 // RemoveVertex removes a vertex and all its edges
-func (g *Graph) RemoveVertex(vertex interface{}) error {
+func (g *Graph[V, E]) RemoveVertex(vertex V) error {
 	if !g.HasVertex(vertex) {
 		return fmt.Errorf("vertex %v does not exist", vertex)
 	}
@@ -152,54 +319,63 @@ func (g *Graph) RemoveVertex(vertex interface{}) error {
 	// Remove all edges to this vertex from other vertices
 	for v := range g.vertices {
 		if v != vertex {
-			delete(g.adjacencyList[v], vertex)
+			if _, exists := g.adjacencyList[v][vertex]; exists {
+				delete(g.adjacencyList[v], vertex)
+				g.removeNeighbor(v, vertex)
+			}
 		}
 	}
 
 	// Remove vertex and its edges
 	delete(g.adjacencyList, vertex)
+	delete(g.neighbors, vertex)
+	delete(g.attributes, vertex)
 	delete(g.vertices, vertex)
 
 	return nil
 }
 
-// GetVertices returns all vertices in the graph
-func (g *Graph) GetVertices() []interface{} {
-	vertices := make([]interface{}, 0, len(g.vertices))
+// GetVertices returns all vertices in the graph in sorted order
+func (g *Graph[V, E]) GetVertices() []V {
+	vertices := make([]V, 0, len(g.vertices))
 	for v := range g.vertices {
 		vertices = append(vertices, v)
 	}
+
+	sortVertices(vertices, g.less)
+
 	return vertices
 }
 
-// GetNeighbors returns all neighbors of a vertex
-func (g *Graph) GetNeighbors(vertex interface{}) ([]interface{}, error) {
+// sortVertices sorts a slice of vertices in place using less
+func sortVertices[V comparable](vertices []V, less func(a, b V) bool) {
+	sort.Slice(vertices, func(i, j int) bool {
+		return less(vertices[i], vertices[j])
+	})
+}
+
+// GetNeighbors returns all neighbors of a vertex in sorted order
+func (g *Graph[V, E]) GetNeighbors(vertex V) ([]V, error) {
 	if !g.HasVertex(vertex) {
 		return nil, fmt.Errorf("vertex %v does not exist", vertex)
 	}
 
-	neighbors := make([]interface{}, 0, len(g.adjacencyList[vertex]))
-	for n := range g.adjacencyList[vertex] {
-		neighbors = append(neighbors, n)
-	}
-
-	// Sort neighbors for deterministic output (if they can be compared)
-	sort.Slice(neighbors, func(i, j int) bool {
-		return fmt.Sprintf("%v", neighbors[i]) < fmt.Sprintf("%v", neighbors[j])
-	})
+	neighbors := make([]V, len(g.neighbors[vertex]))
+	copy(neighbors, g.neighbors[vertex])
 
 	return neighbors, nil
 }
 
-// GetEdges returns all edges in the graph
-func (g *Graph) GetEdges() []Edge {
-	edges := make([]Edge, 0)
+// GetEdges returns all edges in the graph ordered by source then target
+func (g *Graph[V, E]) GetEdges() []Edge[V, E] {
+	edges := make([]Edge[V, E], 0)
 
-	for from := range g.adjacencyList {
-		for to, weight := range g.adjacencyList[from] {
-			// For undirected graphs, only add edges where from < to to avoid duplicates
-			if g.isDirected || fmt.Sprintf("%v", from) <= fmt.Sprintf("%v", to) {
-				edges = append(edges, Edge{From: from, To: to, Weight: weight})
+	for _, from := range g.GetVertices() {
+		for _, to := range g.neighbors[from] {
+			// For undirected graphs, only add each edge once, from its smaller endpoint
+			if g.isDirected || !g.less(to, from) {
+				data := g.adjacencyList[from][to]
+				edges = append(edges, Edge[V, E]{From: from, To: to, Weight: data.weight, Label: data.label})
 			}
 		}
 	}
@@ -208,39 +384,43 @@ func (g *Graph) GetEdges() []Edge {
 }
 
 // VertexCount returns the number of vertices in the graph
-func (g *Graph) VertexCount() int {
+func (g *Graph[V, E]) VertexCount() int {
 	return len(g.vertices)
 }
 
 // EdgeCount returns the number of edges in the graph
-func (g *Graph) EdgeCount() int {
+func (g *Graph[V, E]) EdgeCount() int {
 	count := 0
+	selfLoops := 0
 
 	for from := range g.adjacencyList {
 		count += len(g.adjacencyList[from])
+		if _, exists := g.adjacencyList[from][from]; exists {
+			selfLoops++
+		}
 	}
 
-	// For undirected graphs, each edge is counted twice
+	// For undirected graphs, each edge except a self-loop is counted twice
 	if !g.isDirected {
-		count /= 2
+		count = (count-selfLoops)/2 + selfLoops
 	}
 
 	return count
 }
 
 // IsDirected returns true if the graph is directed
-func (g *Graph) IsDirected() bool {
+func (g *Graph[V, E]) IsDirected() bool {
 	return g.isDirected
 }
 
 // BFS performs breadth-first search from a starting vertex
-func (g *Graph) BFS(start interface{}) ([]interface{}, error) {
+func (g *Graph[V, E]) BFS(start V) ([]V, error) {
 	if !g.HasVertex(start) {
 		return nil, fmt.Errorf("vertex %v does not exist", start)
 	}
 
-	visited := make(map[interface{}]bool)
-	result := make([]interface{}, 0)
+	visited := make(map[V]bool)
+	result := make([]V, 0)
 
 	// Create a queue
 	queue := list.New()
@@ -250,17 +430,14 @@ func (g *Graph) BFS(start interface{}) ([]interface{}, error) {
 	for queue.Len() > 0 {
 		// Dequeue
 		element := queue.Front()
-		vertex := element.Value
+		vertex := element.Value.(V)
 		queue.Remove(element)
 
 		// Add to result
 		result = append(result, vertex)
 
-		// Get neighbors
-		neighbors, _ := g.GetNeighbors(vertex)
-
 		// Visit neighbors
-		for _, neighbor := range neighbors {
+		for _, neighbor := range g.neighbors[vertex] {
 			if !visited[neighbor] {
 				visited[neighbor] = true
 				queue.PushBack(neighbor)
@@ -271,65 +448,52 @@ func (g *Graph) BFS(start interface{}) ([]interface{}, error) {
 	return result, nil
 }
 
-// dfsUtil is a helper function for DFS
-func (g *Graph) dfsUtil(vertex interface{}, visited map[interface{}]bool, result *[]interface{}) {
-	// Mark the current vertex as visited
-	visited[vertex] = true
-	*result = append(*result, vertex)
-
-	// Visit all neighbors
-	neighbors, _ := g.GetNeighbors(vertex)
-	for _, neighbor := range neighbors {
-		if !visited[neighbor] {
-			g.dfsUtil(neighbor, visited, result)
-		}
-	}
-}
-
-// DFS performs depth-first search from a starting vertex
-func (g *Graph) DFS(start interface{}) ([]interface{}, error) {
-	if !g.HasVertex(start) {
-		return nil, fmt.Errorf("vertex %v does not exist", start)
+// DFS performs an iterative depth-first search from a starting vertex
+func (g *Graph[V, E]) DFS(start V) ([]V, error) {
+	result := make([]V, 0)
+	err := g.WalkDFS(start, Visitor[V]{
+		PreVisit: func(v V) bool {
+			result = append(result, v)
+			return true
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	visited := make(map[interface{}]bool)
-	result := make([]interface{}, 0)
-
-	g.dfsUtil(start, visited, &result)
-
 	return result, nil
 }
 
 // Item is a queue item for Dijkstra's algorithm
-type Item struct {
-	vertex   interface{}
+type Item[V comparable] struct {
+	vertex   V
 	priority float64
 	index    int // The index of the item in the heap
 }
 
 // A PriorityQueue implements heap.Interface and holds Items
-type PriorityQueue []*Item
+type PriorityQueue[V comparable] []*Item[V]
 
-func (pq PriorityQueue) Len() int { return len(pq) }
+func (pq PriorityQueue[V]) Len() int { return len(pq) }
 
-func (pq PriorityQueue) Less(i, j int) bool {
+func (pq PriorityQueue[V]) Less(i, j int) bool {
 	return pq[i].priority < pq[j].priority
 }
 
-func (pq PriorityQueue) Swap(i, j int) {
+func (pq PriorityQueue[V]) Swap(i, j int) {
 	pq[i], pq[j] = pq[j], pq[i]
 	pq[i].index = i
 	pq[j].index = j
 }
 
-func (pq *PriorityQueue) Push(x interface{}) {
+func (pq *PriorityQueue[V]) Push(x interface{}) {
 	n := len(*pq)
-	item := x.(*Item)
+	item := x.(*Item[V])
 	item.index = n
 	*pq = append(*pq, item)
 }
 
-func (pq *PriorityQueue) Pop() interface{} {
+func (pq *PriorityQueue[V]) Pop() interface{} {
 	old := *pq
 	n := len(old)
 	item := old[n-1]
@@ -339,18 +503,51 @@ func (pq *PriorityQueue) Pop() interface{} {
 	return item
 }
 
-// ShortestPath finds the shortest path between two vertices using Dijkstra's algorithm
-func (g *Graph) ShortestPath(start, end interface{}) ([]interface{}, error) {
+// ShortestPath finds the shortest path between two vertices using Dijkstra's
+// algorithm and returns it together with its total weight. Dijkstra's algorithm
+// is only correct for non-negative weights, so ErrNegativeWeight is returned if
+// the graph has any negative edge; use BellmanFord for such graphs.
+func (g *Graph[V, E]) ShortestPath(start, end V) ([]V, float64, error) {
 	if !g.HasVertex(start) {
-		return nil, fmt.Errorf("start vertex %v does not exist", start)
+		return nil, 0, fmt.Errorf("start vertex %v does not exist", start)
 	}
 	if !g.HasVertex(end) {
-		return nil, fmt.Errorf("end vertex %v does not exist", end)
+		return nil, 0, fmt.Errorf("end vertex %v does not exist", end)
+	}
+	if g.hasNegativeWeight() {
+		return nil, 0, ErrNegativeWeight
 	}
 
+	dist, prev := g.bestFirstSearch(start, end, true, g.weightOf, nil)
+
+	return buildPath(start, end, dist, prev)
+}
+
+// weightOf returns the weight of the edge from u to v
+func (g *Graph[V, E]) weightOf(u, v V) float64 {
+	return g.adjacencyList[u][v].weight
+}
+
+// hasNegativeWeight reports whether any edge has a negative weight
+func (g *Graph[V, E]) hasNegativeWeight() bool {
+	for _, edges := range g.adjacencyList {
+		for _, data := range edges {
+			if data.weight < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bestFirstSearch runs Dijkstra's algorithm from start, or A* when a heuristic
+// is given. If hasEnd is set the search stops as soon as end is settled.
+// A vertex whose distance improves after it was settled is searched again.
+// It returns the distance to and predecessor of every vertex reached.
+func (g *Graph[V, E]) bestFirstSearch(start, end V, hasEnd bool, weight func(u, v V) float64, heuristic func(V) float64) (map[V]float64, map[V]V) {
 	// Initialize distances and previous vertices
-	dist := make(map[interface{}]float64)
-	prev := make(map[interface{}]interface{})
+	dist := make(map[V]float64)
+	prev := make(map[V]V)
 
 	// Set distances to infinity for all vertices except start
 	for v := range g.vertices {
@@ -359,13 +556,13 @@ func (g *Graph) ShortestPath(start, end interface{}) ([]interface{}, error) {
 	dist[start] = 0
 
 	// Initialize priority queue
-	pq := make(PriorityQueue, 0)
+	pq := make(PriorityQueue[V], 0)
 	heap.Init(&pq)
 
 	// Add all vertices to the priority queue
-	vertexToItem := make(map[interface{}]*Item)
-	for v := range g.vertices {
-		item := &Item{
+	vertexToItem := make(map[V]*Item[V])
+	for _, v := range g.GetVertices() {
+		item := &Item[V]{
 			vertex:   v,
 			priority: dist[v],
 		}
@@ -376,141 +573,98 @@ func (g *Graph) ShortestPath(start, end interface{}) ([]interface{}, error) {
 	// Dijkstra's algorithm
 	for pq.Len() > 0 {
 		// Get vertex with minimum distance
-		item := heap.Pop(&pq).(*Item)
+		item := heap.Pop(&pq).(*Item[V])
 		u := item.vertex
 
 		// If we reached the end, we can stop
-		if u == end {
+		if hasEnd && u == end {
+			break
+		}
+
+		// The remaining vertices are unreachable
+		if math.IsInf(dist[u], 1) {
 			break
 		}
 
 		// For each neighbor of u
-		for v, weight := range g.adjacencyList[u] {
+		for _, v := range g.neighbors[u] {
 			// Calculate potential new distance
-			alt := dist[u] + weight
+			alt := dist[u] + weight(u, v)
 
 			// If we found a shorter path to v
 			if alt < dist[v] {
 				dist[v] = alt
 				prev[v] = u
 
-				// Update priority queue
+				// Update priority queue; A* orders by the estimated total cost
 				item := vertexToItem[v]
 				item.priority = alt
-				heap.Fix(&pq, item.index)
+				if heuristic != nil {
+					item.priority += heuristic(v)
+				}
+				if item.index >= 0 {
+					heap.Fix(&pq, item.index)
+				} else {
+					// A* with an admissible but inconsistent heuristic can
+					// improve a vertex it already settled, so reopen it
+					heap.Push(&pq, item)
+				}
 			}
 		}
 	}
 
+	return dist, prev
+}
+
+// buildPath reconstructs the path from start to end from a predecessor map
+func buildPath[V comparable](start, end V, dist map[V]float64, prev map[V]V) ([]V, float64, error) {
 	// If there's no path to the end vertex
 	if _, ok := prev[end]; !ok && start != end {
-		return nil, fmt.Errorf("no path from %v to %v", start, end)
+		return nil, 0, fmt.Errorf("no path from %v to %v", start, end)
 	}
 
 	// Reconstruct path
-	path := make([]interface{}, 0)
+	path := make([]V, 0)
 	curr := end
 
 	for curr != start {
-		path = append([]interface{}{curr}, path...)
+		path = append([]V{curr}, path...)
 		curr = prev[curr]
 	}
-	path = append([]interface{}{start}, path...)
+	path = append([]V{start}, path...)
 
-	return path, nil
+	return path, dist[end], nil
 }
 
 // IsConnected checks if the graph is connected
-func (g *Graph) IsConnected() bool {
+func (g *Graph[V, E]) IsConnected() bool {
 	// If graph is empty, it's considered connected
 	if len(g.vertices) == 0 {
 		return true
 	}
 
-	// Get a starting vertex
-	var start interface{}
-	for v := range g.vertices {
-		start = v
-		break
-	}
-
-	// Perform BFS from start
-	visited, _ := g.BFS(start)
+	// Perform BFS from the first vertex
+	visited, _ := g.BFS(g.GetVertices()[0])
 
 	// If all vertices are visited, the graph is connected
 	return len(visited) == len(g.vertices)
 }
 
-// HasCycle checks if the graph has a cycle
-func (g *Graph) HasCycle() bool {
-	if g.isDirected {
-		// For directed graph
-		visited := make(map[interface{}]bool)
-		recStack := make(map[interface{}]bool)
-
-		for v := range g.vertices {
-			if !visited[v] {
-				if g.hasCycleDirected(v, visited, recStack) {
-					return true
-				}
-			}
-		}
-	} else {
-		// For undirected graph
-		visited := make(map[interface{}]bool)
-
-		for v := range g.vertices {
-			if !visited[v] {
-				if g.hasCycleUndirected(v, nil, visited) {
-					return true
-				}
-			}
-		}
-	}
-
-	return false
-}
-
-// hasCycleDirected checks if a directed graph has a cycle using DFS
-func (g *Graph) hasCycleDirected(vertex interface{}, visited, recStack map[interface{}]bool) bool {
-	visited[vertex] = true
-	recStack[vertex] = true
-
-	for neighbor := range g.adjacencyList[vertex] {
-		if !visited[neighbor] {
-			if g.hasCycleDirected(neighbor, visited, recStack) {
-				return true
-			}
-		} else if recStack[neighbor] {
-			return true
-		}
-	}
-
-	recStack[vertex] = false
-	return false
-}
-
-// hasCycleUndirected checks if an undirected graph has a cycle using DFS
-func (g *Graph) hasCycleUndirected(vertex, parent interface{}, visited map[interface{}]bool) bool {
-	visited[vertex] = true
-
-	for neighbor := range g.adjacencyList[vertex] {
-		// If the neighbor is not visited, then recurse on it
-		if !visited[neighbor] {
-			if g.hasCycleUndirected(neighbor, vertex, visited) {
-				return true
-			}
-		} else if neighbor != parent {
-			// If the neighbor is visited and not the parent, then there's a cycle
-			return true
-		}
-	}
-
-	return false
+// HasCycle checks if the graph has a cycle. A graph has a cycle exactly
+// when a depth-first search finds a back edge.
+func (g *Graph[V, E]) HasCycle() bool {
+	found := false
+	g.WalkDFSAll(Visitor[V]{
+		Edge: func(from, to V, kind EdgeType) bool {
+			found = kind == BackEdge
+			return !found
+		},
+	})
+	return found
 }
 
 // String returns a string representation of the graph
-func (g *Graph) String() string {
+func (g *Graph[V, E]) String() string {
 	var result string
 
 	if g.isDirected {
@@ -521,20 +675,14 @@ func (g *Graph) String() string {
 
 	result += fmt.Sprintf("Vertices: %d, Edges: %d\n", g.VertexCount(), g.EdgeCount())
 
-	for vertex := range g.adjacencyList {
+	for _, vertex := range g.GetVertices() {
 		result += fmt.Sprintf("Vertex %v: ", vertex)
 
-		edges := make([]string, 0)
-		for neighbor, weight := range g.adjacencyList[vertex] {
-			edges = append(edges, fmt.Sprintf("%v(%.1f)", neighbor, weight))
-		}
-
-		sort.Strings(edges)
-		for i, edge := range edges {
+		for i, neighbor := range g.neighbors[vertex] {
 			if i > 0 {
 				result += ", "
 			}
-			result += edge
+			result += fmt.Sprintf("%v(%.1f)", neighbor, g.adjacencyList[vertex][neighbor].weight)
 		}
 
 		result += "\n"
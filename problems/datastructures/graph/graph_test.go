@@ -204,15 +204,19 @@ func TestShortestPath(t *testing.T) {
 	g.AddEdge("D", "E", 2)
 
 	// Test shortest path
-	path, _ := g.ShortestPath("A", "E")
+	path, cost, _ := g.ShortestPath("A", "E")
 	expectedPath := []interface{}{"A", "C", "E"} // A-C-E (total: 3) is shorter than A-B-D-E (total: 6)
 
 	if !reflect.DeepEqual(path, expectedPath) {
 		t.Errorf("Expected shortest path %v, got %v", expectedPath, path)
 	}
 
+	if cost != 3 {
+		t.Errorf("Expected path cost 3, got %.1f", cost)
+	}
+
 	// Test path to non-existent vertex
-	_, err := g.ShortestPath("A", "F")
+	_, _, err := g.ShortestPath("A", "F")
 	if err == nil {
 		t.Error("ShortestPath to non-existent vertex should return error")
 	}
@@ -222,7 +226,7 @@ func TestShortestPath(t *testing.T) {
 	g.RemoveEdge("C", "E")
 	g.RemoveEdge("D", "E")
 
-	_, err = g.ShortestPath("A", "E")
+	_, _, err = g.ShortestPath("A", "E")
 	if err == nil {
 		t.Error("ShortestPath in disconnected graph should return error")
 	}
@@ -297,3 +301,142 @@ func TestSocialNetworkFunctions(t *testing.T) {
 		t.Errorf("Expected Eve as a top recommendation, got %v", recommendations)
 	}
 }
+
+func TestTypedGraph(t *testing.T) {
+	g := New[int, string](true)
+
+	for i := 1; i <= 12; i++ {
+		g.AddVertex(i)
+	}
+
+	// Neighbours must come back in numeric, not lexical, order
+	g.AddLabeledEdge(1, 10, "ten", 2)
+	g.AddLabeledEdge(1, 2, "two")
+	g.AddLabeledEdge(1, 9, "nine")
+
+	neighbors, _ := g.GetNeighbors(1)
+	expectedNeighbors := []int{2, 9, 10}
+	if !reflect.DeepEqual(neighbors, expectedNeighbors) {
+		t.Errorf("Expected neighbors %v, got %v", expectedNeighbors, neighbors)
+	}
+
+	label, err := g.GetEdgeLabel(1, 10)
+	if err != nil || label != "ten" {
+		t.Errorf("Expected label ten, got %q (err: %v)", label, err)
+	}
+
+	if _, err := g.GetEdgeLabel(10, 1); err == nil {
+		t.Error("GetEdgeLabel on missing edge should return error")
+	}
+
+	// AddEdge leaves the label at its zero value
+	g.AddEdge(2, 3)
+	label, _ = g.GetEdgeLabel(2, 3)
+	if label != "" {
+		t.Errorf("Expected empty label, got %q", label)
+	}
+
+	edges := g.GetEdges()
+	expectedEdges := []Edge[int, string]{
+		{From: 1, To: 2, Weight: 1, Label: "two"},
+		{From: 1, To: 9, Weight: 1, Label: "nine"},
+		{From: 1, To: 10, Weight: 2, Label: "ten"},
+		{From: 2, To: 3, Weight: 1, Label: ""},
+	}
+	if !reflect.DeepEqual(edges, expectedEdges) {
+		t.Errorf("Expected edges %v, got %v", expectedEdges, edges)
+	}
+
+	path, _, _ := g.ShortestPath(1, 3)
+	if !reflect.DeepEqual(path, []int{1, 2, 3}) {
+		t.Errorf("Expected path [1 2 3], got %v", path)
+	}
+}
+
+func TestVertexAttributes(t *testing.T) {
+	g := New[string, struct{}](false)
+	g.AddVertex("db")
+
+	if err := g.SetVertexAttribute("db", "replicas", 3); err != nil {
+		t.Errorf("SetVertexAttribute failed: %v", err)
+	}
+	if err := g.SetVertexAttribute("cache", "replicas", 1); err == nil {
+		t.Error("SetVertexAttribute on missing vertex should return error")
+	}
+
+	value, found := g.GetVertexAttribute("db", "replicas")
+	if !found || value != 3 {
+		t.Errorf("Expected replicas 3, got %v (found: %v)", value, found)
+	}
+
+	// The returned attributes are a copy
+	attributes, _ := g.GetVertexAttributes("db")
+	attributes["replicas"] = 5
+	value, _ = g.GetVertexAttribute("db", "replicas")
+	if value != 3 {
+		t.Errorf("Modifying the copy should not change the graph, got %v", value)
+	}
+
+	g.RemoveVertex("db")
+	if _, found := g.GetVertexAttribute("db", "replicas"); found {
+		t.Error("Attributes should be removed with the vertex")
+	}
+}
+
+func TestDeterministicTraversal(t *testing.T) {
+	build := func() *Graph[string, struct{}] {
+		g := New[string, struct{}](false)
+		// Insert in an order unrelated to the sorted order
+		for _, v := range []string{"E", "C", "A", "D", "B"} {
+			g.AddVertex(v)
+		}
+		g.AddEdge("A", "D")
+		g.AddEdge("A", "B")
+		g.AddEdge("A", "C")
+		g.AddEdge("B", "E")
+		g.AddEdge("D", "E")
+		return g
+	}
+
+	for i := 0; i < 10; i++ {
+		g := build()
+
+		bfsOrder, _ := g.BFS("A")
+		if !reflect.DeepEqual(bfsOrder, []string{"A", "B", "C", "D", "E"}) {
+			t.Fatalf("Unexpected BFS order: %v", bfsOrder)
+		}
+
+		dfsOrder, _ := g.DFS("A")
+		if !reflect.DeepEqual(dfsOrder, []string{"A", "B", "E", "D", "C"}) {
+			t.Fatalf("Unexpected DFS order: %v", dfsOrder)
+		}
+
+		if !reflect.DeepEqual(g.GetVertices(), []string{"A", "B", "C", "D", "E"}) {
+			t.Fatalf("Unexpected vertex order: %v", g.GetVertices())
+		}
+	}
+}
+
+func TestDefaultOrderIsTotal(t *testing.T) {
+	// 1 and "1" format the same, so only their types can order them
+	build := func(vertices ...any) *Graph[any, struct{}] {
+		g := New[any, struct{}](true)
+		for _, v := range vertices {
+			g.AddVertex(v)
+		}
+		g.AddEdge(vertices[0], vertices[1])
+		g.AddEdge(vertices[1], vertices[0])
+		return g
+	}
+
+	forward := build(1, "1")
+	backward := build("1", 1)
+	expected := []any{1, "1"}
+	if !reflect.DeepEqual(forward.GetVertices(), expected) || !reflect.DeepEqual(backward.GetVertices(), expected) {
+		t.Errorf("Expected vertices %v in either insertion order, got %v and %v",
+			expected, forward.GetVertices(), backward.GetVertices())
+	}
+	if !reflect.DeepEqual(forward.GetEdges(), backward.GetEdges()) {
+		t.Errorf("Expected the same edge order, got %v and %v", forward.GetEdges(), backward.GetEdges())
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonGraph is the JSON node/edge representation of a graph
+type jsonGraph struct {
+	Directed bool       `json:"directed"`
+	Nodes    []jsonNode `json:"nodes"`
+	Edges    []jsonEdge `json:"edges"`
+}
+
+// jsonNode is a vertex in the JSON format
+type jsonNode struct {
+	ID         string     `json:"id"`
+	Attributes Attributes `json:"attributes,omitempty"`
+}
+
+// jsonEdge is an edge in the JSON format
+type jsonEdge struct {
+	From   string   `json:"from"`
+	To     string   `json:"to"`
+	Weight *float64 `json:"weight,omitempty"`
+	Label  string   `json:"label,omitempty"`
+}
+
+// WriteJSON writes the graph as a JSON document of nodes and edges:
+//
+//	{
+//	  "directed": true,
+//	  "nodes": [{"id": "A", "attributes": {"team": "core"}}, {"id": "B"}],
+//	  "edges": [{"from": "A", "to": "B", "weight": 2, "label": "calls"}]
+//	}
+//
+// Vertices and labels are written with their default formatting.
+func (g *Graph[V, E]) WriteJSON(w io.Writer) error {
+	doc := jsonGraph{
+		Directed: g.isDirected,
+		Nodes:    make([]jsonNode, 0, len(g.vertices)),
+		Edges:    make([]jsonEdge, 0),
+	}
+
+	for _, v := range g.GetVertices() {
+		node := jsonNode{ID: fmt.Sprintf("%v", v)}
+		if len(g.attributes[v]) > 0 {
+			node.Attributes = g.attributes[v]
+		}
+		doc.Nodes = append(doc.Nodes, node)
+	}
+
+	for _, edge := range g.GetEdges() {
+		weight := edge.Weight
+		label, ok := labelString(edge.Label)
+		if !ok {
+			label = ""
+		}
+		doc.Edges = append(doc.Edges, jsonEdge{
+			From:   fmt.Sprintf("%v", edge.From),
+			To:     fmt.Sprintf("%v", edge.To),
+			Weight: &weight,
+			Label:  label,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// ReadJSON reads a graph in the format written by WriteJSON. Edge endpoints
+// that are not listed as nodes are added automatically, and a missing weight
+// means the default weight.
+func ReadJSON(r io.Reader) (*Graph[string, string], error) {
+	var doc jsonGraph
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("json: %v", err)
+	}
+
+	g := New[string, string](doc.Directed)
+
+	for _, node := range doc.Nodes {
+		if node.ID == "" {
+			return nil, fmt.Errorf("json: node without id")
+		}
+		g.AddVertex(node.ID)
+		for key, value := range node.Attributes {
+			g.SetVertexAttribute(node.ID, key, value)
+		}
+	}
+
+	for _, edge := range doc.Edges {
+		if edge.From == "" || edge.To == "" {
+			return nil, fmt.Errorf("json: edge needs both from and to")
+		}
+		g.AddVertex(edge.From)
+		g.AddVertex(edge.To)
+
+		weights := make([]float64, 0, 1)
+		if edge.Weight != nil {
+			weights = append(weights, *edge.Weight)
+		}
+		if err := g.AddLabeledEdge(edge.From, edge.To, edge.Label, weights...); err != nil {
+			return nil, fmt.Errorf("json: %v", err)
+		}
+	}
+
+	return g, nil
+}
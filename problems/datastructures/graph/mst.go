@@ -0,0 +1,162 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+)
+
+// ErrDirectedGraph is returned by algorithms that require an undirected graph
+var ErrDirectedGraph = errors.New("graph must be undirected")
+
+// ErrGraphNotConnected is returned by algorithms that require a connected graph
+var ErrGraphNotConnected = errors.New("graph is not connected")
+
+// SpanningTree is a set of tree edges connecting a group of vertices
+type SpanningTree[V comparable, E any] struct {
+	Vertices []V
+	Edges    []Edge[V, E]
+	Weight   float64
+}
+
+// sortedEdges returns all edges ordered by weight, keeping the graph's
+// vertex order between equal weights so results are deterministic
+func (g *Graph[V, E]) sortedEdges() []Edge[V, E] {
+	edges := g.GetEdges()
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Weight < edges[j].Weight
+	})
+	return edges
+}
+
+// Kruskal finds a minimum spanning tree of a connected undirected graph by
+// adding the lightest edges that don't form a cycle, tracked with a DisjointSet
+func (g *Graph[V, E]) Kruskal() (*SpanningTree[V, E], error) {
+	forest, err := g.MinimumSpanningForest()
+	if err != nil {
+		return nil, err
+	}
+	if len(forest) > 1 {
+		return nil, ErrGraphNotConnected
+	}
+	if len(forest) == 0 {
+		return &SpanningTree[V, E]{}, nil
+	}
+	return forest[0], nil
+}
+
+// MinimumSpanningForest finds a minimum spanning tree of every connected
+// component of an undirected graph using Kruskal's algorithm. Trees are
+// ordered by their first vertex.
+func (g *Graph[V, E]) MinimumSpanningForest() ([]*SpanningTree[V, E], error) {
+	if g.isDirected {
+		return nil, ErrDirectedGraph
+	}
+
+	vertices := g.GetVertices()
+	sets := NewDisjointSet(vertices...)
+
+	treeEdges := make([]Edge[V, E], 0, len(vertices))
+	for _, edge := range g.sortedEdges() {
+		if sets.Union(edge.From, edge.To) {
+			treeEdges = append(treeEdges, edge)
+		}
+	}
+
+	// Group vertices and edges by the tree they belong to
+	trees := make([]*SpanningTree[V, E], 0, sets.Count())
+	byRoot := make(map[V]*SpanningTree[V, E], sets.Count())
+	for _, v := range vertices {
+		root, _ := sets.Find(v)
+		tree, exists := byRoot[root]
+		if !exists {
+			tree = &SpanningTree[V, E]{}
+			byRoot[root] = tree
+			trees = append(trees, tree)
+		}
+		tree.Vertices = append(tree.Vertices, v)
+	}
+	for _, edge := range treeEdges {
+		root, _ := sets.Find(edge.From)
+		tree := byRoot[root]
+		tree.Edges = append(tree.Edges, edge)
+		tree.Weight += edge.Weight
+	}
+
+	return trees, nil
+}
+
+// edgeHeap is a min-heap of edges ordered by weight
+type edgeHeap[V comparable, E any] []Edge[V, E]
+
+func (h edgeHeap[V, E]) Len() int           { return len(h) }
+func (h edgeHeap[V, E]) Less(i, j int) bool { return h[i].Weight < h[j].Weight }
+func (h edgeHeap[V, E]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *edgeHeap[V, E]) Push(x interface{}) {
+	*h = append(*h, x.(Edge[V, E]))
+}
+
+func (h *edgeHeap[V, E]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	edge := old[n-1]
+	*h = old[:n-1]
+	return edge
+}
+
+// Prim finds a minimum spanning tree of a connected undirected graph by
+// growing a single tree from the first vertex, always adding the lightest
+// edge that reaches a new vertex
+func (g *Graph[V, E]) Prim() (*SpanningTree[V, E], error) {
+	if g.isDirected {
+		return nil, ErrDirectedGraph
+	}
+	if len(g.vertices) == 0 {
+		return &SpanningTree[V, E]{}, nil
+	}
+
+	start := g.GetVertices()[0]
+	tree := &SpanningTree[V, E]{Vertices: []V{start}}
+	inTree := map[V]bool{start: true}
+
+	edges := &edgeHeap[V, E]{}
+	addEdges := func(from V) {
+		for _, to := range g.neighbors[from] {
+			if !inTree[to] {
+				data := g.adjacencyList[from][to]
+				heap.Push(edges, Edge[V, E]{From: from, To: to, Weight: data.weight, Label: data.label})
+			}
+		}
+	}
+	addEdges(start)
+
+	for edges.Len() > 0 && len(inTree) < len(g.vertices) {
+		edge := heap.Pop(edges).(Edge[V, E])
+		if inTree[edge.To] {
+			continue
+		}
+
+		inTree[edge.To] = true
+		tree.Vertices = append(tree.Vertices, edge.To)
+		tree.Edges = append(tree.Edges, edge)
+		tree.Weight += edge.Weight
+		addEdges(edge.To)
+	}
+
+	if len(inTree) < len(g.vertices) {
+		return nil, ErrGraphNotConnected
+	}
+
+	sortVertices(tree.Vertices, g.less)
+	return tree, nil
+}
@@ -0,0 +1,152 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDisjointSet(t *testing.T) {
+	ds := NewDisjointSet(1, 2, 3, 4, 5)
+
+	if ds.Count() != 5 || ds.Size() != 5 {
+		t.Errorf("Expected 5 sets of 5 elements, got %d sets of %d", ds.Count(), ds.Size())
+	}
+
+	if !ds.Union(1, 2) || !ds.Union(3, 4) || !ds.Union(2, 4) {
+		t.Error("Union of separate sets should return true")
+	}
+	if ds.Union(1, 3) {
+		t.Error("Union of already connected elements should return false")
+	}
+	if ds.Union(1, 6) {
+		t.Error("Union with an unknown element should return false")
+	}
+
+	if !ds.Connected(1, 4) || ds.Connected(1, 5) {
+		t.Error("Unexpected connectivity after unions")
+	}
+	if ds.Count() != 2 {
+		t.Errorf("Expected 2 sets, got %d", ds.Count())
+	}
+
+	if ds.MakeSet(1) || !ds.MakeSet(6) || ds.Count() != 3 {
+		t.Error("MakeSet should only add unknown elements")
+	}
+}
+
+// buildMSTGraph creates the classic example graph
+//
+//	A --4-- B --8-- C
+//	|     / |       |
+//	8   11  2       7
+//	| /     |       |
+//	H --1-- I --6-- G --2-- F
+func buildMSTGraph() *Graph[string, struct{}] {
+	g := New[string, struct{}](false)
+	for _, v := range []string{"A", "B", "C", "F", "G", "H", "I"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("A", "B", 4)
+	g.AddEdge("A", "H", 8)
+	g.AddEdge("B", "C", 8)
+	g.AddEdge("B", "H", 11)
+	g.AddEdge("B", "I", 2)
+	g.AddEdge("C", "G", 7)
+	g.AddEdge("H", "I", 1)
+	g.AddEdge("I", "G", 6)
+	g.AddEdge("G", "F", 2)
+	return g
+}
+
+func TestKruskalAndPrim(t *testing.T) {
+	g := buildMSTGraph()
+
+	kruskal, err := g.Kruskal()
+	if err != nil {
+		t.Fatalf("Kruskal failed: %v", err)
+	}
+	prim, err := g.Prim()
+	if err != nil {
+		t.Fatalf("Prim failed: %v", err)
+	}
+
+	for name, tree := range map[string]*SpanningTree[string, struct{}]{"Kruskal": kruskal, "Prim": prim} {
+		if tree.Weight != 22 {
+			t.Errorf("%s: expected weight 22, got %.1f", name, tree.Weight)
+		}
+		if len(tree.Edges) != g.VertexCount()-1 {
+			t.Errorf("%s: expected %d edges, got %d", name, g.VertexCount()-1, len(tree.Edges))
+		}
+		if !reflect.DeepEqual(tree.Vertices, g.GetVertices()) {
+			t.Errorf("%s: expected vertices %v, got %v", name, g.GetVertices(), tree.Vertices)
+		}
+
+		// The tree edges must connect every vertex
+		sets := NewDisjointSet(g.GetVertices()...)
+		for _, edge := range tree.Edges {
+			if !g.HasEdge(edge.From, edge.To) {
+				t.Errorf("%s: tree uses missing edge %v", name, edge)
+			}
+			sets.Union(edge.From, edge.To)
+		}
+		if sets.Count() != 1 {
+			t.Errorf("%s: tree edges leave %d components", name, sets.Count())
+		}
+	}
+}
+
+func TestMinimumSpanningForest(t *testing.T) {
+	g := buildMSTGraph()
+	g.AddVertex("X")
+	g.AddVertex("Y")
+	g.AddVertex("Z")
+	g.AddEdge("X", "Y", 3)
+
+	if _, err := g.Kruskal(); err != ErrGraphNotConnected {
+		t.Errorf("Kruskal: expected ErrGraphNotConnected, got %v", err)
+	}
+	if _, err := g.Prim(); err != ErrGraphNotConnected {
+		t.Errorf("Prim: expected ErrGraphNotConnected, got %v", err)
+	}
+
+	forest, err := g.MinimumSpanningForest()
+	if err != nil {
+		t.Fatalf("MinimumSpanningForest failed: %v", err)
+	}
+	if len(forest) != 3 {
+		t.Fatalf("Expected 3 trees, got %d", len(forest))
+	}
+
+	expectedWeights := []float64{22, 3, 0}
+	expectedSizes := []int{7, 2, 1}
+	for i, tree := range forest {
+		if tree.Weight != expectedWeights[i] || len(tree.Vertices) != expectedSizes[i] {
+			t.Errorf("Tree %d: expected %d vertices with weight %.0f, got %v with weight %.0f",
+				i, expectedSizes[i], expectedWeights[i], tree.Vertices, tree.Weight)
+		}
+	}
+}
+
+func TestSpanningTreeRequiresUndirectedGraph(t *testing.T) {
+	g := New[string, struct{}](true)
+	g.AddVertex("A")
+
+	if _, err := g.Kruskal(); err != ErrDirectedGraph {
+		t.Errorf("Kruskal: expected ErrDirectedGraph, got %v", err)
+	}
+	if _, err := g.Prim(); err != ErrDirectedGraph {
+		t.Errorf("Prim: expected ErrDirectedGraph, got %v", err)
+	}
+	if _, err := g.MinimumSpanningForest(); err != ErrDirectedGraph {
+		t.Errorf("MinimumSpanningForest: expected ErrDirectedGraph, got %v", err)
+	}
+}
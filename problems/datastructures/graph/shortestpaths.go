@@ -0,0 +1,303 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNegativeWeight is returned by Dijkstra-based algorithms when the graph has a negative edge
+var ErrNegativeWeight = errors.New("graph has negative edge weights; use BellmanFord or Johnson")
+
+// NegativeCycleError is returned when shortest paths are undefined because of
+// a cycle whose total weight is negative. Cycle lists the vertices of the
+// cycle in order, starting and ending with the same vertex.
+type NegativeCycleError[V comparable] struct {
+	Cycle []V
+}
+
+func (e *NegativeCycleError[V]) Error() string {
+	return fmt.Sprintf("graph has a negative cycle: %v", e.Cycle)
+}
+
+// ShortestPathTree holds the shortest paths from a single source vertex
+type ShortestPathTree[V comparable] struct {
+	Source V
+	dist   map[V]float64
+	prev   map[V]V
+}
+
+// Distance returns the length of the shortest path from the source to a
+// vertex, and false if the vertex is unreachable
+func (t *ShortestPathTree[V]) Distance(to V) (float64, bool) {
+	d, exists := t.dist[to]
+	if !exists || math.IsInf(d, 1) {
+		return math.Inf(1), false
+	}
+	return d, true
+}
+
+// PathTo returns the shortest path from the source to a vertex and its total weight
+func (t *ShortestPathTree[V]) PathTo(to V) ([]V, float64, error) {
+	if _, exists := t.dist[to]; !exists {
+		return nil, 0, fmt.Errorf("vertex %v does not exist", to)
+	}
+	return buildPath(t.Source, to, t.dist, t.prev)
+}
+
+// AllPairsShortestPaths holds the shortest paths between every pair of vertices
+type AllPairsShortestPaths[V comparable] struct {
+	vertices []V
+	index    map[V]int
+	dist     [][]float64
+	prev     [][]int // prev[i][j] is the vertex before j on the path from i, or -1
+}
+
+// newAllPairsShortestPaths creates an empty result for the given vertices
+func newAllPairsShortestPaths[V comparable](vertices []V) *AllPairsShortestPaths[V] {
+	n := len(vertices)
+	result := &AllPairsShortestPaths[V]{
+		vertices: vertices,
+		index:    make(map[V]int, n),
+		dist:     make([][]float64, n),
+		prev:     make([][]int, n),
+	}
+
+	for i, v := range vertices {
+		result.index[v] = i
+		result.dist[i] = make([]float64, n)
+		result.prev[i] = make([]int, n)
+		for j := range vertices {
+			result.dist[i][j] = math.Inf(1)
+			result.prev[i][j] = -1
+		}
+		result.dist[i][i] = 0
+	}
+
+	return result
+}
+
+// Distance returns the length of the shortest path between two vertices, and
+// false if either vertex is unknown or there is no path
+func (a *AllPairsShortestPaths[V]) Distance(from, to V) (float64, bool) {
+	i, okFrom := a.index[from]
+	j, okTo := a.index[to]
+	if !okFrom || !okTo || math.IsInf(a.dist[i][j], 1) {
+		return math.Inf(1), false
+	}
+	return a.dist[i][j], true
+}
+
+// Path returns the shortest path between two vertices and its total weight
+func (a *AllPairsShortestPaths[V]) Path(from, to V) ([]V, float64, error) {
+	i, exists := a.index[from]
+	if !exists {
+		return nil, 0, fmt.Errorf("vertex %v does not exist", from)
+	}
+	j, exists := a.index[to]
+	if !exists {
+		return nil, 0, fmt.Errorf("vertex %v does not exist", to)
+	}
+	if math.IsInf(a.dist[i][j], 1) {
+		return nil, 0, fmt.Errorf("no path from %v to %v", from, to)
+	}
+
+	path := []V{to}
+	for k := j; k != i; {
+		k = a.prev[i][k]
+		path = append([]V{a.vertices[k]}, path...)
+	}
+
+	return path, a.dist[i][j], nil
+}
+
+// BellmanFord computes the shortest paths from start to every vertex. Unlike
+// ShortestPath it supports negative edge weights; if a negative cycle is
+// reachable from start a *NegativeCycleError describing it is returned.
+func (g *Graph[V, E]) BellmanFord(start V) (*ShortestPathTree[V], error) {
+	if !g.HasVertex(start) {
+		return nil, fmt.Errorf("start vertex %v does not exist", start)
+	}
+
+	dist, prev, cycle := g.bellmanFord([]V{start})
+	if cycle != nil {
+		return nil, &NegativeCycleError[V]{Cycle: cycle}
+	}
+
+	return &ShortestPathTree[V]{Source: start, dist: dist, prev: prev}, nil
+}
+
+// bellmanFord relaxes every edge |V|-1 times starting from the given sources.
+// It returns the distances, predecessors and, if one is reachable from the
+// sources, a negative cycle.
+func (g *Graph[V, E]) bellmanFord(sources []V) (map[V]float64, map[V]V, []V) {
+	vertices := g.GetVertices()
+	dist := make(map[V]float64, len(vertices))
+	prev := make(map[V]V)
+
+	for _, v := range vertices {
+		dist[v] = math.Inf(1)
+	}
+	for _, s := range sources {
+		dist[s] = 0
+	}
+
+	// relax performs one pass over all edges and returns the last vertex updated
+	relax := func() (V, bool) {
+		var last V
+		updated := false
+		for _, u := range vertices {
+			if math.IsInf(dist[u], 1) {
+				continue
+			}
+			for _, v := range g.neighbors[u] {
+				if alt := dist[u] + g.weightOf(u, v); alt < dist[v] {
+					dist[v] = alt
+					prev[v] = u
+					last = v
+					updated = true
+				}
+			}
+		}
+		return last, updated
+	}
+
+	for i := 0; i < len(vertices)-1; i++ {
+		if _, updated := relax(); !updated {
+			return dist, prev, nil
+		}
+	}
+
+	// Any further improvement means a negative cycle
+	last, updated := relax()
+	if !updated {
+		return dist, prev, nil
+	}
+
+	// Walking back |V| predecessors is guaranteed to land on the cycle
+	x := last
+	for i := 0; i < len(vertices); i++ {
+		x = prev[x]
+	}
+
+	cycle := []V{x}
+	for v := prev[x]; v != x; v = prev[v] {
+		cycle = append(cycle, v)
+	}
+	cycle = append(cycle, x)
+
+	// Predecessors run backwards along the cycle
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+
+	return dist, prev, cycle
+}
+
+// FloydWarshall computes the shortest paths between every pair of vertices in
+// O(V^3). Negative edge weights are allowed; a *NegativeCycleError is returned
+// if the graph has a negative cycle.
+func (g *Graph[V, E]) FloydWarshall() (*AllPairsShortestPaths[V], error) {
+	vertices := g.GetVertices()
+	result := newAllPairsShortestPaths(vertices)
+	n := len(vertices)
+
+	for i, u := range vertices {
+		for _, v := range g.neighbors[u] {
+			j := result.index[v]
+			if w := g.weightOf(u, v); w < result.dist[i][j] {
+				result.dist[i][j] = w
+				result.prev[i][j] = i
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if math.IsInf(result.dist[i][k], 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if alt := result.dist[i][k] + result.dist[k][j]; alt < result.dist[i][j] {
+					result.dist[i][j] = alt
+					result.prev[i][j] = result.prev[k][j]
+				}
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if result.dist[i][i] < 0 {
+			_, _, cycle := g.bellmanFord(vertices)
+			return nil, &NegativeCycleError[V]{Cycle: cycle}
+		}
+	}
+
+	return result, nil
+}
+
+// Johnson computes the shortest paths between every pair of vertices by
+// reweighting the edges with Bellman-Ford so they are non-negative and then
+// running Dijkstra from every vertex. It is faster than FloydWarshall on
+// sparse graphs. A *NegativeCycleError is returned if the graph has a negative cycle.
+func (g *Graph[V, E]) Johnson() (*AllPairsShortestPaths[V], error) {
+	vertices := g.GetVertices()
+
+	// Starting from every vertex at distance zero is the same as adding a
+	// new source with zero-weight edges to all vertices
+	potential, _, cycle := g.bellmanFord(vertices)
+	if cycle != nil {
+		return nil, &NegativeCycleError[V]{Cycle: cycle}
+	}
+
+	// The reweighted edges are non-negative; clamp rounding noise to zero
+	reweighted := func(u, v V) float64 {
+		return math.Max(0, g.weightOf(u, v)+potential[u]-potential[v])
+	}
+
+	result := newAllPairsShortestPaths(vertices)
+	for i, u := range vertices {
+		dist, prev := g.bestFirstSearch(u, u, false, reweighted, nil)
+		for j, v := range vertices {
+			if math.IsInf(dist[v], 1) || i == j {
+				continue
+			}
+			result.dist[i][j] = dist[v] - potential[u] + potential[v]
+			result.prev[i][j] = result.index[prev[v]]
+		}
+	}
+
+	return result, nil
+}
+
+// AStar finds the shortest path between two vertices guided by a heuristic
+// that estimates the remaining distance from a vertex to end. The heuristic
+// must never overestimate that distance, and the graph must not have negative
+// edges. It returns the path and its total weight.
+func (g *Graph[V, E]) AStar(start, end V, heuristic func(v V) float64) ([]V, float64, error) {
+	if !g.HasVertex(start) {
+		return nil, 0, fmt.Errorf("start vertex %v does not exist", start)
+	}
+	if !g.HasVertex(end) {
+		return nil, 0, fmt.Errorf("end vertex %v does not exist", end)
+	}
+	if heuristic == nil {
+		return nil, 0, errors.New("heuristic must not be nil")
+	}
+	if g.hasNegativeWeight() {
+		return nil, 0, ErrNegativeWeight
+	}
+
+	dist, prev := g.bestFirstSearch(start, end, true, g.weightOf, heuristic)
+
+	return buildPath(start, end, dist, prev)
+}
@@ -0,0 +1,242 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// buildNegativeWeightGraph creates a directed graph with negative edges but no negative cycle
+//
+//	A -4-> B -(-2)-> C
+//	A -----5-------> C -3-> D
+//	B -(-1)-> D
+func buildNegativeWeightGraph() *Graph[string, struct{}] {
+	g := New[string, struct{}](true)
+	for _, v := range []string{"A", "B", "C", "D", "E"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("A", "B", 4)
+	g.AddEdge("A", "C", 5)
+	g.AddEdge("B", "C", -2)
+	g.AddEdge("C", "D", 3)
+	g.AddEdge("B", "D", -1)
+	return g
+}
+
+func TestShortestPathRejectsNegativeWeights(t *testing.T) {
+	g := buildNegativeWeightGraph()
+
+	if _, _, err := g.ShortestPath("A", "D"); err != ErrNegativeWeight {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+
+	if err := g.AddEdge("A", "E", math.NaN()); err == nil {
+		t.Error("AddEdge should reject a NaN weight")
+	}
+}
+
+func TestBellmanFord(t *testing.T) {
+	g := buildNegativeWeightGraph()
+
+	tree, err := g.BellmanFord("A")
+	if err != nil {
+		t.Fatalf("BellmanFord failed: %v", err)
+	}
+
+	path, cost, err := tree.PathTo("D")
+	if err != nil {
+		t.Fatalf("PathTo failed: %v", err)
+	}
+	if !reflect.DeepEqual(path, []string{"A", "B", "D"}) || cost != 3 {
+		t.Errorf("Expected [A B D] with cost 3, got %v with cost %.1f", path, cost)
+	}
+
+	if d, ok := tree.Distance("C"); !ok || d != 2 {
+		t.Errorf("Expected distance 2 to C, got %.1f (reachable: %v)", d, ok)
+	}
+
+	if _, ok := tree.Distance("E"); ok {
+		t.Error("E should be unreachable")
+	}
+	if _, _, err := tree.PathTo("E"); err == nil {
+		t.Error("PathTo an unreachable vertex should return error")
+	}
+}
+
+func TestBellmanFordReportsNegativeCycle(t *testing.T) {
+	g := buildNegativeWeightGraph()
+	g.AddEdge("D", "B", -1)
+
+	_, err := g.BellmanFord("A")
+
+	var cycleErr *NegativeCycleError[string]
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Expected NegativeCycleError, got %v", err)
+	}
+
+	cycle := cycleErr.Cycle
+	if cycle[0] != cycle[len(cycle)-1] {
+		t.Fatalf("Cycle should start and end with the same vertex: %v", cycle)
+	}
+
+	total := 0.0
+	for i := 0; i+1 < len(cycle); i++ {
+		w, err := g.GetEdgeWeight(cycle[i], cycle[i+1])
+		if err != nil {
+			t.Fatalf("Cycle %v uses missing edge: %v", cycle, err)
+		}
+		total += w
+	}
+	if total >= 0 {
+		t.Errorf("Cycle %v has non-negative weight %.1f", cycle, total)
+	}
+
+	// A negative cycle not reachable from the source doesn't matter
+	if _, err := g.BellmanFord("E"); err != nil {
+		t.Errorf("BellmanFord from E should succeed, got %v", err)
+	}
+}
+
+func TestAllPairsShortestPaths(t *testing.T) {
+	g := buildNegativeWeightGraph()
+
+	floyd, err := g.FloydWarshall()
+	if err != nil {
+		t.Fatalf("FloydWarshall failed: %v", err)
+	}
+	johnson, err := g.Johnson()
+	if err != nil {
+		t.Fatalf("Johnson failed: %v", err)
+	}
+
+	vertices := g.GetVertices()
+	for _, from := range vertices {
+		tree, _ := g.BellmanFord(from)
+
+		for _, to := range vertices {
+			expected, reachable := tree.Distance(to)
+
+			for name, result := range map[string]*AllPairsShortestPaths[string]{"FloydWarshall": floyd, "Johnson": johnson} {
+				d, ok := result.Distance(from, to)
+				if ok != reachable || (ok && d != expected) {
+					t.Errorf("%s: distance %s -> %s = %.1f (%v), expected %.1f (%v)", name, from, to, d, ok, expected, reachable)
+				}
+
+				if !reachable {
+					continue
+				}
+
+				path, cost, err := result.Path(from, to)
+				if err != nil || cost != expected || path[0] != from || path[len(path)-1] != to {
+					t.Errorf("%s: bad path %s -> %s: %v cost %.1f (err: %v)", name, from, to, path, cost, err)
+				}
+			}
+		}
+	}
+}
+
+func TestAllPairsReportNegativeCycle(t *testing.T) {
+	g := buildNegativeWeightGraph()
+	g.AddEdge("C", "B", 1)
+
+	var cycleErr *NegativeCycleError[string]
+	if _, err := g.FloydWarshall(); !errors.As(err, &cycleErr) {
+		t.Errorf("FloydWarshall: expected NegativeCycleError, got %v", err)
+	}
+	if _, err := g.Johnson(); !errors.As(err, &cycleErr) {
+		t.Errorf("Johnson: expected NegativeCycleError, got %v", err)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	// 5x5 grid where every vertex is connected to its right and lower neighbour
+	type point struct{ x, y int }
+	less := func(a, b point) bool { return a.x < b.x || (a.x == b.x && a.y < b.y) }
+	g := NewWithOrder[point, struct{}](false, less)
+
+	for x := 0; x < 5; x++ {
+		for y := 0; y < 5; y++ {
+			g.AddVertex(point{x, y})
+		}
+	}
+	for x := 0; x < 5; x++ {
+		for y := 0; y < 5; y++ {
+			if x+1 < 5 {
+				g.AddEdge(point{x, y}, point{x + 1, y})
+			}
+			if y+1 < 5 {
+				g.AddEdge(point{x, y}, point{x, y + 1})
+			}
+		}
+	}
+
+	// Block the middle column except at the bottom
+	for y := 0; y < 4; y++ {
+		g.RemoveVertex(point{2, y})
+	}
+
+	goal := point{4, 0}
+	manhattan := func(p point) float64 {
+		return math.Abs(float64(goal.x-p.x)) + math.Abs(float64(goal.y-p.y))
+	}
+
+	path, cost, err := g.AStar(point{0, 0}, goal, manhattan)
+	if err != nil {
+		t.Fatalf("AStar failed: %v", err)
+	}
+
+	_, dijkstraCost, _ := g.ShortestPath(point{0, 0}, goal)
+	if cost != dijkstraCost || cost != 12 {
+		t.Errorf("Expected cost 12 matching Dijkstra (%.0f), got %.0f", dijkstraCost, cost)
+	}
+	if len(path) != 13 || path[0] != (point{0, 0}) || path[len(path)-1] != goal {
+		t.Errorf("Unexpected path: %v", path)
+	}
+
+	if _, _, err := g.AStar(point{0, 0}, goal, nil); err == nil {
+		t.Error("AStar without a heuristic should return error")
+	}
+}
+
+func TestAStarInconsistentHeuristic(t *testing.T) {
+	g := New[string, struct{}](true)
+	for _, v := range []string{"S", "A", "B", "C", "G"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("S", "A", 1)
+	g.AddEdge("S", "B", 1)
+	g.AddEdge("A", "C", 1)
+	g.AddEdge("B", "C", 3)
+	g.AddEdge("C", "G", 10)
+
+	// Admissible but not consistent: C is settled through B before A is expanded
+	heuristic := func(v string) float64 {
+		if v == "A" {
+			return 11
+		}
+		return 0
+	}
+
+	path, cost, err := g.AStar("S", "G", heuristic)
+	if err != nil {
+		t.Fatalf("AStar failed: %v", err)
+	}
+	if cost != 12 || strings.Join(path, ",") != "S,A,C,G" {
+		t.Errorf("Expected [S A C G] with cost 12, got %v with cost %.0f", path, cost)
+	}
+	if _, dijkstraCost, _ := g.ShortestPath("S", "G"); cost != dijkstraCost {
+		t.Errorf("Expected cost to match Dijkstra (%.0f), got %.0f", dijkstraCost, cost)
+	}
+}
@@ -67,8 +67,15 @@ func RunExample() {
 
 	// Shortest path
 	fmt.Println("\n3. Finding Shortest Paths")
-	path, _ := undirectedGraph.ShortestPath("A", "E")
-	fmt.Printf("Shortest path from A to E: %v\n", path)
+	path, cost, _ := undirectedGraph.ShortestPath("A", "E")
+	fmt.Printf("Shortest path from A to E: %v (cost: %.1f)\n", path, cost)
+
+	// Minimum spanning tree
+	mst, _ := undirectedGraph.Kruskal()
+	fmt.Printf("Minimum spanning tree weight: %.1f\n", mst.Weight)
+	for _, edge := range mst.Edges {
+		fmt.Printf("  %v - %v (%.1f)\n", edge.From, edge.To, edge.Weight)
+	}
 
 	// Create a directed graph
 	fmt.Println("\n4. Creating a directed graph")
@@ -116,10 +123,87 @@ func RunExample() {
 	// Find friend recommendations based on mutual friends
 	recommendations := recommendFriends(socialNetwork, "Alice")
 	fmt.Printf("Friend recommendations for Alice: %v\n", recommendations)
+
+	// Typed graph with edge labels and vertex attributes
+	fmt.Println("\n8. Typed Graph: Flight Routes")
+	routes := createFlightRoutesGraph()
+	fmt.Println(routes)
+
+	for _, edge := range routes.GetEdges() {
+		fmt.Printf("%s -> %s: %s (%.0f km)\n", edge.From, edge.To, edge.Label, edge.Weight)
+	}
+
+	country, _ := routes.GetVertexAttribute("IST", "country")
+	fmt.Printf("IST is in %v\n", country)
+
+	route, distance, _ := routes.ShortestPath("IST", "JFK")
+	fmt.Printf("Shortest route from IST to JFK: %v (%.0f km)\n", route, distance)
+
+	// Dependency graph of build jobs
+	fmt.Println("\n9. DAG: Build Pipeline")
+	pipeline := createBuildPipelineGraph()
+
+	order, _ := pipeline.TopologicalSort()
+	fmt.Printf("Build order: %v\n", order)
+
+	stages, _ := pipeline.Layers()
+	for i, stage := range stages {
+		fmt.Printf("Stage %d (parallel): %v\n", i+1, stage)
+	}
+
+	criticalPath, duration, _ := pipeline.LongestPath()
+	fmt.Printf("Critical path: %v (%.0f minutes)\n", criticalPath, duration)
+
+	pipeline.AddEdge("deploy", "fetch")
+	if _, err := pipeline.TopologicalSort(); err != nil {
+		fmt.Printf("After adding deploy -> fetch: %v\n", err)
+	}
+}
+
+// createBuildPipelineGraph creates a DAG of build jobs weighted by duration in minutes
+func createBuildPipelineGraph() *Graph[string, struct{}] {
+	g := New[string, struct{}](true)
+
+	for _, job := range []string{"fetch", "compile", "lint", "test", "package", "deploy"} {
+		g.AddVertex(job)
+	}
+
+	g.AddEdge("fetch", "compile", 2)
+	g.AddEdge("fetch", "lint", 2)
+	g.AddEdge("compile", "test", 5)
+	g.AddEdge("compile", "package", 5)
+	g.AddEdge("lint", "package", 1)
+	g.AddEdge("test", "package", 8)
+	g.AddEdge("package", "deploy", 3)
+
+	return g
+}
+
+// createFlightRoutesGraph creates a typed graph of airports connected by flights
+func createFlightRoutesGraph() *Graph[string, string] {
+	g := New[string, string](true)
+
+	airports := map[string]string{
+		"IST": "Turkey",
+		"LHR": "United Kingdom",
+		"FRA": "Germany",
+		"JFK": "United States",
+	}
+	for code, country := range airports {
+		g.AddVertex(code)
+		g.SetVertexAttribute(code, "country", country)
+	}
+
+	g.AddLabeledEdge("IST", "LHR", "TK1971", 2500)
+	g.AddLabeledEdge("IST", "FRA", "TK1589", 1860)
+	g.AddLabeledEdge("LHR", "JFK", "BA117", 5540)
+	g.AddLabeledEdge("FRA", "JFK", "LH400", 6200)
+
+	return g
 }
 
 // createSocialNetworkGraph creates a sample social network graph
-func createSocialNetworkGraph() *Graph {
+func createSocialNetworkGraph() *UntypedGraph {
 	g := NewGraph(false)
 
 	// Add people
@@ -141,7 +225,7 @@ func createSocialNetworkGraph() *Graph {
 }
 
 // findMutualFriends finds mutual friends between two people
-func findMutualFriends(g *Graph, person1, person2 string) []interface{} {
+func findMutualFriends(g *UntypedGraph, person1, person2 string) []interface{} {
 	friends1, _ := g.GetNeighbors(person1)
 	friends2, _ := g.GetNeighbors(person2)
 
@@ -160,7 +244,7 @@ func findMutualFriends(g *Graph, person1, person2 string) []interface{} {
 }
 
 // recommendFriends recommends potential friends based on mutual friends
-func recommendFriends(g *Graph, person string) []interface{} {
+func recommendFriends(g *UntypedGraph, person string) []interface{} {
 	recommendations := make(map[interface{}]int) // person -> mutual friend count
 
 	// Get direct friends
@@ -0,0 +1,224 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"container/list"
+	"fmt"
+)
+
+// EdgeType classifies an edge by how a depth-first search reached it
+type EdgeType int
+
+const (
+	// TreeEdge leads to a vertex seen for the first time
+	TreeEdge EdgeType = iota
+	// BackEdge leads to an ancestor that is still being explored
+	BackEdge
+	// ForwardEdge leads to an already finished descendant (directed graphs only)
+	ForwardEdge
+	// CrossEdge leads to a finished vertex that is not a descendant (directed graphs only)
+	CrossEdge
+)
+
+func (t EdgeType) String() string {
+	switch t {
+	case TreeEdge:
+		return "tree"
+	case BackEdge:
+		return "back"
+	case ForwardEdge:
+		return "forward"
+	case CrossEdge:
+		return "cross"
+	default:
+		return fmt.Sprintf("EdgeType(%d)", int(t))
+	}
+}
+
+// Visitor holds the callbacks of a depth-first walk. Nil callbacks are
+// skipped, and any callback can return false to stop the walk early.
+//
+// In an undirected graph every edge is reported once, as either a tree or a
+// back edge; the edge back to a vertex's DFS parent is not reported again.
+type Visitor[V comparable] struct {
+	// PreVisit is called when a vertex is discovered
+	PreVisit func(vertex V) bool
+	// PostVisit is called when all of a vertex's neighbours have been explored
+	PostVisit func(vertex V) bool
+	// Edge is called for every edge examined, before following tree edges
+	Edge func(from, to V, kind EdgeType) bool
+}
+
+// vertex colours of a depth-first search
+const (
+	white = iota // not discovered
+	gray         // discovered, still on the stack
+	black        // finished
+)
+
+// dfsFrame is an explicit stack entry replacing a recursive call
+type dfsFrame[V comparable] struct {
+	vertex    V
+	parent    V
+	hasParent bool
+	next      int // index of the next neighbour to examine
+}
+
+// dfsState is shared between walks so that WalkDFSAll covers every component once
+type dfsState[V comparable] struct {
+	color     map[V]int
+	discovery map[V]int
+	timer     int
+}
+
+func newDFSState[V comparable]() *dfsState[V] {
+	return &dfsState[V]{color: make(map[V]int), discovery: make(map[V]int)}
+}
+
+// walkDFS runs an iterative depth-first search from start over an adjacency
+// list, reporting false if a callback stopped the walk
+func walkDFS[V comparable](start V, adjacency map[V][]V, directed bool, visitor Visitor[V], state *dfsState[V]) bool {
+	discover := func(v V) bool {
+		state.color[v] = gray
+		state.discovery[v] = state.timer
+		state.timer++
+		return visitor.PreVisit == nil || visitor.PreVisit(v)
+	}
+	edge := func(from, to V, kind EdgeType) bool {
+		return visitor.Edge == nil || visitor.Edge(from, to, kind)
+	}
+
+	if !discover(start) {
+		return false
+	}
+	stack := []dfsFrame[V]{{vertex: start}}
+
+	for len(stack) > 0 {
+		top := len(stack) - 1
+		frame := &stack[top]
+		neighbors := adjacency[frame.vertex]
+
+		if frame.next == len(neighbors) {
+			// All neighbours explored: finish the vertex
+			state.color[frame.vertex] = black
+			stack = stack[:top]
+			if visitor.PostVisit != nil && !visitor.PostVisit(frame.vertex) {
+				return false
+			}
+			continue
+		}
+
+		from := frame.vertex
+		to := neighbors[frame.next]
+		frame.next++
+
+		switch state.color[to] {
+		case white:
+			if !edge(from, to, TreeEdge) || !discover(to) {
+				return false
+			}
+			stack = append(stack, dfsFrame[V]{vertex: to, parent: from, hasParent: true})
+		case gray:
+			// The reverse of the tree edge we arrived by is not a new edge
+			if !directed && frame.hasParent && to == frame.parent {
+				continue
+			}
+			if !edge(from, to, BackEdge) {
+				return false
+			}
+		case black:
+			// In an undirected graph this is a back edge already seen from the other end
+			if !directed {
+				continue
+			}
+			kind := CrossEdge
+			if state.discovery[from] < state.discovery[to] {
+				kind = ForwardEdge
+			}
+			if !edge(from, to, kind) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// WalkDFS performs an iterative depth-first search from a starting vertex,
+// calling the visitor's hooks along the way. Neighbours are visited in
+// vertex order, so the walk is deterministic.
+func (g *Graph[V, E]) WalkDFS(start V, visitor Visitor[V]) error {
+	if !g.HasVertex(start) {
+		return fmt.Errorf("vertex %v does not exist", start)
+	}
+
+	walkDFS(start, g.neighbors, g.isDirected, visitor, newDFSState[V]())
+	return nil
+}
+
+// WalkDFSAll performs a depth-first search that covers every component,
+// starting a new walk from each undiscovered vertex in vertex order.
+// It returns false if a callback stopped the walk.
+func (g *Graph[V, E]) WalkDFSAll(visitor Visitor[V]) bool {
+	state := newDFSState[V]()
+
+	for _, v := range g.GetVertices() {
+		if state.color[v] == white {
+			if !walkDFS(v, g.neighbors, g.isDirected, visitor, state) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DFSAll returns every vertex in depth-first order, covering all components
+func (g *Graph[V, E]) DFSAll() []V {
+	result := make([]V, 0, len(g.vertices))
+	g.WalkDFSAll(Visitor[V]{
+		PreVisit: func(v V) bool {
+			result = append(result, v)
+			return true
+		},
+	})
+	return result
+}
+
+// BFSAll returns every vertex in breadth-first order, covering all components
+func (g *Graph[V, E]) BFSAll() []V {
+	visited := make(map[V]bool)
+	result := make([]V, 0, len(g.vertices))
+
+	for _, start := range g.GetVertices() {
+		if visited[start] {
+			continue
+		}
+
+		queue := list.New()
+		queue.PushBack(start)
+		visited[start] = true
+
+		for queue.Len() > 0 {
+			vertex := queue.Remove(queue.Front()).(V)
+			result = append(result, vertex)
+
+			for _, neighbor := range g.neighbors[vertex] {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					queue.PushBack(neighbor)
+				}
+			}
+		}
+	}
+
+	return result
+}
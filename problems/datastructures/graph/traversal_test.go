@@ -0,0 +1,187 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// classifiedEdge records an edge reported to a visitor
+type classifiedEdge struct {
+	from, to string
+	kind     EdgeType
+}
+
+func TestWalkDFSEdgeClassification(t *testing.T) {
+	g := New[string, struct{}](true)
+	for _, v := range []string{"a", "b", "c", "d"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "a") // back
+	g.AddEdge("a", "c") // forward, c is finished when a examines it
+	g.AddEdge("d", "b") // cross, reached from a later walk
+
+	pre := make([]string, 0)
+	post := make([]string, 0)
+	edges := make([]classifiedEdge, 0)
+
+	completed := g.WalkDFSAll(Visitor[string]{
+		PreVisit:  func(v string) bool { pre = append(pre, v); return true },
+		PostVisit: func(v string) bool { post = append(post, v); return true },
+		Edge: func(from, to string, kind EdgeType) bool {
+			edges = append(edges, classifiedEdge{from, to, kind})
+			return true
+		},
+	})
+
+	if !completed {
+		t.Error("Expected walk to complete")
+	}
+	if expected := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(pre, expected) {
+		t.Errorf("Expected pre-order %v, got %v", expected, pre)
+	}
+	if expected := []string{"c", "b", "a", "d"}; !reflect.DeepEqual(post, expected) {
+		t.Errorf("Expected post-order %v, got %v", expected, post)
+	}
+
+	expected := []classifiedEdge{
+		{"a", "b", TreeEdge},
+		{"b", "c", TreeEdge},
+		{"c", "a", BackEdge},
+		{"a", "c", ForwardEdge},
+		{"d", "b", CrossEdge},
+	}
+	if !reflect.DeepEqual(edges, expected) {
+		t.Errorf("Expected edges %v, got %v", expected, edges)
+	}
+}
+
+func TestWalkDFSUndirected(t *testing.T) {
+	g := New[string, struct{}](false)
+	g.AddVertex("a")
+	g.AddVertex("b")
+	g.AddVertex("c")
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "a")
+
+	counts := make(map[EdgeType]int)
+	err := g.WalkDFS("a", Visitor[string]{
+		Edge: func(from, to string, kind EdgeType) bool {
+			counts[kind]++
+			return true
+		},
+	})
+	if err != nil {
+		t.Fatalf("WalkDFS failed: %v", err)
+	}
+
+	// Each undirected edge is reported exactly once
+	if counts[TreeEdge] != 2 || counts[BackEdge] != 1 || len(counts) != 2 {
+		t.Errorf("Expected 2 tree edges and 1 back edge, got %v", counts)
+	}
+
+	if err := g.WalkDFS("z", Visitor[string]{}); err == nil {
+		t.Error("WalkDFS should fail for a missing vertex")
+	}
+}
+
+func TestWalkDFSEarlyTermination(t *testing.T) {
+	g := New[int, struct{}](true)
+	for i := 0; i < 10; i++ {
+		g.AddVertex(i)
+	}
+	for i := 0; i+1 < 10; i++ {
+		g.AddEdge(i, i+1)
+	}
+
+	visited := make([]int, 0)
+	completed := g.WalkDFSAll(Visitor[int]{
+		PreVisit: func(v int) bool {
+			visited = append(visited, v)
+			return v != 3
+		},
+	})
+
+	if completed {
+		t.Error("Expected walk to stop early")
+	}
+	if expected := []int{0, 1, 2, 3}; !reflect.DeepEqual(visited, expected) {
+		t.Errorf("Expected to visit %v, got %v", expected, visited)
+	}
+}
+
+func TestDFSLongChain(t *testing.T) {
+	const n = 200000
+	limitStack(t)
+
+	g := New[int, struct{}](true)
+	for i := 0; i < n; i++ {
+		g.AddVertex(i)
+	}
+	for i := 0; i+1 < n; i++ {
+		g.AddEdge(i, i+1)
+	}
+
+	order, err := g.DFS(0)
+	if err != nil {
+		t.Fatalf("DFS failed: %v", err)
+	}
+	if len(order) != n || order[n-1] != n-1 {
+		t.Errorf("Expected DFS to visit all %d vertices in order", n)
+	}
+
+	g.AddEdge(n-1, 0)
+	if !g.HasCycle() {
+		t.Error("Expected a cycle after closing the chain")
+	}
+}
+
+func TestTraversalsCoverAllComponents(t *testing.T) {
+	g := New[string, struct{}](false)
+	for _, v := range []string{"a", "b", "c", "d", "e", "f"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("a", "c")
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "d")
+	g.AddEdge("e", "f")
+
+	tests := []struct {
+		name     string
+		order    []string
+		expected []string
+	}{
+		{"DFSAll", g.DFSAll(), []string{"a", "b", "d", "c", "e", "f"}},
+		{"BFSAll", g.BFSAll(), []string{"a", "b", "c", "d", "e", "f"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.order, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, tt.order)
+			}
+		})
+	}
+}
+
+func TestEdgeTypeString(t *testing.T) {
+	for kind, expected := range map[EdgeType]string{
+		TreeEdge: "tree", BackEdge: "back", ForwardEdge: "forward", CrossEdge: "cross",
+	} {
+		if got := fmt.Sprint(kind); got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package hashtable
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultShardCount is the number of shards used by NewConcurrentHashTable
+const DefaultShardCount = 32
+
+// shard is a single HashTable guarded by its own lock
+type shard struct {
+	mu    sync.RWMutex
+	table *HashTable
+}
+
+// ConcurrentHashTable is a hash table that is safe for concurrent use.
+// Keys are spread over a fixed number of shards, each backed by a HashTable
+// with its own RWMutex, so operations on different shards do not contend.
+type ConcurrentHashTable struct {
+	shards []*shard
+	size   atomic.Int64
+}
+
+// NewConcurrentHashTable creates a new concurrent hash table with the default shard count
+func NewConcurrentHashTable() *ConcurrentHashTable {
+	return NewConcurrentHashTableWithShards(DefaultShardCount)
+}
+
+// NewConcurrentHashTableWithShards creates a new concurrent hash table with the specified number of shards
+func NewConcurrentHashTableWithShards(shardCount int) *ConcurrentHashTable {
+	if shardCount < 1 {
+		shardCount = DefaultShardCount
+	}
+
+	shards := make([]*shard, shardCount)
+	for i := range shards {
+		shards[i] = &shard{table: NewHashTable()}
+	}
+
+	return &ConcurrentHashTable{shards: shards}
+}
+
+// getShard returns the shard responsible for a key
+func (c *ConcurrentHashTable) getShard(key string) *shard {
+	h := fnv.New32()
+	h.Write([]byte(key))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+// Put inserts or updates a key-value pair
+func (c *ConcurrentHashTable) Put(key string, value interface{}) {
+	s := c.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := s.table.Size()
+	s.table.Put(key, value)
+	c.size.Add(int64(s.table.Size() - before))
+}
+
+// Get retrieves a value by key
+func (c *ConcurrentHashTable) Get(key string) (interface{}, bool) {
+	s := c.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.table.Get(key)
+}
+
+// Contains checks if a key exists in the hash table
+func (c *ConcurrentHashTable) Contains(key string) bool {
+	_, found := c.Get(key)
+	return found
+}
+
+// Delete removes a key-value pair and reports whether it was present
+func (c *ConcurrentHashTable) Delete(key string) bool {
+	s := c.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.table.Delete(key) {
+		return false
+	}
+	c.size.Add(-1)
+	return true
+}
+
+// GetOrPut atomically returns the existing value for a key, or stores and
+// returns the given value if the key is absent. loaded is true if the value
+// was already present.
+func (c *ConcurrentHashTable) GetOrPut(key string, value interface{}) (actual interface{}, loaded bool) {
+	s := c.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, found := s.table.Get(key); found {
+		return existing, true
+	}
+
+	s.table.Put(key, value)
+	c.size.Add(1)
+	return value, false
+}
+
+// Compute atomically updates the value for a key. fn receives the current
+// value (nil and false if absent) and returns the new value and whether the
+// key should be kept; returning keep=false deletes the key. fn runs while the
+// shard lock is held, so it must not call back into the table.
+func (c *ConcurrentHashTable) Compute(key string, fn func(value interface{}, found bool) (newValue interface{}, keep bool)) (interface{}, bool) {
+	s := c.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldValue, found := s.table.Get(key)
+	newValue, keep := fn(oldValue, found)
+
+	if !keep {
+		if found {
+			s.table.Delete(key)
+			c.size.Add(-1)
+		}
+		return nil, false
+	}
+
+	s.table.Put(key, newValue)
+	if !found {
+		c.size.Add(1)
+	}
+	return newValue, true
+}
+
+// Range calls fn for every key-value pair until fn returns false.
+// Each shard is copied under its read lock before fn is called, so fn sees a
+// consistent snapshot of every shard and may safely modify the table.
+func (c *ConcurrentHashTable) Range(fn func(key string, value interface{}) bool) {
+	for _, s := range c.shards {
+		s.mu.RLock()
+		pairs := make([]KeyValuePair, 0, s.table.Size())
+		for _, bucket := range s.table.buckets {
+			for current := bucket; current != nil; current = current.Next {
+				pairs = append(pairs, KeyValuePair{Key: current.Key, Value: current.Value})
+			}
+		}
+		s.mu.RUnlock()
+
+		for _, pair := range pairs {
+			if !fn(pair.Key, pair.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Size returns the number of key-value pairs in the hash table
+func (c *ConcurrentHashTable) Size() int {
+	return int(c.size.Load())
+}
+
+// ShardSizes returns the number of key-value pairs held by each shard (for debugging)
+func (c *ConcurrentHashTable) ShardSizes() []int {
+	sizes := make([]int, len(c.shards))
+	for i, s := range c.shards {
+		s.mu.RLock()
+		sizes[i] = s.table.Size()
+		s.mu.RUnlock()
+	}
+	return sizes
+}
+
+// Keys returns a list of all keys in the hash table
+func (c *ConcurrentHashTable) Keys() []string {
+	keys := make([]string, 0, c.Size())
+	c.Range(func(key string, _ interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Clear removes all key-value pairs from the hash table
+func (c *ConcurrentHashTable) Clear() {
+	for _, s := range c.shards {
+		s.mu.Lock()
+		c.size.Add(-int64(s.table.Size()))
+		s.table.Clear()
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,266 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package hashtable
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentHashTableBasicOperations(t *testing.T) {
+	c := NewConcurrentHashTableWithShards(4)
+
+	c.Put("key1", "value1")
+	c.Put("key2", 42)
+	c.Put("key1", "updated")
+
+	if c.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", c.Size())
+	}
+
+	val, found := c.Get("key1")
+	if !found || val != "updated" {
+		t.Errorf("Expected updated, got %v (found: %v)", val, found)
+	}
+
+	if !c.Delete("key2") {
+		t.Error("Delete should return true for existing key")
+	}
+	if c.Delete("key2") {
+		t.Error("Delete should return false for missing key")
+	}
+	if c.Size() != 1 {
+		t.Errorf("Expected size 1 after deletion, got %d", c.Size())
+	}
+
+	c.Clear()
+	if c.Size() != 0 || c.Contains("key1") {
+		t.Error("Expected empty table after Clear")
+	}
+}
+
+func TestConcurrentHashTableGetOrPut(t *testing.T) {
+	c := NewConcurrentHashTable()
+
+	actual, loaded := c.GetOrPut("key", 1)
+	if loaded || actual != 1 {
+		t.Errorf("Expected (1, false), got (%v, %v)", actual, loaded)
+	}
+
+	actual, loaded = c.GetOrPut("key", 2)
+	if !loaded || actual != 1 {
+		t.Errorf("Expected (1, true), got (%v, %v)", actual, loaded)
+	}
+
+	if c.Size() != 1 {
+		t.Errorf("Expected size 1, got %d", c.Size())
+	}
+}
+
+func TestConcurrentHashTableCompute(t *testing.T) {
+	c := NewConcurrentHashTable()
+
+	increment := func(value interface{}, found bool) (interface{}, bool) {
+		if !found {
+			return 1, true
+		}
+		return value.(int) + 1, true
+	}
+
+	c.Compute("counter", increment)
+	c.Compute("counter", increment)
+	val, _ := c.Get("counter")
+	if val != 2 {
+		t.Errorf("Expected 2, got %v", val)
+	}
+
+	// Returning keep=false removes the key
+	c.Compute("counter", func(interface{}, bool) (interface{}, bool) { return nil, false })
+	if c.Contains("counter") || c.Size() != 0 {
+		t.Error("Expected counter to be removed by Compute")
+	}
+
+	// Removing a missing key leaves the size untouched
+	c.Compute("missing", func(interface{}, bool) (interface{}, bool) { return nil, false })
+	if c.Size() != 0 {
+		t.Errorf("Expected size 0, got %d", c.Size())
+	}
+}
+
+func TestConcurrentHashTableRange(t *testing.T) {
+	c := NewConcurrentHashTableWithShards(8)
+	for i := 0; i < 50; i++ {
+		c.Put(fmt.Sprintf("key%d", i), i)
+	}
+
+	sum := 0
+	c.Range(func(key string, value interface{}) bool {
+		sum += value.(int)
+		// Modifying the table from inside Range must not deadlock
+		c.Delete(key)
+		return true
+	})
+	if sum != 49*50/2 {
+		t.Errorf("Expected sum %d, got %d", 49*50/2, sum)
+	}
+
+	if c.Size() != 0 {
+		t.Errorf("Expected all keys deleted during Range, size is %d", c.Size())
+	}
+
+	for i := 0; i < 10; i++ {
+		c.Put(fmt.Sprintf("key%d", i), i)
+	}
+
+	visited := 0
+	c.Range(func(string, interface{}) bool {
+		visited++
+		return visited < 3
+	})
+	if visited != 3 {
+		t.Errorf("Expected Range to stop after 3 pairs, visited %d", visited)
+	}
+
+	keys := c.Keys()
+	sort.Strings(keys)
+	if len(keys) != 10 || keys[0] != "key0" || keys[9] != "key9" {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+}
+
+func TestConcurrentHashTableStress(t *testing.T) {
+	c := NewConcurrentHashTableWithShards(8)
+	const workers = 16
+	const perWorker = 500
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				key := fmt.Sprintf("w%d-%d", w, i)
+				c.Put(key, i)
+				c.Get(key)
+				c.Compute("shared", func(value interface{}, found bool) (interface{}, bool) {
+					if !found {
+						return 1, true
+					}
+					return value.(int) + 1, true
+				})
+				if i%2 == 0 {
+					c.Delete(key)
+				}
+			}
+			c.Range(func(string, interface{}) bool { return true })
+		}(w)
+	}
+	wg.Wait()
+
+	shared, _ := c.Get("shared")
+	if shared != workers*perWorker {
+		t.Errorf("Expected shared counter %d, got %v", workers*perWorker, shared)
+	}
+
+	expected := workers*perWorker/2 + 1
+	if c.Size() != expected {
+		t.Errorf("Expected size %d, got %d", expected, c.Size())
+	}
+
+	total := 0
+	for _, n := range c.ShardSizes() {
+		total += n
+	}
+	if total != c.Size() {
+		t.Errorf("Shard sizes sum to %d, size counter is %d", total, c.Size())
+	}
+}
+
+func benchmarkKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key%d", i)
+	}
+	return keys
+}
+
+func BenchmarkConcurrentHashTableReadHeavy(b *testing.B) {
+	c := NewConcurrentHashTable()
+	keys := benchmarkKeys(1024)
+	for i, key := range keys {
+		c.Put(key, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := keys[i%len(keys)]
+			if i%10 == 0 {
+				c.Put(key, i)
+			} else {
+				c.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapReadHeavy(b *testing.B) {
+	var m sync.Map
+	keys := benchmarkKeys(1024)
+	for i, key := range keys {
+		m.Store(key, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := keys[i%len(keys)]
+			if i%10 == 0 {
+				m.Store(key, i)
+			} else {
+				m.Load(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentHashTableWriteHeavy(b *testing.B) {
+	c := NewConcurrentHashTable()
+	keys := benchmarkKeys(1024)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			c.Put(keys[i%len(keys)], i)
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapWriteHeavy(b *testing.B) {
+	var m sync.Map
+	keys := benchmarkKeys(1024)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Store(keys[i%len(keys)], i)
+			i++
+		}
+	})
+}
@@ -0,0 +1,544 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package hashtable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// File names used inside a store directory
+const (
+	SnapshotFileName = "snapshot.db"
+	LogFileName      = "oplog.db"
+)
+
+// snapshotMagic identifies a snapshot file and its format version
+var snapshotMagic = []byte("HTS1")
+
+// Operation codes written to snapshots and the operation log
+const (
+	opPut    byte = 1
+	opDelete byte = 2
+)
+
+// Value encodings used inside a record
+const (
+	valueNil byte = 0
+	valueGob byte = 1
+)
+
+// maxRecordSize caps a record's body. A header claiming more is corrupt.
+const maxRecordSize = 64 << 20
+
+// ErrCorruptSnapshot is returned when a snapshot file cannot be decoded
+var ErrCorruptSnapshot = errors.New("hashtable: corrupt snapshot")
+
+// ErrCorruptLog is returned when the operation log holds a bad record that
+// is not a torn write at its end
+var ErrCorruptLog = errors.New("hashtable: corrupt operation log")
+
+// errCorruptRecord is returned by readRecord for a complete but invalid record
+var errCorruptRecord = errors.New("hashtable: corrupt record")
+
+// ErrCompactFailed is returned by Put and Delete when the write was logged and
+// applied but the automatic compaction after it failed. The store stays usable
+// and compaction is retried on the next write.
+var ErrCompactFailed = errors.New("hashtable: automatic compaction failed")
+
+// ErrStoreClosed is returned when a closed store is used
+var ErrStoreClosed = errors.New("hashtable: store is closed")
+
+// record is a single Put or Delete operation
+type record struct {
+	op    byte
+	key   string
+	value interface{}
+}
+
+// encodeRecord serializes a record as
+// [length uint32][crc32 uint32][op][key length uvarint][key][value kind][gob value]
+func encodeRecord(rec record) ([]byte, error) {
+	var body bytes.Buffer
+	body.WriteByte(rec.op)
+
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(len(rec.key)))
+	body.Write(lenBuf[:n])
+	body.WriteString(rec.key)
+
+	if rec.op == opPut {
+		if rec.value == nil {
+			body.WriteByte(valueNil)
+		} else {
+			body.WriteByte(valueGob)
+			// Each value gets its own encoder so records are self-describing
+			if err := gob.NewEncoder(&body).Encode(&rec.value); err != nil {
+				return nil, fmt.Errorf("hashtable: encoding value for key %q: %w", rec.key, err)
+			}
+		}
+	}
+
+	if body.Len() > maxRecordSize {
+		return nil, fmt.Errorf("hashtable: record for key %q is larger than %d bytes", rec.key, maxRecordSize)
+	}
+
+	frame := make([]byte, 8, 8+body.Len())
+	binary.LittleEndian.PutUint32(frame[0:4], uint32(body.Len()))
+	binary.LittleEndian.PutUint32(frame[4:8], crc32.ChecksumIEEE(body.Bytes()))
+	return append(frame, body.Bytes()...), nil
+}
+
+// readRecord reads one record written by encodeRecord. It returns io.EOF at a
+// clean end of input, io.ErrUnexpectedEOF when the input ends partway through
+// a record and errCorruptRecord for a record that fails its checks.
+func readRecord(r io.Reader) (record, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return record{}, err
+	}
+
+	length := binary.LittleEndian.Uint32(header[0:4])
+	checksum := binary.LittleEndian.Uint32(header[4:8])
+	if length > maxRecordSize {
+		return record{}, errCorruptRecord
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return record{}, io.ErrUnexpectedEOF
+	}
+	if crc32.ChecksumIEEE(body) != checksum {
+		return record{}, errCorruptRecord
+	}
+
+	buf := bytes.NewReader(body)
+	rec := record{}
+	op, err := buf.ReadByte()
+	if err != nil || (op != opPut && op != opDelete) {
+		return record{}, errCorruptRecord
+	}
+	rec.op = op
+
+	keyLen, err := binary.ReadUvarint(buf)
+	if err != nil || keyLen > uint64(buf.Len()) {
+		return record{}, errCorruptRecord
+	}
+	key := make([]byte, keyLen)
+	io.ReadFull(buf, key)
+	rec.key = string(key)
+
+	if rec.op == opPut {
+		kind, err := buf.ReadByte()
+		if err != nil {
+			return record{}, errCorruptRecord
+		}
+		if kind == valueGob {
+			if err := gob.NewDecoder(buf).Decode(&rec.value); err != nil {
+				return record{}, fmt.Errorf("hashtable: decoding value for key %q: %w", rec.key, err)
+			}
+		}
+	}
+
+	return rec, nil
+}
+
+// WriteSnapshot writes every key-value pair of the hash table to w in binary form.
+// Values are encoded with encoding/gob, so custom value types must be registered
+// with gob.Register before they can be written.
+func WriteSnapshot(w io.Writer, ht *HashTable) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(snapshotMagic); err != nil {
+		return err
+	}
+
+	for _, bucket := range ht.buckets {
+		for current := bucket; current != nil; current = current.Next {
+			data, err := encodeRecord(record{op: opPut, key: current.Key, value: current.Value})
+			if err != nil {
+				return err
+			}
+			if _, err := bw.Write(data); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ReadSnapshot reads a hash table previously written by WriteSnapshot
+func ReadSnapshot(r io.Reader) (*HashTable, error) {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, snapshotMagic) {
+		return nil, ErrCorruptSnapshot
+	}
+
+	ht := NewHashTable()
+	for {
+		rec, err := readRecord(br)
+		if err == io.EOF {
+			return ht, nil
+		}
+		if err == io.ErrUnexpectedEOF || err == errCorruptRecord {
+			return nil, ErrCorruptSnapshot
+		}
+		if err != nil {
+			return nil, err
+		}
+		ht.Put(rec.key, rec.value)
+	}
+}
+
+// StoreOptions configures a Store
+type StoreOptions struct {
+	// SyncWrites calls fsync on the operation log after every Put and Delete
+	SyncWrites bool
+	// CompactThreshold triggers an automatic compaction once the log holds this
+	// many records. Zero disables automatic compaction.
+	CompactThreshold int
+}
+
+// Store is an embedded key-value store backed by a HashTable. Every Put and
+// Delete is appended to an operation log before it is applied in memory;
+// Compact folds the log into a binary snapshot. Opening a store loads the
+// snapshot and replays the log, discarding a record left half-written at the
+// end of the log by a crash.
+// A Store is safe for concurrent use.
+type Store struct {
+	mu         sync.RWMutex
+	dir        string
+	options    StoreOptions
+	table      *HashTable
+	log        *os.File
+	logRecords int
+	closed     bool
+}
+
+// OpenStore opens or creates a store in the given directory with default options
+func OpenStore(dir string) (*Store, error) {
+	return OpenStoreWithOptions(dir, StoreOptions{})
+}
+
+// OpenStoreWithOptions opens or creates a store in the given directory
+func OpenStoreWithOptions(dir string, options StoreOptions) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	table, err := loadSnapshot(filepath.Join(dir, SnapshotFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	logPath := filepath.Join(dir, LogFileName)
+	records, validSize, err := replayLog(logPath, table)
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := os.OpenFile(logPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	// Drop any torn record at the tail so new records follow valid data
+	if err := log.Truncate(validSize); err != nil {
+		log.Close()
+		return nil, err
+	}
+	if _, err := log.Seek(validSize, io.SeekStart); err != nil {
+		log.Close()
+		return nil, err
+	}
+
+	return &Store{
+		dir:        dir,
+		options:    options,
+		table:      table,
+		log:        log,
+		logRecords: records,
+	}, nil
+}
+
+// loadSnapshot reads the snapshot file, returning an empty table if it doesn't exist
+func loadSnapshot(path string) (*HashTable, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewHashTable(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadSnapshot(file)
+}
+
+// replayLog applies every valid record in the log to the table. It returns the
+// number of records applied and the byte offset where valid data ends. Only a
+// record cut short by the end of the file is treated as a torn write; any
+// other bad record fails with ErrCorruptLog rather than dropping what follows.
+func replayLog(path string, table *HashTable) (int, int64, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	counter := &countingReader{r: bufio.NewReader(file)}
+	records := 0
+	var validSize int64
+
+	for {
+		rec, err := readRecord(counter)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return records, validSize, nil
+		}
+		if err == errCorruptRecord {
+			return 0, 0, fmt.Errorf("%w: bad record at offset %d", ErrCorruptLog, validSize)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+
+		switch rec.op {
+		case opPut:
+			table.Put(rec.key, rec.value)
+		case opDelete:
+			table.Delete(rec.key)
+		}
+		records++
+		validSize = counter.n
+	}
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// appendLog writes a record to the operation log. Callers must hold s.mu.
+// A failed write is cut back off the log, so a later record doesn't follow a
+// partial one and the failed operation isn't replayed on the next open.
+func (s *Store) appendLog(rec record) error {
+	data, err := encodeRecord(rec)
+	if err != nil {
+		return err
+	}
+	offset, err := s.log.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.log.Write(data)
+	if err == nil && s.options.SyncWrites {
+		err = s.log.Sync()
+	}
+	if err != nil {
+		if truncErr := s.log.Truncate(offset); truncErr != nil {
+			return fmt.Errorf("%w (rolling back the log: %v)", err, truncErr)
+		}
+		if _, seekErr := s.log.Seek(offset, io.SeekStart); seekErr != nil {
+			return fmt.Errorf("%w (rolling back the log: %v)", err, seekErr)
+		}
+		return err
+	}
+	s.logRecords++
+	return nil
+}
+
+// maybeCompact compacts the store once the log reaches the threshold, wrapping
+// a failure in ErrCompactFailed. Callers must hold s.mu.
+func (s *Store) maybeCompact() error {
+	if s.options.CompactThreshold > 0 && s.logRecords >= s.options.CompactThreshold {
+		if err := s.compact(); err != nil {
+			return fmt.Errorf("%w: %v", ErrCompactFailed, err)
+		}
+	}
+	return nil
+}
+
+// Put inserts or updates a key-value pair and records it in the log.
+// An error wrapping ErrCompactFailed means the pair was still stored.
+func (s *Store) Put(key string, value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if err := s.appendLog(record{op: opPut, key: key, value: value}); err != nil {
+		return err
+	}
+	s.table.Put(key, value)
+	return s.maybeCompact()
+}
+
+// Delete removes a key and records the deletion in the log.
+// It reports whether the key was present. An error wrapping ErrCompactFailed
+// means the key was still removed.
+func (s *Store) Delete(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false, ErrStoreClosed
+	}
+	if !s.table.Contains(key) {
+		return false, nil
+	}
+	if err := s.appendLog(record{op: opDelete, key: key}); err != nil {
+		return false, err
+	}
+	s.table.Delete(key)
+	return true, s.maybeCompact()
+}
+
+// Get retrieves a value by key
+func (s *Store) Get(key string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.table.Get(key)
+}
+
+// Contains checks if a key exists in the store
+func (s *Store) Contains(key string) bool {
+	_, found := s.Get(key)
+	return found
+}
+
+// Size returns the number of key-value pairs in the store
+func (s *Store) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.table.Size()
+}
+
+// Keys returns a list of all keys in the store
+func (s *Store) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.table.Keys()
+}
+
+// LogRecords returns the number of records in the operation log since the last compaction
+func (s *Store) LogRecords() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.logRecords
+}
+
+// Compact writes a fresh snapshot of the current contents and empties the log
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	return s.compact()
+}
+
+// compact does the work of Compact. Callers must hold s.mu.
+// The snapshot is written to a temporary file and renamed into place, so a
+// crash at any point leaves either the old or the new snapshot. Replaying the
+// old log over the new snapshot is harmless because records are idempotent.
+func (s *Store) compact() error {
+	snapshotPath := filepath.Join(s.dir, SnapshotFileName)
+	tmp, err := os.CreateTemp(s.dir, SnapshotFileName+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if err := WriteSnapshot(tmp, s.table); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, snapshotPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	// The rename must be durable before the log it replaces is emptied
+	if err := syncDir(s.dir); err != nil {
+		return err
+	}
+
+	if err := s.log.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := s.log.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.logRecords = 0
+	return s.log.Sync()
+}
+
+// syncDir flushes a directory's entries, making renames inside it durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// Close flushes the operation log and releases the store's files
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	if err := s.log.Sync(); err != nil {
+		s.log.Close()
+		return err
+	}
+	return s.log.Close()
+}
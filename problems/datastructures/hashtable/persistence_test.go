@@ -0,0 +1,285 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package hashtable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	ht := NewHashTable()
+	ht.Put("name", "John Doe")
+	ht.Put("age", 30)
+	ht.Put("active", true)
+	ht.Put("score", 9.5)
+	ht.Put("nothing", nil)
+
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, ht); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+
+	loaded, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("ReadSnapshot failed: %v", err)
+	}
+
+	if loaded.Size() != ht.Size() {
+		t.Errorf("Expected size %d, got %d", ht.Size(), loaded.Size())
+	}
+	for _, key := range ht.Keys() {
+		want, _ := ht.Get(key)
+		got, found := loaded.Get(key)
+		if !found || got != want {
+			t.Errorf("Key %s: expected %v, got %v (found: %v)", key, want, got, found)
+		}
+	}
+}
+
+func TestReadSnapshotRejectsGarbage(t *testing.T) {
+	if _, err := ReadSnapshot(bytes.NewReader([]byte("not a snapshot"))); err != ErrCorruptSnapshot {
+		t.Errorf("Expected ErrCorruptSnapshot, got %v", err)
+	}
+}
+
+func TestReadSnapshotRejectsOversizedRecord(t *testing.T) {
+	// A header claiming a 4GB record must not be trusted with an allocation
+	data := append([]byte(nil), snapshotMagic...)
+	header := make([]byte, 8)
+	binary.LittleEndian.PutUint32(header[0:4], 0xFFFFFFFF)
+	data = append(data, header...)
+
+	if _, err := ReadSnapshot(bytes.NewReader(data)); err != ErrCorruptSnapshot {
+		t.Errorf("Expected ErrCorruptSnapshot, got %v", err)
+	}
+}
+
+func TestStoreReopenReplaysLog(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := OpenStore(dir)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	store.Put("key1", "value1")
+	store.Put("key2", 2)
+	store.Put("key1", "updated")
+	if deleted, _ := store.Delete("key2"); !deleted {
+		t.Error("Delete should return true for existing key")
+	}
+	if deleted, _ := store.Delete("missing"); deleted {
+		t.Error("Delete should return false for missing key")
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if err := store.Put("key3", 3); err != ErrStoreClosed {
+		t.Errorf("Expected ErrStoreClosed, got %v", err)
+	}
+
+	reopened, err := OpenStore(dir)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer reopened.Close()
+
+	if reopened.Size() != 1 {
+		t.Errorf("Expected size 1, got %d", reopened.Size())
+	}
+	val, found := reopened.Get("key1")
+	if !found || val != "updated" {
+		t.Errorf("Expected updated, got %v (found: %v)", val, found)
+	}
+	if reopened.LogRecords() != 4 {
+		t.Errorf("Expected 4 log records, got %d", reopened.LogRecords())
+	}
+}
+
+func TestStoreCompact(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := OpenStore(dir)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		store.Put(fmt.Sprintf("key%d", i), i)
+	}
+	store.Delete("key0")
+
+	if err := store.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if store.LogRecords() != 0 {
+		t.Errorf("Expected empty log after compaction, got %d records", store.LogRecords())
+	}
+
+	info, err := os.Stat(filepath.Join(dir, LogFileName))
+	if err != nil || info.Size() != 0 {
+		t.Errorf("Expected empty log file after compaction, got %v (err: %v)", info.Size(), err)
+	}
+
+	// Writes after compaction land in the fresh log
+	store.Put("key10", 10)
+	store.Close()
+
+	reopened, err := OpenStore(dir)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer reopened.Close()
+
+	if reopened.Size() != 10 {
+		t.Errorf("Expected size 10, got %d", reopened.Size())
+	}
+	if reopened.Contains("key0") {
+		t.Error("key0 should stay deleted after compaction")
+	}
+	if val, _ := reopened.Get("key10"); val != 10 {
+		t.Errorf("Expected 10, got %v", val)
+	}
+}
+
+func TestStoreAutoCompact(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := OpenStoreWithOptions(dir, StoreOptions{CompactThreshold: 5})
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	defer store.Close()
+
+	for i := 0; i < 7; i++ {
+		store.Put(fmt.Sprintf("key%d", i), i)
+	}
+
+	if store.LogRecords() != 2 {
+		t.Errorf("Expected 2 log records after auto-compaction, got %d", store.LogRecords())
+	}
+	if _, err := os.Stat(filepath.Join(dir, SnapshotFileName)); err != nil {
+		t.Errorf("Expected snapshot file to exist: %v", err)
+	}
+}
+
+func TestStoreAutoCompactFailureKeepsWrite(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := OpenStoreWithOptions(dir, StoreOptions{CompactThreshold: 2})
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.Put("a", 1); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// The open log keeps working, but no snapshot can be created any more
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Put("b", 2)
+	if !errors.Is(err, ErrCompactFailed) {
+		t.Fatalf("Expected ErrCompactFailed, got %v", err)
+	}
+	if v, ok := store.Get("b"); !ok || v != 2 {
+		t.Errorf("Expected b=2 to be stored despite the failed compaction, got %v, %v", v, ok)
+	}
+	if store.LogRecords() != 2 {
+		t.Errorf("Expected both records to stay in the log, got %d", store.LogRecords())
+	}
+
+	present, err := store.Delete("a")
+	if !present || !errors.Is(err, ErrCompactFailed) {
+		t.Errorf("Expected delete to apply with ErrCompactFailed, got %v, %v", present, err)
+	}
+	if store.Contains("a") {
+		t.Error("Expected a to be deleted")
+	}
+}
+
+func TestStoreRecoversFromTornWrite(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := OpenStore(dir)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	store.Put("key1", "value1")
+	store.Put("key2", "value2")
+	store.Close()
+
+	// Simulate a crash halfway through writing a record
+	logPath := filepath.Join(dir, LogFileName)
+	info, _ := os.Stat(logPath)
+	if err := os.Truncate(logPath, info.Size()-3); err != nil {
+		t.Fatalf("Truncate failed: %v", err)
+	}
+
+	reopened, err := OpenStore(dir)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if reopened.Size() != 1 || !reopened.Contains("key1") {
+		t.Errorf("Expected only key1 to survive, got keys %v", reopened.Keys())
+	}
+
+	// New writes must follow the last valid record
+	reopened.Put("key3", "value3")
+	reopened.Close()
+
+	again, err := OpenStore(dir)
+	if err != nil {
+		t.Fatalf("Second reopen failed: %v", err)
+	}
+	defer again.Close()
+
+	if again.Size() != 2 || !again.Contains("key3") {
+		t.Errorf("Expected key1 and key3, got keys %v", again.Keys())
+	}
+}
+
+func TestStoreRejectsCorruptLog(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := OpenStore(dir)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	store.Put("key1", "value1")
+	store.Put("key2", "value2")
+	store.Put("key3", "value3")
+	store.Close()
+
+	// Flip a byte inside the first record's body
+	logPath := filepath.Join(dir, LogFileName)
+	data, _ := os.ReadFile(logPath)
+	data[10] ^= 0xFF
+	if err := os.WriteFile(logPath, data, 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if _, err := OpenStore(dir); !errors.Is(err, ErrCorruptLog) {
+		t.Fatalf("Expected ErrCorruptLog, got %v", err)
+	}
+
+	// The records after the bad one must not have been truncated away
+	if info, _ := os.Stat(logPath); info.Size() != int64(len(data)) {
+		t.Errorf("Expected the log to keep its %d bytes, got %d", len(data), info.Size())
+	}
+}
@@ -11,28 +11,108 @@ package bankingsystem
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+var (
+	// ErrInsufficientFunds is returned when a withdrawal would overdraw an account
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrWithdrawalLimit is returned when a savings account has used up its monthly withdrawals
+	ErrWithdrawalLimit = errors.New("maximum withdrawals for this month reached")
 )
 
 // Account interface defines methods that all account types must implement
 type Account interface {
-	Deposit(amount float64) error
-	Withdraw(amount float64) error
-	GetBalance() float64
+	Deposit(amount money.Money) error
+	Withdraw(amount money.Money) error
+	GetBalance() money.Money
 	GetAccountNumber() string
 	GetAccountType() string
 }
 
-// BaseAccount contains common fields and methods for all account types
+// BaseAccount contains common fields and methods for all account types.
+// Its mutex guards the balance, the state and the account's own counters.
 type BaseAccount struct {
 	AccountNumber string
-	Balance       float64
+	Balance       money.Money
 	OpenDate      time.Time
 	OwnerID       string
+	State         AccountState
+	LastActivity  time.Time            // Time of the last customer-initiated transaction
+	JobPeriods    map[string]time.Time // Last period each scheduled job has run for on the account
+	ledger        *Ledger
+	rules         *RuleEngine
+	mu            sync.Mutex
+}
+
+// bankAccount is implemented by every account type the bank opens. Splitting
+// a withdrawal into a check and an apply step lets Transfer validate both
+// legs before moving any money.
+type bankAccount interface {
+	Account
+	base() *BaseAccount
+	// checkWithdrawal validates a withdrawal and returns the fee it incurs
+	// and the balance it would leave
+	checkWithdrawal(amount money.Money) (fee, balanceAfter money.Money, err error)
+	// applyWithdrawal commits a withdrawal validated by checkWithdrawal
+	applyWithdrawal(balanceAfter money.Money)
+	// checkDeposit validates money paid into the account, such as a transfer
+	checkDeposit(amount money.Money) error
+	// checkClose validates closing the account
+	checkClose() error
+}
+
+// base returns the shared account state
+func (a *BaseAccount) base() *BaseAccount {
+	return a
+}
+
+// record writes a transaction to the bank's ledger, if the account belongs to a bank
+func (a *BaseAccount) record(tx Transaction) {
+	if a.ledger != nil {
+		tx.AccountNumber = a.AccountNumber
+		tx.BalanceAfter = a.Balance
+		// Show a missing fee as zero in the account's currency
+		if zero, err := money.Zero(a.Balance.Currency()); err == nil && tx.Fee.Currency() == "" {
+			tx.Fee = zero
+		}
+		recorded := a.ledger.record(tx)
+
+		switch tx.Type {
+		case TransactionInterest, TransactionInterestCharge, TransactionFeeCharge:
+			// The bank's own postings don't count as customer activity
+		default:
+			a.LastActivity = recorded.Timestamp
+		}
+	}
+}
+
+// checkDeposit accepts any deposit; account types that restrict deposits override it
+func (a *BaseAccount) checkDeposit(amount money.Money) error {
+	return nil
+}
+
+// charge takes interest or a fee from the account, even if that makes the
+// balance negative. The caller must hold the account's lock.
+func (a *BaseAccount) charge(txType TransactionType, amount money.Money, description string) error {
+	balance, err := a.Balance.Sub(amount)
+	if err != nil {
+		return err
+	}
+
+	a.Balance = balance
+	a.record(Transaction{Type: txType, Amount: amount, Description: description})
+	return nil
 }
 
 // GetBalance returns the current balance
-func (a *BaseAccount) GetBalance() float64 {
+func (a *BaseAccount) GetBalance() money.Money {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	return a.Balance
 }
 
@@ -42,19 +122,74 @@ func (a *BaseAccount) GetAccountNumber() string {
 }
 
 // Deposit adds funds to the account
-func (a *BaseAccount) Deposit(amount float64) error {
-	if amount <= 0 {
-		return errors.New("deposit amount must be positive")
+func (a *BaseAccount) Deposit(amount money.Money) error {
+	if !amount.IsPositive() {
+		return fmt.Errorf("%w: deposit amount must be positive", ErrInvalidArgument)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkIncoming(); err != nil {
+		return err
+	}
+	balance, err := a.Balance.Add(amount)
+	if err != nil {
+		return err
+	}
+	if err := a.screen(MovementDeposit, amount, ""); err != nil {
+		return err
+	}
+
+	a.Balance = balance
+	a.record(Transaction{Type: TransactionDeposit, Amount: amount, Description: "Deposit"})
+	a.received()
+	return nil
+}
+
+// withdraw validates and applies a withdrawal on any account type
+func withdraw(account bankAccount, amount money.Money) error {
+	a := account.base()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkOutgoing(); err != nil {
+		return err
+	}
+	fee, balanceAfter, err := account.checkWithdrawal(amount)
+	if err != nil {
+		return err
+	}
+	if err := a.screen(MovementWithdrawal, amount, ""); err != nil {
+		return err
 	}
 
-	a.Balance += amount
+	account.applyWithdrawal(balanceAfter)
+	a.record(Transaction{Type: TransactionWithdrawal, Amount: amount, Fee: fee, Description: "Withdrawal"})
 	return nil
 }
 
+// subtractWithdrawal subtracts a withdrawal and its fee from a balance, failing
+// with the insufficient error if the result would be negative
+func subtractWithdrawal(balance, amount, fee money.Money, insufficient error) (money.Money, error) {
+	total, err := amount.Add(fee)
+	if err != nil {
+		return money.Money{}, err
+	}
+	remaining, err := balance.Sub(total)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if remaining.IsNegative() {
+		return money.Money{}, insufficient
+	}
+	return remaining, nil
+}
+
 // CheckingAccount represents a checking account
 type CheckingAccount struct {
 	BaseAccount
-	TransactionFee float64
+	TransactionFee money.Money
 }
 
 // GetAccountType returns the account type
@@ -63,27 +198,35 @@ func (c *CheckingAccount) GetAccountType() string {
 }
 
 // Withdraw removes funds from the checking account and applies transaction fee
-func (c *CheckingAccount) Withdraw(amount float64) error {
-	if amount <= 0 {
-		return errors.New("withdrawal amount must be positive")
-	}
+func (c *CheckingAccount) Withdraw(amount money.Money) error {
+	return withdraw(c, amount)
+}
 
-	totalWithdrawal := amount + c.TransactionFee
+func (c *CheckingAccount) checkWithdrawal(amount money.Money) (money.Money, money.Money, error) {
+	if !amount.IsPositive() {
+		return money.Money{}, money.Money{}, fmt.Errorf("%w: withdrawal amount must be positive", ErrInvalidArgument)
+	}
 
-	if totalWithdrawal > c.Balance {
-		return errors.New("insufficient funds for withdrawal and fee")
+	remaining, err := subtractWithdrawal(c.Balance, amount, c.TransactionFee, fmt.Errorf("%w for withdrawal and fee", ErrInsufficientFunds))
+	if err != nil {
+		return money.Money{}, money.Money{}, err
 	}
 
-	c.Balance -= totalWithdrawal
-	return nil
+	return c.TransactionFee, remaining, nil
+}
+
+func (c *CheckingAccount) applyWithdrawal(balanceAfter money.Money) {
+	c.Balance = balanceAfter
 }
 
 // SavingsAccount represents a savings account
 type SavingsAccount struct {
 	BaseAccount
-	InterestRate         float64
+	InterestRate         money.Rate
 	WithdrawalsThisMonth int
 	MaxWithdrawals       int
+	AccruedBalance       money.Money // Sum of the end-of-day balances since interest was last paid
+	AccrualDays          int
 }
 
 // GetAccountType returns the account type
@@ -92,28 +235,92 @@ func (s *SavingsAccount) GetAccountType() string {
 }
 
 // Withdraw removes funds from the savings account with withdrawal limits
-func (s *SavingsAccount) Withdraw(amount float64) error {
-	if amount <= 0 {
-		return errors.New("withdrawal amount must be positive")
+func (s *SavingsAccount) Withdraw(amount money.Money) error {
+	return withdraw(s, amount)
+}
+
+func (s *SavingsAccount) checkWithdrawal(amount money.Money) (money.Money, money.Money, error) {
+	if !amount.IsPositive() {
+		return money.Money{}, money.Money{}, fmt.Errorf("%w: withdrawal amount must be positive", ErrInvalidArgument)
 	}
 
-	if amount > s.Balance {
-		return errors.New("insufficient funds")
+	remaining, err := subtractWithdrawal(s.Balance, amount, money.Money{}, ErrInsufficientFunds)
+	if err != nil {
+		return money.Money{}, money.Money{}, err
 	}
 
 	if s.WithdrawalsThisMonth >= s.MaxWithdrawals {
-		return errors.New("maximum withdrawals for this month reached")
+		return money.Money{}, money.Money{}, ErrWithdrawalLimit
 	}
 
-	s.Balance -= amount
+	return money.Money{}, remaining, nil
+}
+
+func (s *SavingsAccount) applyWithdrawal(balanceAfter money.Money) {
+	s.Balance = balanceAfter
 	s.WithdrawalsThisMonth++
+}
+
+// ApplyMonthlyInterest applies interest to the savings account. If daily
+// balances have been accrued, interest is paid on their average; otherwise on
+// the current balance. Interest is rounded to the nearest cent with ties to
+// even, so rounding doesn't drift over many months.
+func (s *SavingsAccount) ApplyMonthlyInterest() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	base := s.Balance
+	if s.AccrualDays > 0 {
+		var err error
+		if base, err = s.AccruedBalance.Div(int64(s.AccrualDays)); err != nil {
+			return err
+		}
+	}
+	interest, err := base.MulRate(s.InterestRate)
+	if err != nil {
+		return err
+	}
+	return s.creditInterest(interest, "Monthly interest")
+}
+
+// closingInterest is the interest owed for the days accrued since the last
+// payment when the account closes part way through a month: the monthly rate
+// on the month's average balance, counting the days not accrued as zero.
+// Callers must hold s.mu.
+func (s *SavingsAccount) closingInterest(now time.Time) (money.Money, error) {
+	if s.AccrualDays == 0 {
+		return money.Zero(s.Balance.Currency())
+	}
+
+	days := time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, now.Location()).Day()
+	days = max(days, s.AccrualDays)
+	base, err := s.AccruedBalance.Div(int64(days))
+	if err != nil {
+		return money.Money{}, err
+	}
+	return base.MulRate(s.InterestRate)
+}
+
+// creditInterest adds interest to the balance and starts a new accrual
+// period. Callers must hold s.mu.
+func (s *SavingsAccount) creditInterest(interest money.Money, description string) error {
+	balance, err := s.Balance.Add(interest)
+	if err != nil {
+		return err
+	}
+
+	s.Balance = balance
+	s.AccruedBalance = money.Money{}
+	s.AccrualDays = 0
+	if interest.IsPositive() {
+		s.record(Transaction{Type: TransactionInterest, Amount: interest, Description: description})
+	}
 	return nil
 }
 
-// ApplyMonthlyInterest applies interest to the savings account
-func (s *SavingsAccount) ApplyMonthlyInterest() {
-	interest := s.Balance * s.InterestRate
-	s.Balance += interest
-	// Reset monthly withdrawal counter
+// ResetMonthlyWithdrawals starts a new month of withdrawals
+func (s *SavingsAccount) ResetMonthlyWithdrawals() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.WithdrawalsThisMonth = 0
 }
@@ -12,139 +12,385 @@ package bankingsystem
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+var (
+	// ErrCustomerNotFound is returned when no customer has the requested ID
+	ErrCustomerNotFound = errors.New("customer not found")
+	// ErrAccountNotFound is returned when no account has the requested number
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrInvalidArgument is wrapped by errors for requests the bank rejects as
+	// malformed, such as a non-positive amount
+	ErrInvalidArgument = errors.New("invalid argument")
 )
 
-// Bank represents the banking institution
+// Bank represents the banking institution. It is safe for concurrent use:
+// its mutex guards the customer and account maps, ID generation and the
+// scheduler's progress, and each account has its own lock. The rate and fee
+// fields are configuration and should be set before the bank is shared
+// between goroutines. Fees and limits are set in the bank's currency and
+// converted for accounts held in other currencies.
 type Bank struct {
 	Name                  string
+	Currency              string
 	Customers             map[string]*Customer
 	Accounts              map[string]Account
 	NextCustomerID        int
 	NextAccountNumber     int
-	CheckingFeeRate       float64
-	SavingsInterestRate   float64
+	CheckingFeeRate       money.Money
+	SavingsInterestRate   money.Rate
 	MaxSavingsWithdrawals int
+	DormancyPeriod        time.Duration // Inactivity after which accounts become dormant
+	OverdraftFee          money.Money
+	OverdraftInterestRate money.Rate
+	CreditLineRate        money.Rate
+	MinimumPaymentRate    money.Rate
+	MinimumPaymentFloor   money.Money
+	LateFee               money.Money
+	TermDepositRate       money.Rate
+	EarlyWithdrawalRate   money.Rate // Penalty on term deposit withdrawals before maturity
+	AllowEarlyWithdrawal  bool
+	MaintenanceFee        money.Money // Monthly fee on checking accounts
+	MaintenanceFeeWaiver  money.Money // Balance at which the maintenance fee is waived
+	ScheduledThrough      time.Time   // End of the last day the scheduler has processed
+	Ledger                *Ledger
+	Rules                 *RuleEngine  // Screens customer money movements
+	Rates                 RateProvider // Exchange rates; without them every account uses Currency
+	FXSpread              money.Rate   // Fee on the amount of a cross-currency transfer
+	mu                    sync.RWMutex
+	saveMu                sync.Mutex // Writes saved snapshots in the order they were taken
 }
 
 // NewBank creates a new bank instance
 func NewBank(name string) *Bank {
-	return &Bank{
+	bank := &Bank{
 		Name:                  name,
+		Currency:              "USD",
 		Customers:             make(map[string]*Customer),
 		Accounts:              make(map[string]Account),
 		NextCustomerID:        1000,
 		NextAccountNumber:     10000,
-		CheckingFeeRate:       5.0,   // $5 transaction fee
-		SavingsInterestRate:   0.005, // 0.5% monthly interest
-		MaxSavingsWithdrawals: 6,     // 6 withdrawals per month
+		CheckingFeeRate:       money.MustParse("5", "USD"),    // $5 transaction fee
+		SavingsInterestRate:   money.MustParseRate("0.005"),   // 0.5% monthly interest
+		MaxSavingsWithdrawals: 6,                              // 6 withdrawals per month
+		DormancyPeriod:        365 * 24 * time.Hour,           // A year without activity
+		OverdraftFee:          money.MustParse("25", "USD"),   // $25 per overdrawn withdrawal
+		OverdraftInterestRate: money.MustParseRate("0.015"),   // 1.5% monthly on overdrawn balances
+		CreditLineRate:        money.MustParseRate("0.015"),   // 1.5% monthly on credit owed
+		MinimumPaymentRate:    money.MustParseRate("0.03"),    // 3% of the credit owed each cycle
+		MinimumPaymentFloor:   money.MustParse("25", "USD"),   // but at least $25
+		LateFee:               money.MustParse("35", "USD"),   // $35 for a missed minimum payment
+		TermDepositRate:       money.MustParseRate("0.004"),   // 0.4% monthly until maturity
+		EarlyWithdrawalRate:   money.MustParseRate("0.02"),    // 2% penalty before maturity
+		AllowEarlyWithdrawal:  true,                           // Early term deposit withdrawals pay the penalty
+		MaintenanceFee:        money.MustParse("10", "USD"),   // $10 a month
+		MaintenanceFeeWaiver:  money.MustParse("1500", "USD"), // unless $1,500 or more is kept
+		Ledger:                NewLedger(),
+		Rules:                 &RuleEngine{},
+		FXSpread:              money.MustParseRate("0.01"), // 1% on currency conversions
 	}
+	bank.Rules.convert = bank.feeIn
+	return bank
 }
 
 // CreateCustomer creates a new customer at the bank
 func (b *Bank) CreateCustomer(firstName, lastName, address string) (*Customer, error) {
-	id := fmt.Sprintf("C%d", b.NextCustomerID)
-	b.NextCustomerID++
-
-	customer, err := NewCustomer(id, firstName, lastName, address)
+	// Validate before taking an ID, so rejected customers don't use one up
+	customer, err := NewCustomer("", firstName, lastName, address)
 	if err != nil {
 		return nil, err
 	}
 
-	b.Customers[id] = customer
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	customer.ID = fmt.Sprintf("C%d", b.NextCustomerID)
+	b.NextCustomerID++
+	b.Customers[customer.ID] = customer
 	return customer, nil
 }
 
 // OpenCheckingAccount opens a new checking account for a customer
-func (b *Bank) OpenCheckingAccount(customerID string, initialDeposit float64) (*CheckingAccount, error) {
-	if initialDeposit < 0 {
-		return nil, errors.New("initial deposit cannot be negative")
+func (b *Bank) OpenCheckingAccount(customerID string, initialDeposit money.Money) (*CheckingAccount, error) {
+	currency := b.accountCurrency(initialDeposit)
+	balance, err := b.openingBalance(initialDeposit, currency)
+	if err != nil {
+		return nil, err
 	}
-
-	customer, exists := b.Customers[customerID]
-	if !exists {
-		return nil, errors.New("customer not found")
+	fee, err := b.feeIn(b.CheckingFeeRate, currency)
+	if err != nil {
+		return nil, err
 	}
 
-	accountNumber := fmt.Sprintf("AC%d", b.NextAccountNumber)
-	b.NextAccountNumber++
-
 	account := &CheckingAccount{
 		BaseAccount: BaseAccount{
-			AccountNumber: accountNumber,
-			Balance:       initialDeposit,
-			OpenDate:      time.Now(),
-			OwnerID:       customerID,
+			Balance:  balance,
+			OpenDate: b.Ledger.now(),
+			OwnerID:  customerID,
+			ledger:   b.Ledger,
 		},
-		TransactionFee: b.CheckingFeeRate,
+		TransactionFee: fee,
 	}
 
-	b.Accounts[accountNumber] = account
-	customer.AddAccount(accountNumber)
-
+	if err := b.addAccount(account, "AC", initialDeposit); err != nil {
+		return nil, err
+	}
 	return account, nil
 }
 
 // OpenSavingsAccount opens a new savings account for a customer
-func (b *Bank) OpenSavingsAccount(customerID string, initialDeposit float64) (*SavingsAccount, error) {
-	if initialDeposit < 0 {
-		return nil, errors.New("initial deposit cannot be negative")
-	}
-
-	customer, exists := b.Customers[customerID]
-	if !exists {
-		return nil, errors.New("customer not found")
+func (b *Bank) OpenSavingsAccount(customerID string, initialDeposit money.Money) (*SavingsAccount, error) {
+	balance, err := b.openingBalance(initialDeposit, b.accountCurrency(initialDeposit))
+	if err != nil {
+		return nil, err
 	}
 
-	accountNumber := fmt.Sprintf("AS%d", b.NextAccountNumber)
-	b.NextAccountNumber++
-
 	account := &SavingsAccount{
 		BaseAccount: BaseAccount{
-			AccountNumber: accountNumber,
-			Balance:       initialDeposit,
-			OpenDate:      time.Now(),
-			OwnerID:       customerID,
+			Balance:  balance,
+			OpenDate: b.Ledger.now(),
+			OwnerID:  customerID,
+			ledger:   b.Ledger,
 		},
 		InterestRate:         b.SavingsInterestRate,
 		WithdrawalsThisMonth: 0,
 		MaxWithdrawals:       b.MaxSavingsWithdrawals,
 	}
 
-	b.Accounts[accountNumber] = account
-	customer.AddAccount(accountNumber)
+	if err := b.addAccount(account, "AS", initialDeposit); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
 
+// OpenOverdraftAccount opens a checking account that can be overdrawn down to overdraftLimit
+func (b *Bank) OpenOverdraftAccount(customerID string, initialDeposit, overdraftLimit money.Money) (*OverdraftAccount, error) {
+	currency := b.accountCurrency(initialDeposit, overdraftLimit)
+	balance, err := b.openingBalance(initialDeposit, currency)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := b.inCurrency(overdraftLimit, currency)
+	if err != nil {
+		return nil, err
+	}
+	if limit.IsNegative() {
+		return nil, fmt.Errorf("%w: overdraft limit cannot be negative", ErrInvalidArgument)
+	}
+	transactionFee, err := b.feeIn(b.CheckingFeeRate, currency)
+	if err != nil {
+		return nil, err
+	}
+	overdraftFee, err := b.feeIn(b.OverdraftFee, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &OverdraftAccount{
+		CheckingAccount: CheckingAccount{
+			BaseAccount: BaseAccount{
+				Balance:  balance,
+				OpenDate: b.Ledger.now(),
+				OwnerID:  customerID,
+				ledger:   b.Ledger,
+			},
+			TransactionFee: transactionFee,
+		},
+		OverdraftLimit:        limit,
+		OverdraftFee:          overdraftFee,
+		OverdraftInterestRate: b.OverdraftInterestRate,
+	}
+
+	if err := b.addAccount(account, "AO", initialDeposit); err != nil {
+		return nil, err
+	}
 	return account, nil
 }
 
+// OpenCreditLine opens a credit line with nothing drawn yet
+func (b *Bank) OpenCreditLine(customerID string, creditLimit money.Money) (*CreditLineAccount, error) {
+	currency := b.accountCurrency(creditLimit)
+	limit, err := b.inCurrency(creditLimit, currency)
+	if err != nil {
+		return nil, err
+	}
+	if !limit.IsPositive() {
+		return nil, fmt.Errorf("%w: credit limit must be positive", ErrInvalidArgument)
+	}
+	balance, err := b.inCurrency(money.Money{}, currency)
+	if err != nil {
+		return nil, err
+	}
+	paymentFloor, err := b.feeIn(b.MinimumPaymentFloor, currency)
+	if err != nil {
+		return nil, err
+	}
+	lateFee, err := b.feeIn(b.LateFee, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	openDate := b.Ledger.now()
+	account := &CreditLineAccount{
+		BaseAccount: BaseAccount{
+			Balance:  balance,
+			OpenDate: openDate,
+			OwnerID:  customerID,
+			ledger:   b.Ledger,
+		},
+		CreditLimit:         limit,
+		InterestRate:        b.CreditLineRate,
+		MinimumPaymentRate:  b.MinimumPaymentRate,
+		MinimumPaymentFloor: paymentFloor,
+		LateFee:             lateFee,
+		MinimumPaymentDue:   balance,
+		cycleStart:          openDate,
+	}
+
+	if err := b.addAccount(account, "AL", money.Money{}); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// OpenTermDeposit opens a term deposit holding amount for termMonths months
+func (b *Bank) OpenTermDeposit(customerID string, amount money.Money, termMonths int) (*TermDepositAccount, error) {
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: term deposit amount must be positive", ErrInvalidArgument)
+	}
+	if termMonths < 1 {
+		return nil, fmt.Errorf("%w: term must be at least one month", ErrInvalidArgument)
+	}
+	balance, err := b.openingBalance(amount, b.accountCurrency(amount))
+	if err != nil {
+		return nil, err
+	}
+
+	openDate := b.Ledger.now()
+	account := &TermDepositAccount{
+		BaseAccount: BaseAccount{
+			Balance:  balance,
+			OpenDate: openDate,
+			OwnerID:  customerID,
+			ledger:   b.Ledger,
+		},
+		InterestRate:           b.TermDepositRate,
+		TermMonths:             termMonths,
+		MaturityDate:           openDate.AddDate(0, termMonths, 0),
+		AllowEarlyWithdrawal:   b.AllowEarlyWithdrawal,
+		EarlyWithdrawalPenalty: b.EarlyWithdrawalRate,
+	}
+
+	if err := b.addAccount(account, "AT", amount); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// addAccount assigns the next account number to a new account, registers it
+// with its owner and records the opening deposit. The account is locked until
+// the deposit is recorded, so nobody can use it before its history starts.
+func (b *Bank) addAccount(account bankAccount, prefix string, initialDeposit money.Money) error {
+	base := account.base()
+	base.mu.Lock()
+	defer base.mu.Unlock()
+
+	b.mu.Lock()
+	customer, exists := b.Customers[base.OwnerID]
+	if !exists {
+		b.mu.Unlock()
+		return ErrCustomerNotFound
+	}
+
+	base.State = StateActive
+	base.rules = b.Rules
+	base.LastActivity = base.OpenDate
+	base.AccountNumber = fmt.Sprintf("%s%d", prefix, b.NextAccountNumber)
+	b.NextAccountNumber++
+	b.Accounts[base.AccountNumber] = account
+	customer.AddAccount(base.AccountNumber)
+	b.mu.Unlock()
+
+	if initialDeposit.IsPositive() {
+		base.record(Transaction{Type: TransactionDeposit, Amount: initialDeposit, Description: "Opening deposit"})
+	}
+	return nil
+}
+
+// openingBalance validates an initial deposit and returns it in the account's currency
+func (b *Bank) openingBalance(initialDeposit money.Money, currency string) (money.Money, error) {
+	if initialDeposit.IsNegative() {
+		return money.Money{}, fmt.Errorf("%w: initial deposit cannot be negative", ErrInvalidArgument)
+	}
+
+	return b.inCurrency(initialDeposit, currency)
+}
+
+// accountCurrency picks the currency of a new account from the amounts it is
+// opened with, or the bank's currency if none of them has one. Amounts in
+// different currencies are rejected later by inCurrency.
+func (b *Bank) accountCurrency(amounts ...money.Money) string {
+	for _, amount := range amounts {
+		if amount.Currency() != "" {
+			return amount.Currency()
+		}
+	}
+	return b.Currency
+}
+
+// inCurrency checks that an amount is in a currency, giving a currency-less
+// zero that currency
+func (b *Bank) inCurrency(amount money.Money, currency string) (money.Money, error) {
+	zero, err := money.Zero(currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return zero.Add(amount)
+}
+
 // GetAccount retrieves an account by account number
 func (b *Bank) GetAccount(accountNumber string) (Account, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
 	account, exists := b.Accounts[accountNumber]
 	if !exists {
-		return nil, errors.New("account not found")
+		return nil, ErrAccountNotFound
 	}
 	return account, nil
 }
 
 // GetCustomer retrieves a customer by ID
 func (b *Bank) GetCustomer(customerID string) (*Customer, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
 	customer, exists := b.Customers[customerID]
 	if !exists {
-		return nil, errors.New("customer not found")
+		return nil, ErrCustomerNotFound
 	}
 	return customer, nil
 }
 
 // GetCustomerAccounts gets all accounts for a customer
 func (b *Bank) GetCustomerAccounts(customerID string) ([]Account, error) {
-	customer, err := b.GetCustomer(customerID)
-	if err != nil {
-		return nil, err
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	customer, exists := b.Customers[customerID]
+	if !exists {
+		return nil, ErrCustomerNotFound
 	}
 
-	accounts := make([]Account, 0, len(customer.AccountNumbers))
+	accountNumbers := customer.GetAccountNumbers()
+	accounts := make([]Account, 0, len(accountNumbers))
 
-	for _, accNum := range customer.AccountNumbers {
+	for _, accNum := range accountNumbers {
 		if acc, exists := b.Accounts[accNum]; exists {
 			accounts = append(accounts, acc)
 		}
@@ -153,11 +399,277 @@ func (b *Bank) GetCustomerAccounts(customerID string) ([]Account, error) {
 	return accounts, nil
 }
 
-// ApplyMonthlyInterest applies interest to all savings accounts
-func (b *Bank) ApplyMonthlyInterest() {
-	for _, acc := range b.Accounts {
-		if savingsAcc, ok := acc.(*SavingsAccount); ok {
-			savingsAcc.ApplyMonthlyInterest()
+// monthlyAccount is an account with month-end processing, such as paying
+// or charging interest
+type monthlyAccount interface {
+	ApplyMonthlyInterest() error
+}
+
+// ApplyMonthlyInterest runs month-end processing on every open account that
+// has it, returning the first error after trying every account
+func (b *Bank) ApplyMonthlyInterest() error {
+	return b.applyMonthlyInterest(time.Time{})
+}
+
+// applyMonthlyInterest runs month-end processing for a month, skipping
+// accounts it has already run on for that month
+func (b *Bank) applyMonthlyInterest(month time.Time) error {
+	return b.runJob(JobMonthlyInterest, month, func(acc Account) error {
+		if internal, ok := acc.(bankAccount); ok && internal.base().GetState() == StateClosed {
+			return nil
+		}
+		if monthly, ok := acc.(monthlyAccount); ok {
+			return monthly.ApplyMonthlyInterest()
 		}
+		return nil
+	})
+}
+
+// Transfer moves money between two accounts of the bank. The source account's
+// withdrawal rules apply, including the checking transaction fee and the
+// savings withdrawal limit. Both legs are validated before either is applied,
+// so the transfer happens completely or not at all. Accounts are locked in
+// account number order, so concurrent transfers in opposite directions can't
+// deadlock.
+//
+// The amount is in the source account's currency. If the destination holds
+// another currency, the amount is converted at the bank's rates and the
+// source also pays Bank.FXSpread on it; both legs record the conversion.
+func (b *Bank) Transfer(fromAccountNumber, toAccountNumber string, amount money.Money) error {
+	if fromAccountNumber == toAccountNumber {
+		return fmt.Errorf("%w: cannot transfer to the same account", ErrInvalidArgument)
+	}
+	if !amount.IsPositive() {
+		return fmt.Errorf("%w: transfer amount must be positive", ErrInvalidArgument)
+	}
+
+	from, err := b.bankAccount(fromAccountNumber)
+	if err != nil {
+		return fmt.Errorf("source %w", err)
+	}
+	to, err := b.bankAccount(toAccountNumber)
+	if err != nil {
+		return fmt.Errorf("destination %w", err)
+	}
+
+	unlock := lockAccounts(from.base(), to.base())
+	defer unlock()
+
+	if err := from.base().checkOutgoing(); err != nil {
+		return fmt.Errorf("source %w", err)
+	}
+	if err := to.base().checkIncoming(); err != nil {
+		return fmt.Errorf("destination %w", err)
+	}
+	conversion, spread, err := b.exchange(amount, to.base().Balance.Currency())
+	if err != nil {
+		return err
+	}
+	debit, err := amount.Add(spread)
+	if err != nil {
+		return err
+	}
+	fee, fromBalance, err := from.checkWithdrawal(debit)
+	if err != nil {
+		return err
+	}
+	if fee, err = fee.Add(spread); err != nil {
+		return err
+	}
+	received := amount
+	if conversion != nil {
+		received = conversion.Converted
+	}
+	if err := to.checkDeposit(received); err != nil {
+		return err
+	}
+	toBalance, err := to.base().Balance.Add(received)
+	if err != nil {
+		return err
+	}
+	if err := from.base().screen(MovementTransfer, amount, toAccountNumber); err != nil {
+		return err
+	}
+
+	from.applyWithdrawal(fromBalance)
+	to.base().Balance = toBalance
+
+	from.base().record(Transaction{
+		Type:         TransactionTransferOut,
+		Amount:       amount,
+		Fee:          fee,
+		Counterparty: toAccountNumber,
+		Description:  "Transfer to " + toAccountNumber,
+		FX:           conversion,
+	})
+	to.base().record(Transaction{
+		Type:         TransactionTransferIn,
+		Amount:       received,
+		Counterparty: fromAccountNumber,
+		Description:  "Transfer from " + fromAccountNumber,
+		FX:           conversion,
+	})
+	to.base().received()
+
+	return nil
+}
+
+// accountList returns a snapshot of every account, sorted by account number.
+// Callers work on the snapshot without holding the bank's lock, so the
+// accounts' own locks are never taken while it is held.
+func (b *Bank) accountList() []Account {
+	b.mu.RLock()
+	accounts := make([]Account, 0, len(b.Accounts))
+	for _, account := range b.Accounts {
+		accounts = append(accounts, account)
+	}
+	b.mu.RUnlock()
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].GetAccountNumber() < accounts[j].GetAccountNumber()
+	})
+	return accounts
+}
+
+// bankAccount looks up an account opened by this bank
+func (b *Bank) bankAccount(accountNumber string) (bankAccount, error) {
+	account, err := b.GetAccount(accountNumber)
+	if err != nil {
+		return nil, err
+	}
+	internal, ok := account.(bankAccount)
+	if !ok {
+		return nil, fmt.Errorf("%w: account %s does not support transfers", ErrInvalidArgument, accountNumber)
+	}
+	return internal, nil
+}
+
+// SetClock replaces the clock used to date new accounts and transactions
+func (b *Bank) SetClock(clock Clock) {
+	b.Ledger.SetClock(clock)
+}
+
+// Statement lists the transactions of an account within a date range
+type Statement struct {
+	AccountNumber  string        `json:"accountNumber"`
+	From           time.Time     `json:"from"`
+	To             time.Time     `json:"to"`
+	OpeningBalance money.Money   `json:"openingBalance"`
+	ClosingBalance money.Money   `json:"closingBalance"`
+	TotalCredits   money.Money   `json:"totalCredits"`
+	TotalDebits    money.Money   `json:"totalDebits"`
+	Transactions   []Transaction `json:"transactions"`
+}
+
+// GetStatement builds a statement for the transactions of an account with
+// timestamps in [from, to)
+func (b *Bank) GetStatement(accountNumber string, from, to time.Time) (*Statement, error) {
+	account, err := b.GetAccount(accountNumber)
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: statement end date is before start date", ErrInvalidArgument)
+	}
+
+	// Amounts start at zero in the account's currency, so a statement with
+	// nothing before or in its range still reports one
+	zero, err := money.Zero(account.GetBalance().Currency())
+	if err != nil {
+		return nil, err
+	}
+	statement := &Statement{
+		AccountNumber:  accountNumber,
+		From:           from,
+		To:             to,
+		OpeningBalance: zero,
+		TotalCredits:   zero,
+		TotalDebits:    zero,
+		Transactions:   []Transaction{},
+	}
+
+	for _, tx := range b.Ledger.Transactions(accountNumber) {
+		if tx.Timestamp.Before(from) {
+			statement.OpeningBalance = tx.BalanceAfter
+			continue
+		}
+		if !tx.Timestamp.Before(to) {
+			break
+		}
+
+		statement.Transactions = append(statement.Transactions, tx)
+
+		switch tx.Type {
+		case TransactionDeposit, TransactionInterest, TransactionTransferIn:
+			statement.TotalCredits, err = statement.TotalCredits.Add(tx.Amount)
+		case TransactionWithdrawal, TransactionTransferOut, TransactionInterestCharge, TransactionFeeCharge:
+			statement.TotalDebits, err = statement.TotalDebits.Add(tx.Amount)
+		}
+		if err == nil {
+			statement.TotalDebits, err = statement.TotalDebits.Add(tx.Fee)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	statement.ClosingBalance = statement.OpeningBalance
+	if n := len(statement.Transactions); n > 0 {
+		statement.ClosingBalance = statement.Transactions[n-1].BalanceAfter
+	}
+
+	return statement, nil
+}
+
+// Discrepancy is an account whose balance doesn't match the ledger
+type Discrepancy struct {
+	AccountNumber string
+	Balance       money.Money
+	LedgerBalance money.Money
+}
+
+// Reconcile compares every account balance with the balance rebuilt from
+// the ledger and returns the accounts that differ, sorted by account number.
+// Each account is locked while it is compared, so money moving concurrently
+// is never reported as a discrepancy.
+func (b *Bank) Reconcile() ([]Discrepancy, error) {
+	discrepancies := make([]Discrepancy, 0)
+
+	for _, account := range b.accountList() {
+		discrepancy, err := b.reconcileAccount(account)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", account.GetAccountNumber(), err)
+		}
+		if discrepancy != nil {
+			discrepancies = append(discrepancies, *discrepancy)
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// reconcileAccount compares one account with the ledger, returning nil if they agree
+func (b *Bank) reconcileAccount(account Account) (*Discrepancy, error) {
+	var balance money.Money
+	if internal, ok := account.(bankAccount); ok {
+		a := internal.base()
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		balance = a.Balance
+	} else {
+		balance = account.GetBalance()
+	}
+
+	ledgerBalance, err := b.Ledger.Balance(account.GetAccountNumber())
+	if err != nil {
+		return nil, err
+	}
+	if balance.Equal(ledgerBalance) {
+		return nil, nil
 	}
+	return &Discrepancy{
+		AccountNumber: account.GetAccountNumber(),
+		Balance:       balance,
+		LedgerBalance: ledgerBalance,
+	}, nil
 }
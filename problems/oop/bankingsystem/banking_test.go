@@ -11,8 +11,15 @@ package bankingsystem
 
 import (
 	"testing"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
 )
 
+// usd parses a dollar amount for tests
+func usd(amount string) money.Money {
+	return money.MustParse(amount, "USD")
+}
+
 func TestCustomerCreation(t *testing.T) {
 	customer, err := NewCustomer("C1001", "John", "Doe", "123 Go Street")
 	if err != nil {
@@ -40,69 +47,72 @@ func TestBankAndAccountOperations(t *testing.T) {
 	}
 
 	// Test checking account
-	checking, err := bank.OpenCheckingAccount(customer.ID, 1000.0)
+	checking, err := bank.OpenCheckingAccount(customer.ID, usd("1000"))
 	if err != nil {
 		t.Errorf("Expected no error opening checking account, got: %v", err)
 	}
 
-	if checking.GetBalance() != 1000.0 {
-		t.Errorf("Expected balance of 1000.0, got: %.2f", checking.GetBalance())
+	if !checking.GetBalance().Equal(usd("1000")) {
+		t.Errorf("Expected balance of 1000.0, got: %v", checking.GetBalance())
 	}
 
 	// Test deposit
-	err = checking.Deposit(500.0)
+	err = checking.Deposit(usd("500"))
 	if err != nil {
 		t.Errorf("Expected no error on deposit, got: %v", err)
 	}
 
-	if checking.GetBalance() != 1500.0 {
-		t.Errorf("Expected balance of 1500.0 after deposit, got: %.2f", checking.GetBalance())
+	if !checking.GetBalance().Equal(usd("1500")) {
+		t.Errorf("Expected balance of 1500.0 after deposit, got: %v", checking.GetBalance())
 	}
 
 	// Test withdrawal with fee
-	err = checking.Withdraw(200.0)
+	err = checking.Withdraw(usd("200"))
 	if err != nil {
 		t.Errorf("Expected no error on withdrawal, got: %v", err)
 	}
 
-	expectedBalance := 1500.0 - 200.0 - checking.TransactionFee
-	if checking.GetBalance() != expectedBalance {
-		t.Errorf("Expected balance of %.2f after withdrawal and fee, got: %.2f",
+	expectedBalance, _ := usd("1300").Sub(checking.TransactionFee)
+	if !checking.GetBalance().Equal(expectedBalance) {
+		t.Errorf("Expected balance of %v after withdrawal and fee, got: %v",
 			expectedBalance, checking.GetBalance())
 	}
 
 	// Test insufficient funds
-	err = checking.Withdraw(2000.0)
+	err = checking.Withdraw(usd("2000"))
 	if err == nil {
 		t.Errorf("Expected error on withdrawal with insufficient funds, got none")
 	}
 
 	// Test savings account
-	savings, err := bank.OpenSavingsAccount(customer.ID, 2000.0)
+	savings, err := bank.OpenSavingsAccount(customer.ID, usd("2000"))
 	if err != nil {
 		t.Errorf("Expected no error opening savings account, got: %v", err)
 	}
 
 	// Test interest
 	initialBalance := savings.GetBalance()
-	savings.ApplyMonthlyInterest()
-	expectedWithInterest := initialBalance * (1.0 + savings.InterestRate)
+	if err := savings.ApplyMonthlyInterest(); err != nil {
+		t.Errorf("Expected no error applying interest, got: %v", err)
+	}
+	interest, _ := initialBalance.MulRate(savings.InterestRate)
+	expectedWithInterest, _ := initialBalance.Add(interest)
 
-	if savings.GetBalance() != expectedWithInterest {
-		t.Errorf("Expected balance of %.2f after interest, got: %.2f",
+	if !savings.GetBalance().Equal(expectedWithInterest) {
+		t.Errorf("Expected balance of %v after interest, got: %v",
 			expectedWithInterest, savings.GetBalance())
 	}
 
 	// Test withdrawal limits
 	for i := 0; i < savings.MaxWithdrawals; i++ {
-		err = savings.Withdraw(10.0)
+		err = savings.Withdraw(usd("10"))
 		if err != nil {
 			t.Errorf("Expected no error on withdrawal #%d, got: %v", i+1, err)
 		}
 	}
 
 	// This should exceed the limit
-	err = savings.Withdraw(10.0)
+	err = savings.Withdraw(usd("10"))
 	if err == nil {
 		t.Errorf("Expected error when exceeding withdrawal limit, got none")
 	}
@@ -117,3 +127,35 @@ func TestBankAndAccountOperations(t *testing.T) {
 		t.Errorf("Expected 2 accounts for customer, got: %d", len(accounts))
 	}
 }
+
+func TestInterestCompoundsExactly(t *testing.T) {
+	bank := NewBank("Test Bank")
+	customer, _ := bank.CreateCustomer("Jane", "Smith", "456 Go Lane")
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1234.56"))
+
+	// Compute the expected balance independently in whole cents: 0.5% of the
+	// balance, rounded half to even
+	cents := int64(123456)
+	for month := 0; month < 240; month++ {
+		if err := savings.ApplyMonthlyInterest(); err != nil {
+			t.Fatalf("ApplyMonthlyInterest failed in month %d: %v", month+1, err)
+		}
+
+		interest, remainder := cents*5/1000, cents*5%1000
+		if 2*remainder > 1000 || 2*remainder == 1000 && interest%2 == 1 {
+			interest++
+		}
+		cents += interest
+
+		if got := savings.GetBalance().MinorUnits(); got != cents {
+			t.Fatalf("Month %d: expected %d cents, got %d", month+1, cents, got)
+		}
+	}
+
+	if discrepancies, err := bank.Reconcile(); err != nil || len(discrepancies) != 0 {
+		t.Errorf("Expected the ledger to reconcile after 20 years of interest, got %v (%v)", discrepancies, err)
+	}
+	if !bank.Ledger.IsBalanced() {
+		t.Error("Expected debits to equal credits")
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// Run these with the race detector: go test -race ./problems/oop/bankingsystem
+
+func TestConcurrentAccountOpening(t *testing.T) {
+	bank, _, _ := newTestBank(t)
+
+	const workers, perWorker = 8, 25
+	var wg sync.WaitGroup
+	customerIDs := make(chan string, workers*perWorker)
+	accountNumbers := make(chan string, 2*workers*perWorker)
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				customer, err := bank.CreateCustomer("Jane", "Smith", "456 Go Lane")
+				if err != nil {
+					t.Errorf("CreateCustomer failed: %v", err)
+					return
+				}
+				customerIDs <- customer.ID
+
+				checking, err := bank.OpenCheckingAccount(customer.ID, usd("100"))
+				if err != nil {
+					t.Errorf("OpenCheckingAccount failed: %v", err)
+					return
+				}
+				savings, err := bank.OpenSavingsAccount(customer.ID, usd("50"))
+				if err != nil {
+					t.Errorf("OpenSavingsAccount failed: %v", err)
+					return
+				}
+				accountNumbers <- checking.AccountNumber
+				accountNumbers <- savings.AccountNumber
+
+				if accounts, _ := bank.GetCustomerAccounts(customer.ID); len(accounts) != 2 {
+					t.Errorf("Expected 2 accounts for %s, got %d", customer.ID, len(accounts))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(customerIDs)
+	close(accountNumbers)
+
+	assertUnique := func(kind string, ids chan string, expected int) {
+		seen := make(map[string]bool)
+		for id := range ids {
+			if seen[id] {
+				t.Errorf("Duplicate %s %s", kind, id)
+			}
+			seen[id] = true
+		}
+		if len(seen) != expected {
+			t.Errorf("Expected %d %ss, got %d", expected, kind, len(seen))
+		}
+	}
+	assertUnique("customer ID", customerIDs, workers*perWorker)
+	assertUnique("account number", accountNumbers, 2*workers*perWorker)
+
+	// The test bank's own customer has no accounts
+	if len(bank.Customers) != workers*perWorker+1 || len(bank.Accounts) != 2*workers*perWorker {
+		t.Errorf("Unexpected bank size: %d customers, %d accounts", len(bank.Customers), len(bank.Accounts))
+	}
+	if discrepancies, err := bank.Reconcile(); err != nil || len(discrepancies) != 0 {
+		t.Errorf("Expected the ledger to reconcile, got %v (%v)", discrepancies, err)
+	}
+}
+
+func TestConcurrentDepositsAndWithdrawals(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+	savings.MaxWithdrawals = 1 << 30
+
+	const workers, perWorker = 16, 200
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				// Withdrawals may fail for lack of funds; conservation is checked
+				// against the ledger, which only records what succeeded
+				switch (worker + i) % 5 {
+				case 0:
+					checking.Deposit(usd("3.33"))
+				case 1:
+					checking.Withdraw(usd("7.50"))
+				case 2:
+					savings.Deposit(usd("12.01"))
+				case 3:
+					savings.Withdraw(usd("4.99"))
+				case 4:
+					bank.Transfer(savings.AccountNumber, checking.AccountNumber, usd("1.25"))
+				}
+				if i%50 == 0 {
+					bank.ApplyMonthlyInterest()
+					bank.Reconcile()
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	// Money in the accounts equals money that came in through the bank's cash
+	// account and interest, less what went out and was charged as fees
+	cash, _ := bank.Ledger.Balance(LedgerCash)
+	interest, _ := bank.Ledger.Balance(LedgerInterestExpense)
+	fees, _ := bank.Ledger.Balance(LedgerFeeIncome)
+	expected, _ := cash.Neg().Sub(interest)
+	expected, _ = expected.Sub(fees)
+
+	total, _ := checking.GetBalance().Add(savings.GetBalance())
+	if !total.Equal(expected) {
+		t.Errorf("Expected total balance %v, got %v", expected, total)
+	}
+	if clearing, _ := bank.Ledger.Balance(LedgerTransferClearing); !clearing.IsZero() {
+		t.Errorf("Expected transfer clearing to net to zero, got %v", clearing)
+	}
+	if discrepancies, err := bank.Reconcile(); err != nil || len(discrepancies) != 0 || !bank.Ledger.IsBalanced() {
+		t.Errorf("Expected the ledger to reconcile, got %v (%v)", discrepancies, err)
+	}
+	if checking.GetBalance().IsNegative() || savings.GetBalance().IsNegative() {
+		t.Error("Balances must never go negative")
+	}
+}
+
+func TestConcurrentWithdrawalsNeverOverdraw(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	bank.CheckingFeeRate = money.Money{}
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+
+	// 50 withdrawals of $10 race for $100, so exactly 10 may succeed
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := checking.Withdraw(usd("10")); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 10 || !checking.GetBalance().IsZero() {
+		t.Errorf("Expected 10 withdrawals and an empty account, got %d and %v", succeeded, checking.GetBalance())
+	}
+}
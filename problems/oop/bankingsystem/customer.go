@@ -10,7 +10,8 @@
 package bankingsystem
 
 import (
-	"errors"
+	"fmt"
+	"sync"
 	"time"
 )
 
@@ -22,16 +23,17 @@ type Customer struct {
 	Address        string
 	JoinDate       time.Time
 	AccountNumbers []string
+	mu             sync.Mutex // Guards AccountNumbers
 }
 
 // NewCustomer creates a new customer with validation
 func NewCustomer(id, firstName, lastName, address string) (*Customer, error) {
 	if firstName == "" || lastName == "" {
-		return nil, errors.New("first name and last name are required")
+		return nil, fmt.Errorf("%w: first name and last name are required", ErrInvalidArgument)
 	}
 
 	if address == "" {
-		return nil, errors.New("address is required")
+		return nil, fmt.Errorf("%w: address is required", ErrInvalidArgument)
 	}
 
 	return &Customer{
@@ -46,9 +48,18 @@ func NewCustomer(id, firstName, lastName, address string) (*Customer, error) {
 
 // AddAccount adds an account to the customer
 func (c *Customer) AddAccount(accountNumber string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.AccountNumbers = append(c.AccountNumbers, accountNumber)
 }
 
+// GetAccountNumbers returns a copy of the customer's account numbers
+func (c *Customer) GetAccountNumbers() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.AccountNumbers...)
+}
+
 // GetFullName returns the customer's full name
 func (c *Customer) GetFullName() string {
 	return c.FirstName + " " + c.LastName
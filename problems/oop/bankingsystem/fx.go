@@ -0,0 +1,166 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// ErrRateNotFound is returned when no exchange rate is known for a currency pair
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// RateProvider supplies exchange rates
+type RateProvider interface {
+	// Rate returns how many units of the to currency one unit of the from
+	// currency buys, at the mid-market rate
+	Rate(from, to string) (money.Rate, error)
+}
+
+// Conversion records the currency exchange behind a cross-currency transfer
+type Conversion struct {
+	Original  money.Money `json:"original"`  // The amount sent, in the source account's currency
+	Converted money.Money `json:"converted"` // The amount received, in the destination's currency
+	Rate      money.Rate  `json:"rate"`
+}
+
+// StaticRates is a fixed table of exchange rates. A pair missing from the
+// table is served by the inverse of the opposite pair if that is known.
+type StaticRates struct {
+	rates map[string]money.Rate
+	mu    sync.RWMutex
+}
+
+// NewStaticRates creates an empty rate table
+func NewStaticRates() *StaticRates {
+	return &StaticRates{rates: make(map[string]money.Rate)}
+}
+
+// ParseRates reads a rate table from JSON such as {"rates": {"USD/EUR": "0.92"}}
+func ParseRates(data []byte) (*StaticRates, error) {
+	var config struct {
+		Rates map[string]money.Rate `json:"rates"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid rates: %w", err)
+	}
+
+	rates := NewStaticRates()
+	for pair, rate := range config.Rates {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid currency pair %q, expected FROM/TO", pair)
+		}
+		if err := rates.Set(from, to, rate); err != nil {
+			return nil, err
+		}
+	}
+	return rates, nil
+}
+
+// LoadRates reads a rate table from a JSON file
+func LoadRates(path string) (*StaticRates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRates(data)
+}
+
+// Set adds or replaces the rate from one currency to another
+func (s *StaticRates) Set(from, to string, rate money.Rate) error {
+	fromCurrency, err := money.LookupCurrency(from)
+	if err != nil {
+		return err
+	}
+	toCurrency, err := money.LookupCurrency(to)
+	if err != nil {
+		return err
+	}
+	if rate.Float64() <= 0 {
+		return fmt.Errorf("%w: rate for %s/%s must be positive", ErrInvalidArgument, fromCurrency.Code, toCurrency.Code)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rates[fromCurrency.Code+"/"+toCurrency.Code] = rate
+	return nil
+}
+
+// Rate returns the rate from one currency to another
+func (s *StaticRates) Rate(from, to string) (money.Rate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return money.ParseRate("1")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if rate, ok := s.rates[from+"/"+to]; ok {
+		return rate, nil
+	}
+	if rate, ok := s.rates[to+"/"+from]; ok {
+		return rate.Inverse()
+	}
+	return money.Rate{}, fmt.Errorf("%w for %s/%s", ErrRateNotFound, from, to)
+}
+
+// convert changes an amount into a currency at the bank's rates
+func (b *Bank) convert(amount money.Money, currency string) (money.Money, money.Rate, error) {
+	if amount.Currency() == "" || strings.EqualFold(amount.Currency(), currency) {
+		converted, err := b.inCurrency(amount, currency)
+		return converted, money.Rate{}, err
+	}
+	if b.Rates == nil {
+		return money.Money{}, money.Rate{}, fmt.Errorf("%w for %s/%s: the bank has no rate provider", ErrRateNotFound, amount.Currency(), currency)
+	}
+
+	rate, err := b.Rates.Rate(amount.Currency(), currency)
+	if err != nil {
+		return money.Money{}, money.Rate{}, err
+	}
+	converted, err := amount.Convert(currency, rate)
+	return converted, rate, err
+}
+
+// feeIn converts one of the bank's configured fees or limits into an
+// account's currency
+func (b *Bank) feeIn(fee money.Money, currency string) (money.Money, error) {
+	converted, _, err := b.convert(fee, currency)
+	return converted, err
+}
+
+// exchange prepares the conversion of a transfer into the destination
+// account's currency and the spread the sender pays on it. It returns no
+// conversion and a zero spread when the currencies match.
+func (b *Bank) exchange(amount money.Money, currency string) (*Conversion, money.Money, error) {
+	if strings.EqualFold(amount.Currency(), currency) {
+		return nil, money.Money{}, nil
+	}
+
+	converted, rate, err := b.convert(amount, currency)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+	spread, err := amount.MulRate(b.FXSpread)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+	return &Conversion{Original: amount, Converted: converted, Rate: rate}, spread, nil
+}
@@ -0,0 +1,287 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// eur parses a euro amount for tests
+func eur(amount string) money.Money {
+	return money.MustParse(amount, "EUR")
+}
+
+// newFXBank creates a test bank that converts dollars to euros at 0.92
+func newFXBank(t *testing.T) (*Bank, *Customer) {
+	t.Helper()
+	bank, customer, _ := newTestBank(t)
+	rates := NewStaticRates()
+	if err := rates.Set("USD", "EUR", money.MustParseRate("0.92")); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	bank.Rates = rates
+	return bank, customer
+}
+
+// expectAmount fails the test if an amount isn't the expected one
+func expectAmount(t *testing.T, name string, actual, expected money.Money) {
+	t.Helper()
+	if !actual.Equal(expected) {
+		t.Errorf("Expected %s to be %v, got %v", name, expected, actual)
+	}
+}
+
+func TestStaticRates(t *testing.T) {
+	rates, err := ParseRates([]byte(`{"rates": {"USD/EUR": "0.92", "gbp/usd": "1.25"}}`))
+	if err != nil {
+		t.Fatalf("ParseRates failed: %v", err)
+	}
+
+	tests := []struct {
+		from, to string
+		expected string
+	}{
+		{"USD", "EUR", "0.92"},
+		{"EUR", "USD", "1.086956522"}, // The inverse of USD/EUR
+		{"GBP", "USD", "1.25"},
+		{"USD", "GBP", "0.8"},
+		{"EUR", "EUR", "1"},
+	}
+	for _, tt := range tests {
+		rate, err := rates.Rate(tt.from, tt.to)
+		if err != nil || rate.String() != tt.expected {
+			t.Errorf("Rate(%s, %s) = %v (%v), expected %s", tt.from, tt.to, rate, err, tt.expected)
+		}
+	}
+	if _, err := rates.Rate("EUR", "GBP"); !errors.Is(err, ErrRateNotFound) {
+		t.Errorf("Expected a missing pair to fail with ErrRateNotFound, got %v", err)
+	}
+
+	invalid := []string{
+		`{"rates": {"USDEUR": "0.92"}}`,
+		`{"rates": {"USD/XYZ": "0.92"}}`,
+		`{"rates": {"USD/EUR": "0"}}`,
+		`{"rates": {"USD/EUR": "-1"}}`,
+		`{"rates": {"USD/EUR": "0.92"}, "spread": "0.01"}`,
+	}
+	for _, config := range invalid {
+		if _, err := ParseRates([]byte(config)); err == nil {
+			t.Errorf("Expected %s to be rejected", config)
+		}
+	}
+}
+
+func TestOpenForeignCurrencyAccount(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	if _, err := bank.OpenCheckingAccount(customer.ID, eur("100")); !errors.Is(err, ErrRateNotFound) {
+		t.Errorf("Expected a euro account to need exchange rates, got %v", err)
+	}
+
+	bank, customer = newFXBank(t)
+	checking, err := bank.OpenCheckingAccount(customer.ID, eur("100"))
+	if err != nil {
+		t.Fatalf("OpenCheckingAccount failed: %v", err)
+	}
+	// The bank's $5 fee is charged as €4.60
+	expectAmount(t, "the transaction fee", checking.TransactionFee, eur("4.60"))
+	if err := checking.Withdraw(eur("10")); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	expectAmount(t, "the balance", checking.GetBalance(), eur("85.40"))
+	if err := checking.Deposit(usd("10")); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("Expected a dollar deposit to be rejected, got %v", err)
+	}
+
+	credit, err := bank.OpenCreditLine(customer.ID, eur("1000"))
+	if err != nil {
+		t.Fatalf("OpenCreditLine failed: %v", err)
+	}
+	expectAmount(t, "the late fee", credit.LateFee, eur("32.20"))
+	if _, err := bank.OpenOverdraftAccount(customer.ID, eur("100"), usd("500")); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("Expected amounts in different currencies to be rejected, got %v", err)
+	}
+
+	// Maintenance fees and their waiver are converted too: €9.20 below €1,380
+	rich, _ := bank.OpenCheckingAccount(customer.ID, eur("1400"))
+	if err := bank.ChargeMaintenanceFees(); err != nil {
+		t.Fatalf("ChargeMaintenanceFees failed: %v", err)
+	}
+	expectAmount(t, "the balance", checking.GetBalance(), eur("76.20"))
+	expectAmount(t, "the waived balance", rich.GetBalance(), eur("1400"))
+	expectReconciled(t, bank)
+}
+
+func TestCrossCurrencyTransfer(t *testing.T) {
+	bank, customer := newFXBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, eur("0"))
+
+	// $100 buys €92; the sender pays the $5 fee and a 1% spread of $1
+	if err := bank.Transfer(checking.AccountNumber, savings.AccountNumber, usd("100")); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	expectBalance(t, checking, "894")
+	expectAmount(t, "the savings balance", savings.GetBalance(), eur("92"))
+
+	// €46 buys $50 back, with a spread of €0.46
+	if err := bank.Transfer(savings.AccountNumber, checking.AccountNumber, eur("46")); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	expectBalance(t, checking, "944")
+	expectAmount(t, "the savings balance", savings.GetBalance(), eur("45.54"))
+	expectReconciled(t, bank)
+
+	if err := bank.Transfer(checking.AccountNumber, savings.AccountNumber, eur("10")); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("Expected an amount in the destination's currency to be rejected, got %v", err)
+	}
+	bank.Rates = NewStaticRates()
+	if err := bank.Transfer(checking.AccountNumber, savings.AccountNumber, usd("10")); !errors.Is(err, ErrRateNotFound) {
+		t.Errorf("Expected a transfer without a rate to fail, got %v", err)
+	}
+	expectBalance(t, checking, "944")
+
+	// Statements show both sides of each conversion
+	statement, err := bank.GetStatement(checking.AccountNumber, checking.OpenDate, bank.Ledger.now().Add(1))
+	if err != nil {
+		t.Fatalf("GetStatement failed: %v", err)
+	}
+	out := statement.Transactions[1]
+	if out.FX == nil || out.FX.Rate.String() != "0.92" {
+		t.Fatalf("Expected the outgoing transfer to record its conversion, got %+v", out)
+	}
+	expectAmount(t, "the amount sent", out.FX.Original, usd("100"))
+	expectAmount(t, "the amount received", out.FX.Converted, eur("92"))
+	expectAmount(t, "the fee", out.Fee, usd("6"))
+	in := statement.Transactions[2]
+	expectAmount(t, "the amount credited", in.Amount, usd("50"))
+	if in.FX == nil || !in.FX.Original.Equal(eur("46")) {
+		t.Errorf("Expected the incoming transfer to record its conversion, got %+v", in)
+	}
+	if statement.Transactions[0].FX != nil {
+		t.Errorf("Expected the opening deposit to have no conversion")
+	}
+}
+
+func TestFXSurvivesPersistence(t *testing.T) {
+	bank, customer := newFXBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	savings, _ := bank.OpenCheckingAccount(customer.ID, eur("50"))
+	bank.Transfer(checking.AccountNumber, savings.AccountNumber, usd("100"))
+
+	repo := NewMemoryRepository()
+	if err := bank.Save(repo); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	restored, err := LoadBank(bank.Name, repo)
+	if err != nil {
+		t.Fatalf("LoadBank failed: %v", err)
+	}
+
+	account, _ := restored.GetAccount(savings.AccountNumber)
+	expectAmount(t, "the restored balance", account.GetBalance(), eur("142"))
+	expectAmount(t, "the restored fee", account.(*CheckingAccount).TransactionFee, eur("4.60"))
+	transactions := restored.Ledger.Transactions(savings.AccountNumber)
+	if fx := transactions[len(transactions)-1].FX; fx == nil || !fx.Original.Equal(usd("100")) {
+		t.Errorf("Expected the conversion to be restored, got %+v", fx)
+	}
+	expectReconciled(t, restored)
+}
+
+func TestLimitRulesConvertToTheAccountCurrency(t *testing.T) {
+	bank, customer := newFXBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, eur("100"))
+	setRules(t, bank, `{"rules": [{"name": "large", "type": "large-transaction", "action": "deny",
+		"limit": {"amount": "50", "currency": "USD"}}]}`)
+
+	// $50 is €46 at the bank's rates
+	if err := checking.Deposit(eur("45")); err != nil {
+		t.Errorf("Expected a deposit under the converted limit to pass, got %v", err)
+	}
+	if err := checking.Deposit(eur("46")); !errors.Is(err, ErrTransactionDenied) {
+		t.Errorf("Expected the dollar limit to apply to euro deposits, got %v", err)
+	}
+
+	// Without a rate the rule can't be checked, so the movement fails
+	bank.Rates = nil
+	if err := checking.Deposit(eur("1")); !errors.Is(err, ErrRateNotFound) {
+		t.Errorf("Expected ErrRateNotFound, got %v", err)
+	}
+}
+
+func TestServerCurrencies(t *testing.T) {
+	client, bank, _ := newAPIClient(t)
+	bank.Rates, _ = ParseRates([]byte(`{"rates": {"USD/EUR": "0.92"}}`))
+	customer, _ := bank.CreateCustomer("Ada", "Lovelace", "12 Analytical St")
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+
+	var opened accountView
+	resp := client.do("POST", "/accounts",
+		`{"customerId":"`+customer.ID+`","type":"savings","currency":"EUR"}`, nil, &opened)
+	client.expectStatus(resp, http.StatusCreated)
+	expectAmount(t, "the opening balance", opened.Balance, eur("0"))
+
+	// Amounts are in the account's currency
+	resp = client.do("POST", "/accounts/"+opened.AccountNumber+"/deposits", `{"amount":"8"}`, nil, nil)
+	client.expectStatus(resp, http.StatusOK)
+	resp = client.do("POST", "/transfers",
+		`{"from":"`+checking.AccountNumber+`","to":"`+opened.AccountNumber+`","amount":"100"}`, nil, nil)
+	client.expectStatus(resp, http.StatusOK)
+
+	var statement Statement
+	resp = client.do("GET", "/accounts/"+opened.AccountNumber+"/statement", "", nil, &statement)
+	client.expectStatus(resp, http.StatusOK)
+	expectAmount(t, "the closing balance", statement.ClosingBalance, eur("100"))
+	if n := len(statement.Transactions); n != 2 || statement.Transactions[1].FX == nil {
+		t.Errorf("Expected the transfer to show its conversion, got %+v", statement.Transactions)
+	}
+
+	resp = client.do("POST", "/accounts", `{"customerId":"`+customer.ID+`","type":"checking","currency":"GBP"}`, nil, nil)
+	client.expectStatus(resp, http.StatusUnprocessableEntity)
+	resp = client.do("POST", "/accounts", `{"customerId":"`+customer.ID+`","type":"checking","currency":"XYZ"}`, nil, nil)
+	client.expectStatus(resp, http.StatusBadRequest)
+}
+
+func TestCloseAccountIntoAnotherCurrency(t *testing.T) {
+	bank, customer := newFXBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("10"))
+	euros, _ := bank.OpenCheckingAccount(customer.ID, eur("100"))
+
+	// Closing payouts are converted at the mid rate without the spread
+	if err := bank.CloseAccount(euros.AccountNumber, checking.AccountNumber); err != nil {
+		t.Fatalf("CloseAccount failed: %v", err)
+	}
+	expectAmount(t, "the closed balance", euros.GetBalance(), eur("0"))
+	expectBalance(t, checking, "118.70")
+
+	transactions := bank.Ledger.Transactions(checking.AccountNumber)
+	in := transactions[len(transactions)-1]
+	if in.FX == nil || !in.FX.Original.Equal(eur("100")) || !in.FX.Converted.Equal(usd("108.70")) {
+		t.Errorf("Expected the payout to record its conversion, got %+v", in)
+	}
+	out := bank.Ledger.Transactions(euros.AccountNumber)
+	if fx := out[len(out)-1].FX; fx == nil || fx.Rate.String() != "1.086956522" {
+		t.Errorf("Expected the closing leg to record its conversion, got %+v", fx)
+	}
+	expectReconciled(t, bank)
+
+	// Without a rate the account stays open with its balance
+	other, _ := bank.OpenCheckingAccount(customer.ID, eur("50"))
+	bank.Rates = NewStaticRates()
+	if err := bank.CloseAccount(other.AccountNumber, checking.AccountNumber); !errors.Is(err, ErrRateNotFound) {
+		t.Errorf("Expected ErrRateNotFound, got %v", err)
+	}
+	if other.GetState() != StateActive {
+		t.Errorf("Expected the account to stay open, got %s", other.GetState())
+	}
+}
@@ -0,0 +1,294 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// TransactionType identifies the kind of money movement
+type TransactionType string
+
+const (
+	TransactionDeposit     TransactionType = "deposit"
+	TransactionWithdrawal  TransactionType = "withdrawal"
+	TransactionInterest    TransactionType = "interest"
+	TransactionTransferOut TransactionType = "transfer-out"
+	TransactionTransferIn  TransactionType = "transfer-in"
+	// Interest and fees the bank charges a customer
+	TransactionInterestCharge TransactionType = "interest-charge"
+	TransactionFeeCharge      TransactionType = "fee"
+)
+
+// Internal ledger accounts on the bank's side of every posting
+const (
+	LedgerCash            = "bank:cash"
+	LedgerFeeIncome       = "bank:fee-income"
+	LedgerInterestExpense = "bank:interest-expense"
+	LedgerInterestIncome  = "bank:interest-income"
+	// Transfers pass through a clearing account that always nets to zero
+	LedgerTransferClearing = "bank:transfer-clearing"
+	// Cross-currency transfers pass through the bank's currency position
+	// instead, which takes in one currency and pays out another
+	LedgerFXPosition = "bank:fx-position"
+)
+
+// Transaction is an immutable record of a money movement on a customer account
+type Transaction struct {
+	ID            string          `json:"id"`
+	AccountNumber string          `json:"accountNumber"`
+	Type          TransactionType `json:"type"`
+	Amount        money.Money     `json:"amount"`
+	Fee           money.Money     `json:"fee"`
+	Timestamp     time.Time       `json:"timestamp"`
+	BalanceAfter  money.Money     `json:"balanceAfter"`
+	Counterparty  string          `json:"counterparty,omitempty"` // The other account of a transfer
+	Description   string          `json:"description"`
+	FX            *Conversion     `json:"fx,omitempty"` // The exchange behind a cross-currency transfer
+}
+
+// Entry is one line of a double-entry posting. Every transaction posts
+// entries whose debits and credits sum to the same total.
+type Entry struct {
+	TransactionID string
+	Account       string
+	Debit         money.Money
+	Credit        money.Money
+}
+
+// Clock tells the current time; the bank uses it to timestamp transactions
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the real wall clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// AuditEntry records a change to an account's state or to a customer
+type AuditEntry struct {
+	Timestamp time.Time    `json:"timestamp"`
+	Subject   string       `json:"subject"` // The account number or customer ID concerned
+	From      AccountState `json:"from,omitempty"`
+	To        AccountState `json:"to,omitempty"`
+	Reason    string       `json:"reason"`
+}
+
+// Ledger is an append-only double-entry journal of every transaction.
+// Customer accounts are liabilities of the bank, so deposits credit them
+// and withdrawals and fees debit them. It also keeps the audit trail.
+type Ledger struct {
+	transactions []Transaction
+	byAccount    map[string][]int // Positions in transactions of each account's transactions
+	entries      []Entry
+	audit        []AuditEntry
+	nextID       int
+	clock        Clock
+	mu           sync.Mutex
+}
+
+// NewLedger creates an empty ledger using the system clock
+func NewLedger() *Ledger {
+	return &Ledger{byAccount: make(map[string][]int), nextID: 1, clock: systemClock{}}
+}
+
+// SetClock replaces the clock used to timestamp new transactions
+func (l *Ledger) SetClock(clock Clock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.clock = clock
+}
+
+// now reads the ledger's clock
+func (l *Ledger) now() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.clock.Now()
+}
+
+// record assigns an ID and timestamp to a transaction, appends it and posts
+// its balanced entries
+func (l *Ledger) record(tx Transaction) Transaction {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tx.ID = fmt.Sprintf("TX%06d", l.nextID)
+	tx.Timestamp = l.clock.Now()
+	l.nextID++
+	l.transactions = append(l.transactions, tx)
+	l.byAccount[tx.AccountNumber] = append(l.byAccount[tx.AccountNumber], len(l.transactions)-1)
+	l.postTransaction(tx)
+	return tx
+}
+
+// postTransaction posts the balanced entries of a transaction. The caller
+// must hold the ledger's lock.
+func (l *Ledger) postTransaction(tx Transaction) {
+	switch tx.Type {
+	case TransactionDeposit:
+		l.post(tx.ID, LedgerCash, tx.AccountNumber, tx.Amount)
+	case TransactionWithdrawal:
+		l.post(tx.ID, tx.AccountNumber, LedgerCash, tx.Amount)
+	case TransactionInterest:
+		l.post(tx.ID, LedgerInterestExpense, tx.AccountNumber, tx.Amount)
+	case TransactionTransferOut:
+		l.post(tx.ID, tx.AccountNumber, transferAccount(tx), tx.Amount)
+	case TransactionTransferIn:
+		l.post(tx.ID, transferAccount(tx), tx.AccountNumber, tx.Amount)
+	case TransactionInterestCharge:
+		l.post(tx.ID, tx.AccountNumber, LedgerInterestIncome, tx.Amount)
+	case TransactionFeeCharge:
+		l.post(tx.ID, tx.AccountNumber, LedgerFeeIncome, tx.Amount)
+	}
+	if tx.Fee.IsPositive() {
+		l.post(tx.ID, tx.AccountNumber, LedgerFeeIncome, tx.Fee)
+	}
+}
+
+// transferAccount returns the internal account a transfer leg passes through
+func transferAccount(tx Transaction) string {
+	if tx.FX != nil {
+		return LedgerFXPosition
+	}
+	return LedgerTransferClearing
+}
+
+// post adds a pair of entries moving amount from the credited to the debited account
+func (l *Ledger) post(transactionID, debit, credit string, amount money.Money) {
+	l.entries = append(l.entries,
+		Entry{TransactionID: transactionID, Account: debit, Debit: amount},
+		Entry{TransactionID: transactionID, Account: credit, Credit: amount},
+	)
+}
+
+// recordAudit timestamps and appends an audit entry
+func (l *Ledger) recordAudit(entry AuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry.Timestamp = l.clock.Now()
+	l.audit = append(l.audit, entry)
+}
+
+// AuditTrail returns the audit entries about an account or customer, oldest first
+func (l *Ledger) AuditTrail(subject string) []AuditEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := make([]AuditEntry, 0)
+	for _, entry := range l.audit {
+		if entry.Subject == subject {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// Transactions returns the transactions of an account in the order they happened
+func (l *Ledger) Transactions(accountNumber string) []Transaction {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	positions := l.byAccount[accountNumber]
+	result := make([]Transaction, 0, len(positions))
+	for _, i := range positions {
+		result = append(result, l.transactions[i])
+	}
+	return result
+}
+
+// transactionsSince returns the transactions of an account recorded at or
+// after a time, in the order they happened. Transactions are stamped by the
+// ledger's clock as they are recorded, so only the newest need looking at.
+func (l *Ledger) transactionsSince(accountNumber string, since time.Time) []Transaction {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	positions := l.byAccount[accountNumber]
+	first := len(positions)
+	for first > 0 && !l.transactions[positions[first-1]].Timestamp.Before(since) {
+		first--
+	}
+
+	result := make([]Transaction, 0, len(positions)-first)
+	for _, i := range positions[first:] {
+		result = append(result, l.transactions[i])
+	}
+	return result
+}
+
+// Entries returns a copy of every entry in the journal
+func (l *Ledger) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]Entry(nil), l.entries...)
+}
+
+// Balance returns credits minus debits for a ledger account. For a customer
+// account this is the balance the bank owes the customer.
+func (l *Ledger) Balance(account string) (money.Money, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	balance := money.Money{}
+	for _, entry := range l.entries {
+		if entry.Account != account {
+			continue
+		}
+
+		var err error
+		if balance, err = balance.Add(entry.Credit); err != nil {
+			return money.Money{}, err
+		}
+		if balance, err = balance.Sub(entry.Debit); err != nil {
+			return money.Money{}, err
+		}
+	}
+	return balance, nil
+}
+
+// balanceAt returns an account's balance just before t, taken from the last
+// transaction recorded before then
+func (l *Ledger) balanceAt(accountNumber string, t time.Time) money.Money {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	balance := money.Money{}
+	for _, i := range l.byAccount[accountNumber] {
+		if tx := l.transactions[i]; tx.Timestamp.Before(t) {
+			balance = tx.BalanceAfter
+		}
+	}
+	return balance
+}
+
+// IsBalanced checks that total debits equal total credits in every currency
+func (l *Ledger) IsBalanced() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	net := make(map[string]int64)
+	for _, entry := range l.entries {
+		net[entry.Debit.Currency()] += entry.Debit.MinorUnits()
+		net[entry.Credit.Currency()] -= entry.Credit.MinorUnits()
+	}
+	for _, units := range net {
+		if units != 0 {
+			return false
+		}
+	}
+	return true
+}
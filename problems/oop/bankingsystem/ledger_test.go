@@ -0,0 +1,162 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// fakeClock is a manually advanced clock for tests
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestBank creates a bank with one customer and a fake clock starting on 1 March 2024
+func newTestBank(t *testing.T) (*Bank, *Customer, *fakeClock) {
+	t.Helper()
+
+	clock := &fakeClock{now: time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)}
+	bank := NewBank("Test Bank")
+	bank.SetClock(clock)
+
+	customer, err := bank.CreateCustomer("Jane", "Smith", "456 Go Lane")
+	if err != nil {
+		t.Fatalf("CreateCustomer failed: %v", err)
+	}
+	return bank, customer, clock
+}
+
+func TestLedgerRecordsTransactions(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	checking.Deposit(usd("500"))
+	checking.Withdraw(usd("200"))
+
+	txs := bank.Ledger.Transactions(checking.AccountNumber)
+	if len(txs) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(txs))
+	}
+
+	withdrawal := txs[2]
+	if withdrawal.Type != TransactionWithdrawal || !withdrawal.Amount.Equal(usd("200")) ||
+		!withdrawal.Fee.Equal(checking.TransactionFee) || !withdrawal.BalanceAfter.Equal(usd("1295")) {
+		t.Errorf("Unexpected withdrawal record: %+v", withdrawal)
+	}
+	if txs[0].ID == txs[1].ID {
+		t.Error("Expected unique transaction IDs")
+	}
+
+	// Failed operations leave no trace
+	checking.Withdraw(usd("5000"))
+	if len(bank.Ledger.Transactions(checking.AccountNumber)) != 3 {
+		t.Error("Failed withdrawal should not be recorded")
+	}
+
+	if !bank.Ledger.IsBalanced() {
+		t.Error("Expected debits to equal credits")
+	}
+	if fees, _ := bank.Ledger.Balance(LedgerFeeIncome); !fees.Equal(checking.TransactionFee) {
+		t.Errorf("Expected fee income %v, got %v", checking.TransactionFee, fees)
+	}
+	if discrepancies, err := bank.Reconcile(); err != nil || len(discrepancies) != 0 {
+		t.Errorf("Expected no discrepancies, got %v (%v)", discrepancies, err)
+	}
+
+	// Changing a balance behind the ledger's back is caught
+	checking.Balance, _ = checking.Balance.Add(usd("10"))
+	discrepancies, _ := bank.Reconcile()
+	if len(discrepancies) != 1 || !discrepancies[0].LedgerBalance.Equal(usd("1295")) {
+		t.Errorf("Expected one discrepancy, got %v", discrepancies)
+	}
+}
+
+func TestLedgerTransactionsSince(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("100"))
+
+	clock.Advance(time.Hour)
+	since := clock.Now()
+	checking.Deposit(usd("10"))
+	savings.Deposit(usd("20"))
+	checking.Deposit(usd("30"))
+
+	txs := bank.Ledger.transactionsSince(checking.AccountNumber, since)
+	if len(txs) != 2 || !txs[0].Amount.Equal(usd("10")) || !txs[1].Amount.Equal(usd("30")) {
+		t.Errorf("Expected the checking account's last two deposits, got %+v", txs)
+	}
+	if txs := bank.Ledger.transactionsSince("AC99999", since); len(txs) != 0 {
+		t.Errorf("Expected no transactions for an unknown account, got %+v", txs)
+	}
+}
+
+func TestAccountStatement(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("2000"))
+	clock.Advance(24 * time.Hour)
+	savings.Withdraw(usd("100"))
+
+	statementStart := clock.Now().Add(time.Hour)
+	clock.Advance(48 * time.Hour)
+	savings.Deposit(usd("300"))
+	savings.ApplyMonthlyInterest()
+	statementEnd := clock.Now().Add(time.Hour)
+
+	clock.Advance(48 * time.Hour)
+	savings.Withdraw(usd("50"))
+
+	statement, err := bank.GetStatement(savings.AccountNumber, statementStart, statementEnd)
+	if err != nil {
+		t.Fatalf("GetStatement failed: %v", err)
+	}
+
+	// 0.5% of 2200.00 is exactly 11.00
+	if !statement.OpeningBalance.Equal(usd("1900")) || len(statement.Transactions) != 2 {
+		t.Errorf("Unexpected statement: %+v", statement)
+	}
+	if !statement.TotalCredits.Equal(usd("311")) || !statement.TotalDebits.IsZero() {
+		t.Errorf("Unexpected totals: credits %v, debits %v", statement.TotalCredits, statement.TotalDebits)
+	}
+	if !statement.ClosingBalance.Equal(usd("2211")) {
+		t.Errorf("Expected closing balance $2211.00, got %v", statement.ClosingBalance)
+	}
+
+	empty, _ := bank.GetStatement(savings.AccountNumber, statementStart.Add(-time.Minute), statementStart)
+	if len(empty.Transactions) != 0 || !empty.ClosingBalance.Equal(usd("1900")) {
+		t.Errorf("Expected an empty statement closing at 1900, got %+v", empty)
+	}
+
+	// Nothing before the range still gives amounts in the account's currency
+	opening, _ := bank.GetStatement(savings.AccountNumber, time.Time{}, savings.OpenDate)
+	for name, amount := range map[string]money.Money{
+		"opening": opening.OpeningBalance, "closing": opening.ClosingBalance,
+		"credits": opening.TotalCredits, "debits": opening.TotalDebits,
+	} {
+		if !amount.Equal(usd("0")) || amount.Currency() != "USD" {
+			t.Errorf("Expected %s of $0.00, got %#v", name, amount)
+		}
+	}
+
+	if _, err := bank.GetStatement("missing", statementStart, statementEnd); err == nil {
+		t.Error("GetStatement should fail for an unknown account")
+	}
+	if _, err := bank.GetStatement(savings.AccountNumber, statementEnd, statementStart); err == nil {
+		t.Error("GetStatement should reject an inverted date range")
+	}
+}
@@ -0,0 +1,353 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// AccountState is a stage in an account's lifecycle
+type AccountState string
+
+const (
+	// StateActive accounts accept every operation
+	StateActive AccountState = "active"
+	// StateFrozen accounts refuse all money movement until unfrozen
+	StateFrozen AccountState = "frozen"
+	// StateDormant accounts have been inactive too long; they refuse outgoing
+	// money and are reactivated by incoming money
+	StateDormant AccountState = "dormant"
+	// StateClosed accounts refuse everything and can't be reopened
+	StateClosed AccountState = "closed"
+)
+
+var (
+	// ErrAccountFrozen is returned when moving money in or out of a frozen account
+	ErrAccountFrozen = errors.New("account is frozen")
+	// ErrAccountDormant is returned when taking money out of a dormant account
+	ErrAccountDormant = errors.New("account is dormant")
+	// ErrAccountClosed is returned for any operation on a closed account
+	ErrAccountClosed = errors.New("account is closed")
+	// ErrInvalidTransition is returned when an account can't move to the requested state
+	ErrInvalidTransition = errors.New("invalid account state transition")
+)
+
+// transitions lists the states each state may move to
+var transitions = map[AccountState][]AccountState{
+	StateActive:  {StateFrozen, StateDormant, StateClosed},
+	StateFrozen:  {StateActive},
+	StateDormant: {StateActive, StateFrozen, StateClosed},
+	StateClosed:  {},
+}
+
+// GetState returns the account's lifecycle state
+func (a *BaseAccount) GetState() AccountState {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.State
+}
+
+// canTransition reports whether the account may move to a state
+func (a *BaseAccount) canTransition(to AccountState) error {
+	for _, allowed := range transitions[a.State] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w from %s to %s", ErrInvalidTransition, a.State, to)
+}
+
+// setState moves the account to a new state and writes an audit entry. The
+// caller must hold the account's lock.
+func (a *BaseAccount) setState(to AccountState, reason string) error {
+	if err := a.canTransition(to); err != nil {
+		return err
+	}
+
+	if a.ledger != nil {
+		a.ledger.recordAudit(AuditEntry{Subject: a.AccountNumber, From: a.State, To: to, Reason: reason})
+	}
+	a.State = to
+	return nil
+}
+
+// checkIncoming fails if the account can't receive money
+func (a *BaseAccount) checkIncoming() error {
+	switch a.State {
+	case StateFrozen:
+		return ErrAccountFrozen
+	case StateClosed:
+		return ErrAccountClosed
+	}
+	return nil
+}
+
+// checkOutgoing fails if money can't be taken out of the account
+func (a *BaseAccount) checkOutgoing() error {
+	if a.State == StateDormant {
+		return fmt.Errorf("%w: reactivate it first", ErrAccountDormant)
+	}
+	return a.checkIncoming()
+}
+
+// received reactivates a dormant account after money is paid into it
+func (a *BaseAccount) received() {
+	if a.State == StateDormant {
+		a.setState(StateActive, "reactivated by incoming payment")
+	}
+}
+
+// checkClose validates closing an account; account types with conditions
+// on closure override it
+func (a *BaseAccount) checkClose() error {
+	return nil
+}
+
+// checkClose refuses to pay out an unmatured term deposit on closure, which
+// would skip the early withdrawal rules; the balance must be withdrawn first
+func (t *TermDepositAccount) checkClose() error {
+	if t.Balance.IsPositive() && !t.IsMatured() {
+		return fmt.Errorf("%w: it matures on %s", ErrEarlyWithdrawal, t.MaturityDate.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// FreezeAccount stops all money movement on an account
+func (b *Bank) FreezeAccount(accountNumber, reason string) error {
+	return b.changeState(accountNumber, StateFrozen, reason)
+}
+
+// UnfreezeAccount makes a frozen account active again
+func (b *Bank) UnfreezeAccount(accountNumber, reason string) error {
+	return b.requireState(accountNumber, StateFrozen, StateActive, reason)
+}
+
+// ReactivateAccount makes a dormant account active again
+func (b *Bank) ReactivateAccount(accountNumber, reason string) error {
+	return b.requireState(accountNumber, StateDormant, StateActive, reason)
+}
+
+// requireState moves an account from one specific state to another
+func (b *Bank) requireState(accountNumber string, from, to AccountState, reason string) error {
+	account, err := b.bankAccount(accountNumber)
+	if err != nil {
+		return err
+	}
+
+	a := account.base()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.State != from {
+		return fmt.Errorf("%w: account is %s, not %s", ErrInvalidTransition, a.State, from)
+	}
+	return a.setState(to, reason)
+}
+
+// changeState moves an account to a state from any state that allows it
+func (b *Bank) changeState(accountNumber string, to AccountState, reason string) error {
+	account, err := b.bankAccount(accountNumber)
+	if err != nil {
+		return err
+	}
+
+	a := account.base()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.setState(to, reason)
+}
+
+// MarkDormantAccounts makes every active account without customer activity
+// for Bank.DormancyPeriod dormant and returns their account numbers.
+// Interest, fees and other bank-initiated transactions don't count as activity.
+func (b *Bank) MarkDormantAccounts() ([]string, error) {
+	now := b.Ledger.now()
+	marked := make([]string, 0)
+
+	for _, account := range b.accountList() {
+		internal, ok := account.(bankAccount)
+		if !ok {
+			continue
+		}
+
+		a := internal.base()
+		a.mu.Lock()
+		if a.State == StateActive && now.Sub(a.LastActivity) >= b.DormancyPeriod {
+			reason := fmt.Sprintf("no activity since %s", a.LastActivity.Format(time.DateOnly))
+			if err := a.setState(StateDormant, reason); err != nil {
+				a.mu.Unlock()
+				return marked, err
+			}
+			marked = append(marked, a.AccountNumber)
+		}
+		a.mu.Unlock()
+	}
+
+	return marked, nil
+}
+
+// CloseAccount closes an account for good. A positive balance is paid out to
+// payoutAccountNumber in full, without fees or withdrawal limits; an account
+// that owes money must be settled first. A payout into another currency is
+// converted at the bank's rates without the FX spread. Frozen accounts can't
+// be closed. Savings accounts are first paid the interest accrued so far this
+// month, pro-rated over the days accrued.
+func (b *Bank) CloseAccount(accountNumber, payoutAccountNumber string) error {
+	account, err := b.bankAccount(accountNumber)
+	if err != nil {
+		return err
+	}
+
+	a := account.base()
+	var payout bankAccount
+	if payoutAccountNumber != "" {
+		if payoutAccountNumber == accountNumber {
+			return fmt.Errorf("%w: cannot pay a closing balance into the same account", ErrInvalidArgument)
+		}
+		if payout, err = b.bankAccount(payoutAccountNumber); err != nil {
+			return fmt.Errorf("payout %w", err)
+		}
+		unlock := lockAccounts(a, payout.base())
+		defer unlock()
+	} else {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+	}
+
+	if err := a.canTransition(StateClosed); err != nil {
+		return err
+	}
+	if err := account.checkClose(); err != nil {
+		return err
+	}
+	if a.Balance.IsNegative() {
+		return fmt.Errorf("%w: account owes %v and must be settled before closing", ErrInvalidArgument, a.Balance.Neg())
+	}
+
+	// Accrued interest is part of what is paid out, but is only credited once
+	// the payout is known to succeed
+	savings, isSavings := account.(*SavingsAccount)
+	closing := a.Balance
+	var interest money.Money
+	if isSavings {
+		if interest, err = savings.closingInterest(b.Ledger.now()); err != nil {
+			return err
+		}
+		if closing, err = closing.Add(interest); err != nil {
+			return err
+		}
+	}
+
+	reason := "closed"
+	if closing.IsPositive() {
+		if payout == nil {
+			return fmt.Errorf("%w: an account with a balance needs a payout account to close", ErrInvalidArgument)
+		}
+		if err := payout.base().checkIncoming(); err != nil {
+			return fmt.Errorf("payout %w", err)
+		}
+		// The spread is a fee, which closing payouts don't pay
+		conversion, _, err := b.exchange(closing, payout.base().Balance.Currency())
+		if err != nil {
+			return err
+		}
+		received := closing
+		if conversion != nil {
+			received = conversion.Converted
+		}
+		if err := payout.checkDeposit(received); err != nil {
+			return fmt.Errorf("payout %w", err)
+		}
+		payoutBalance, err := payout.base().Balance.Add(received)
+		if err != nil {
+			return err
+		}
+
+		if isSavings {
+			if err := savings.creditInterest(interest, "Interest to closing"); err != nil {
+				return err
+			}
+		}
+		amount := a.Balance
+		a.Balance, _ = a.Balance.Sub(amount)
+		payout.base().Balance = payoutBalance
+
+		a.record(Transaction{
+			Type:         TransactionTransferOut,
+			Amount:       amount,
+			Counterparty: payoutAccountNumber,
+			Description:  "Closing payout to " + payoutAccountNumber,
+			FX:           conversion,
+		})
+		payout.base().record(Transaction{
+			Type:         TransactionTransferIn,
+			Amount:       received,
+			Counterparty: accountNumber,
+			Description:  "Closing payout from " + accountNumber,
+			FX:           conversion,
+		})
+		payout.base().received()
+		reason = fmt.Sprintf("closed, %v paid out to %s", amount, payoutAccountNumber)
+	}
+
+	return a.setState(StateClosed, reason)
+}
+
+// RemoveCustomer deletes a customer whose accounts are all closed. The closed
+// accounts stay with the bank so their history remains available.
+func (b *Bank) RemoveCustomer(customerID string) error {
+	accounts, err := b.GetCustomerAccounts(customerID)
+	if err != nil {
+		return err
+	}
+
+	// Closed is final, so accounts checked here can't change back
+	for _, account := range accounts {
+		if internal, ok := account.(bankAccount); ok && internal.base().GetState() != StateClosed {
+			return fmt.Errorf("%w: customer still has open account %s", ErrInvalidArgument, account.GetAccountNumber())
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	customer, exists := b.Customers[customerID]
+	if !exists {
+		return ErrCustomerNotFound
+	}
+	// Refuse if an account was opened since the check above
+	if len(customer.GetAccountNumbers()) != len(accounts) {
+		return fmt.Errorf("%w: customer opened an account while being removed", ErrInvalidArgument)
+	}
+
+	delete(b.Customers, customerID)
+	b.Ledger.recordAudit(AuditEntry{Subject: customerID, Reason: "customer removed"})
+	return nil
+}
+
+// lockAccounts locks two accounts in account number order, so concurrent
+// operations on the same pair can't deadlock, and returns a function that
+// unlocks them
+func lockAccounts(a, b *BaseAccount) func() {
+	first, second := a, b
+	if second.AccountNumber < first.AccountNumber {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	second.mu.Lock()
+	return func() {
+		second.mu.Unlock()
+		first.mu.Unlock()
+	}
+}
@@ -0,0 +1,245 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+func TestFreezeAccount(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	bank.CheckingFeeRate = money.Money{}
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("500"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("500"))
+
+	if err := bank.FreezeAccount(checking.AccountNumber, "suspected fraud"); err != nil {
+		t.Fatalf("FreezeAccount failed: %v", err)
+	}
+
+	if err := checking.Deposit(usd("10")); !errors.Is(err, ErrAccountFrozen) {
+		t.Errorf("Expected deposits to be refused, got %v", err)
+	}
+	if err := checking.Withdraw(usd("10")); !errors.Is(err, ErrAccountFrozen) {
+		t.Errorf("Expected withdrawals to be refused, got %v", err)
+	}
+	if err := bank.Transfer(savings.AccountNumber, checking.AccountNumber, usd("10")); !errors.Is(err, ErrAccountFrozen) {
+		t.Errorf("Expected transfers in to be refused, got %v", err)
+	}
+	if err := bank.CloseAccount(checking.AccountNumber, savings.AccountNumber); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected a frozen account not to close, got %v", err)
+	}
+	expectBalance(t, checking, "500")
+	expectBalance(t, savings, "500")
+
+	if err := bank.UnfreezeAccount(checking.AccountNumber, "cleared"); err != nil {
+		t.Fatalf("UnfreezeAccount failed: %v", err)
+	}
+	if err := checking.Withdraw(usd("10")); err != nil {
+		t.Errorf("Expected withdrawals after unfreezing, got %v", err)
+	}
+	if err := bank.UnfreezeAccount(checking.AccountNumber, ""); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected unfreezing an active account to fail, got %v", err)
+	}
+
+	trail := bank.Ledger.AuditTrail(checking.AccountNumber)
+	if len(trail) != 2 || trail[0].To != StateFrozen || trail[0].Reason != "suspected fraud" ||
+		trail[1].From != StateFrozen || trail[1].To != StateActive {
+		t.Errorf("Unexpected audit trail: %+v", trail)
+	}
+}
+
+func TestDormancy(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	bank.DormancyPeriod = 90 * 24 * time.Hour
+	idle, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+	busy, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+
+	// Interest is not activity, a deposit is
+	for month := 0; month < 3; month++ {
+		clock.Advance(31 * 24 * time.Hour)
+		bank.ApplyMonthlyInterest()
+		busy.Deposit(usd("1"))
+	}
+
+	marked, err := bank.MarkDormantAccounts()
+	if err != nil || len(marked) != 1 || marked[0] != idle.AccountNumber {
+		t.Fatalf("Expected only %s to become dormant, got %v (%v)", idle.AccountNumber, marked, err)
+	}
+	if idle.GetState() != StateDormant || busy.GetState() != StateActive {
+		t.Errorf("Unexpected states %s and %s", idle.GetState(), busy.GetState())
+	}
+
+	if err := idle.Withdraw(usd("10")); !errors.Is(err, ErrAccountDormant) {
+		t.Errorf("Expected withdrawals from a dormant account to fail, got %v", err)
+	}
+	if err := bank.Transfer(idle.AccountNumber, busy.AccountNumber, usd("10")); !errors.Is(err, ErrAccountDormant) {
+		t.Errorf("Expected transfers out of a dormant account to fail, got %v", err)
+	}
+
+	// Incoming money wakes the account up
+	if err := bank.Transfer(busy.AccountNumber, idle.AccountNumber, usd("10")); err != nil {
+		t.Fatalf("Transfer into a dormant account failed: %v", err)
+	}
+	if idle.GetState() != StateActive {
+		t.Errorf("Expected the transfer to reactivate the account, got %s", idle.GetState())
+	}
+
+	if marked, _ := bank.MarkDormantAccounts(); len(marked) != 0 {
+		t.Errorf("Expected no accounts to become dormant, got %v", marked)
+	}
+
+	clock.Advance(100 * 24 * time.Hour)
+	bank.MarkDormantAccounts()
+	if err := bank.ReactivateAccount(idle.AccountNumber, "customer called"); err != nil {
+		t.Errorf("ReactivateAccount failed: %v", err)
+	}
+	if err := idle.Withdraw(usd("10")); err != nil {
+		t.Errorf("Expected withdrawals after reactivation, got %v", err)
+	}
+
+	trail := bank.Ledger.AuditTrail(idle.AccountNumber)
+	states := make([]AccountState, 0, len(trail))
+	for _, entry := range trail {
+		states = append(states, entry.To)
+	}
+	expected := []AccountState{StateDormant, StateActive, StateDormant, StateActive}
+	if len(states) != len(expected) {
+		t.Fatalf("Expected transitions %v, got %v", expected, states)
+	}
+	for i := range expected {
+		if states[i] != expected[i] {
+			t.Errorf("Expected transitions %v, got %v", expected, states)
+			break
+		}
+	}
+}
+
+func TestCloseAccount(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("250.75"))
+
+	// The payout ignores the savings withdrawal limit
+	savings.MaxWithdrawals = 0
+	if err := bank.CloseAccount(savings.AccountNumber, ""); err == nil {
+		t.Error("Expected closing with a balance and no payout account to fail")
+	}
+	if err := bank.CloseAccount(savings.AccountNumber, checking.AccountNumber); err != nil {
+		t.Fatalf("CloseAccount failed: %v", err)
+	}
+	expectBalance(t, savings, "0")
+	expectBalance(t, checking, "350.75")
+	if savings.GetState() != StateClosed {
+		t.Errorf("Expected the account to be closed, got %s", savings.GetState())
+	}
+
+	if err := savings.Deposit(usd("1")); !errors.Is(err, ErrAccountClosed) {
+		t.Errorf("Expected deposits to a closed account to fail, got %v", err)
+	}
+	if err := bank.Transfer(checking.AccountNumber, savings.AccountNumber, usd("1")); !errors.Is(err, ErrAccountClosed) {
+		t.Errorf("Expected transfers to a closed account to fail, got %v", err)
+	}
+	if err := bank.FreezeAccount(savings.AccountNumber, ""); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected a closed account to stay closed, got %v", err)
+	}
+
+	// Closed accounts keep their history and get no month-end processing
+	bank.ApplyMonthlyInterest()
+	txs := bank.Ledger.Transactions(savings.AccountNumber)
+	if last := txs[len(txs)-1]; last.Type != TransactionTransferOut || last.Counterparty != checking.AccountNumber {
+		t.Errorf("Expected the payout to be the last transaction, got %+v", last)
+	}
+	expectReconciled(t, bank)
+
+	if err := bank.RemoveCustomer(customer.ID); err == nil {
+		t.Error("Expected removing a customer with an open account to fail")
+	}
+	if err := bank.CloseAccount(checking.AccountNumber, ""); err == nil {
+		t.Error("Expected closing a funded account without a payout to fail")
+	}
+	checking.Withdraw(usd("345.75"))
+	if err := bank.CloseAccount(checking.AccountNumber, ""); err != nil {
+		t.Fatalf("Closing an empty account failed: %v", err)
+	}
+
+	if err := bank.RemoveCustomer(customer.ID); err != nil {
+		t.Fatalf("RemoveCustomer failed: %v", err)
+	}
+	if _, err := bank.GetCustomer(customer.ID); !errors.Is(err, ErrCustomerNotFound) {
+		t.Errorf("Expected the customer to be gone, got %v", err)
+	}
+	if _, err := bank.GetAccount(savings.AccountNumber); err != nil {
+		t.Errorf("Expected closed accounts to remain, got %v", err)
+	}
+	if trail := bank.Ledger.AuditTrail(customer.ID); len(trail) != 1 {
+		t.Errorf("Expected the removal to be audited, got %+v", trail)
+	}
+}
+
+func TestCloseAccountRules(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+
+	overdraft, _ := bank.OpenOverdraftAccount(customer.ID, usd("0"), usd("100"))
+	overdraft.Withdraw(usd("10"))
+	if err := bank.CloseAccount(overdraft.AccountNumber, checking.AccountNumber); err == nil {
+		t.Error("Expected an overdrawn account not to close")
+	}
+
+	term, _ := bank.OpenTermDeposit(customer.ID, usd("1000"), 6)
+	if err := bank.CloseAccount(term.AccountNumber, checking.AccountNumber); !errors.Is(err, ErrEarlyWithdrawal) {
+		t.Errorf("Expected an unmatured term deposit not to close, got %v", err)
+	}
+
+	// A frozen payout account can't receive the balance
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("50"))
+	bank.FreezeAccount(checking.AccountNumber, "")
+	if err := bank.CloseAccount(savings.AccountNumber, checking.AccountNumber); !errors.Is(err, ErrAccountFrozen) {
+		t.Errorf("Expected a frozen payout account to be refused, got %v", err)
+	}
+	if savings.GetState() != StateActive {
+		t.Errorf("Expected a failed closure to leave the account active, got %s", savings.GetState())
+	}
+	expectReconciled(t, bank)
+}
+
+func TestServerLifecycle(t *testing.T) {
+	client, bank, _ := newAPIClient(t)
+	customer, _ := bank.CreateCustomer("Jane", "Smith", "456 Go Lane")
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("100"))
+	path := "/accounts/" + checking.AccountNumber
+
+	var account accountView
+	client.expectStatus(client.do("POST", path+"/freeze", `{"reason":"lost card"}`, nil, &account), http.StatusOK)
+	if account.State != StateFrozen {
+		t.Errorf("Expected a frozen account, got %+v", account)
+	}
+	client.expectStatus(client.do("POST", path+"/deposits", `{"amount":"5"}`, nil, nil), http.StatusConflict)
+	client.expectStatus(client.do("POST", path+"/unfreeze", "", nil, nil), http.StatusOK)
+
+	var trail []AuditEntry
+	client.expectStatus(client.do("GET", "/audit/"+checking.AccountNumber, "", nil, &trail), http.StatusOK)
+	if len(trail) != 2 || trail[0].Reason != "lost card" {
+		t.Errorf("Unexpected audit trail: %+v", trail)
+	}
+
+	client.expectStatus(client.do("DELETE", "/customers/"+customer.ID, "", nil, nil), http.StatusBadRequest)
+	client.expectStatus(client.do("POST", path+"/close", `{"payoutTo":"`+savings.AccountNumber+`"}`, nil, &account), http.StatusOK)
+	if account.State != StateClosed || !account.Balance.IsZero() {
+		t.Errorf("Expected a closed, empty account, got %+v", account)
+	}
+	client.expectStatus(client.do("POST", path+"/close", "", nil, nil), http.StatusConflict)
+}
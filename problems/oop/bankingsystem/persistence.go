@@ -0,0 +1,536 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// Account type names used in stored records and by the REST API
+const (
+	AccountTypeChecking    = "checking"
+	AccountTypeSavings     = "savings"
+	AccountTypeOverdraft   = "overdraft"
+	AccountTypeCreditLine  = "creditline"
+	AccountTypeTermDeposit = "termdeposit"
+)
+
+// Repository stores a bank's customers, accounts and transactions between
+// runs. Save replaces everything stored at once, so a bank loaded after a
+// crash sees either the previous or the new state, never a mix of both.
+type Repository interface {
+	// Load returns the stored state, or an empty snapshot if nothing was saved yet
+	Load() (*Snapshot, error)
+	// Save replaces the stored state
+	Save(snapshot *Snapshot) error
+}
+
+// Snapshot is the complete state of a bank at one moment
+type Snapshot struct {
+	Currency         string           `json:"currency,omitempty"`
+	Counters         Counters         `json:"counters"`
+	ScheduledThrough time.Time        `json:"scheduledThrough,omitzero"`
+	Customers        []CustomerRecord `json:"customers"`
+	Accounts         []AccountRecord  `json:"accounts"`
+	Transactions     []Transaction    `json:"transactions"`
+	Audit            []AuditEntry     `json:"audit"`
+	Decisions        []Decision       `json:"decisions,omitempty"`
+}
+
+// Counters are the next IDs the bank hands out
+type Counters struct {
+	NextCustomerID    int `json:"nextCustomerId"`
+	NextAccountNumber int `json:"nextAccountNumber"`
+}
+
+// CustomerRecord is the stored form of a customer
+type CustomerRecord struct {
+	ID             string    `json:"id"`
+	FirstName      string    `json:"firstName"`
+	LastName       string    `json:"lastName"`
+	Address        string    `json:"address"`
+	JoinDate       time.Time `json:"joinDate"`
+	AccountNumbers []string  `json:"accountNumbers"`
+}
+
+// AccountRecord is the stored form of an account of any type. Fields that
+// don't apply to the account's type are left zero.
+type AccountRecord struct {
+	Type          string               `json:"type"`
+	AccountNumber string               `json:"accountNumber"`
+	OwnerID       string               `json:"ownerId"`
+	Balance       money.Money          `json:"balance"`
+	OpenDate      time.Time            `json:"openDate"`
+	State         AccountState         `json:"state"`
+	LastActivity  time.Time            `json:"lastActivity"`
+	JobPeriods    map[string]time.Time `json:"jobPeriods,omitempty"`
+
+	// Checking and overdraft accounts
+	TransactionFee        money.Money `json:"transactionFee,omitzero"`
+	OverdraftLimit        money.Money `json:"overdraftLimit,omitzero"`
+	OverdraftFee          money.Money `json:"overdraftFee,omitzero"`
+	OverdraftInterestRate money.Rate  `json:"overdraftInterestRate,omitzero"`
+
+	// Savings accounts, credit lines and term deposits
+	InterestRate         money.Rate  `json:"interestRate,omitzero"`
+	WithdrawalsThisMonth int         `json:"withdrawalsThisMonth,omitzero"`
+	MaxWithdrawals       int         `json:"maxWithdrawals,omitzero"`
+	AccruedBalance       money.Money `json:"accruedBalance,omitzero"`
+	AccrualDays          int         `json:"accrualDays,omitzero"`
+
+	// Credit lines
+	CreditLimit         money.Money   `json:"creditLimit,omitzero"`
+	MinimumPaymentRate  money.Rate    `json:"minimumPaymentRate,omitzero"`
+	MinimumPaymentFloor money.Money   `json:"minimumPaymentFloor,omitzero"`
+	LateFee             money.Money   `json:"lateFee,omitzero"`
+	MinimumPaymentDue   money.Money   `json:"minimumPaymentDue,omitzero"`
+	Cycles              []CreditCycle `json:"cycles,omitempty"`
+	CycleStart          time.Time     `json:"cycleStart,omitzero"`
+	CycleTransactions   int           `json:"cycleTransactions,omitzero"`
+
+	// Term deposits
+	TermMonths             int        `json:"termMonths,omitzero"`
+	MaturityDate           time.Time  `json:"maturityDate,omitzero"`
+	AllowEarlyWithdrawal   bool       `json:"allowEarlyWithdrawal,omitzero"`
+	EarlyWithdrawalPenalty money.Rate `json:"earlyWithdrawalPenalty,omitzero"`
+	InterestPayments       int        `json:"interestPayments,omitzero"`
+}
+
+// Save writes the bank's current state to a repository. Every account is
+// locked while the snapshot is taken, so no transaction is half-captured.
+func (b *Bank) Save(repo Repository) error {
+	b.saveMu.Lock()
+	defer b.saveMu.Unlock()
+
+	snapshot, err := b.snapshot()
+	if err != nil {
+		return err
+	}
+	return repo.Save(snapshot)
+}
+
+// snapshot captures the bank's state. It takes every account's lock in
+// account number order, then the bank's lock, following the usual lock order.
+func (b *Bank) snapshot() (*Snapshot, error) {
+	for {
+		accounts := make([]bankAccount, 0)
+		for _, account := range b.accountList() {
+			internal, ok := account.(bankAccount)
+			if !ok {
+				return nil, fmt.Errorf("account %s can't be saved", account.GetAccountNumber())
+			}
+			accounts = append(accounts, internal)
+		}
+
+		for _, account := range accounts {
+			account.base().mu.Lock()
+		}
+		b.mu.RLock()
+
+		// An account opened after the list was taken isn't locked; start over
+		if len(b.Accounts) == len(accounts) {
+			snapshot := b.snapshotLocked(accounts)
+			b.mu.RUnlock()
+			for _, account := range accounts {
+				account.base().mu.Unlock()
+			}
+			return snapshot, nil
+		}
+
+		b.mu.RUnlock()
+		for _, account := range accounts {
+			account.base().mu.Unlock()
+		}
+	}
+}
+
+// snapshotLocked builds a snapshot while the caller holds every lock
+func (b *Bank) snapshotLocked(accounts []bankAccount) *Snapshot {
+	snapshot := &Snapshot{
+		Currency:         b.Currency,
+		ScheduledThrough: b.ScheduledThrough,
+		Counters: Counters{
+			NextCustomerID:    b.NextCustomerID,
+			NextAccountNumber: b.NextAccountNumber,
+		},
+		Customers: make([]CustomerRecord, 0, len(b.Customers)),
+		Accounts:  make([]AccountRecord, 0, len(accounts)),
+	}
+
+	for _, customer := range b.Customers {
+		snapshot.Customers = append(snapshot.Customers, CustomerRecord{
+			ID:             customer.ID,
+			FirstName:      customer.FirstName,
+			LastName:       customer.LastName,
+			Address:        customer.Address,
+			JoinDate:       customer.JoinDate,
+			AccountNumbers: customer.GetAccountNumbers(),
+		})
+	}
+	sort.Slice(snapshot.Customers, func(i, j int) bool {
+		return snapshot.Customers[i].ID < snapshot.Customers[j].ID
+	})
+
+	for _, account := range accounts {
+		snapshot.Accounts = append(snapshot.Accounts, newAccountRecord(account))
+	}
+
+	snapshot.Transactions, snapshot.Audit = b.Ledger.snapshot()
+	snapshot.Decisions = b.Rules.snapshot()
+	return snapshot
+}
+
+// newAccountRecord converts an account to its stored form. The caller must
+// hold the account's lock.
+func newAccountRecord(account bankAccount) AccountRecord {
+	a := account.base()
+	record := AccountRecord{
+		AccountNumber: a.AccountNumber,
+		OwnerID:       a.OwnerID,
+		Balance:       a.Balance,
+		OpenDate:      a.OpenDate,
+		State:         a.State,
+		LastActivity:  a.LastActivity,
+		JobPeriods:    maps.Clone(a.JobPeriods),
+	}
+
+	switch acc := account.(type) {
+	case *CheckingAccount:
+		record.Type = AccountTypeChecking
+		record.TransactionFee = acc.TransactionFee
+	case *SavingsAccount:
+		record.Type = AccountTypeSavings
+		record.InterestRate = acc.InterestRate
+		record.WithdrawalsThisMonth = acc.WithdrawalsThisMonth
+		record.MaxWithdrawals = acc.MaxWithdrawals
+		record.AccruedBalance = acc.AccruedBalance
+		record.AccrualDays = acc.AccrualDays
+	case *OverdraftAccount:
+		record.Type = AccountTypeOverdraft
+		record.TransactionFee = acc.TransactionFee
+		record.OverdraftLimit = acc.OverdraftLimit
+		record.OverdraftFee = acc.OverdraftFee
+		record.OverdraftInterestRate = acc.OverdraftInterestRate
+	case *CreditLineAccount:
+		record.Type = AccountTypeCreditLine
+		record.CreditLimit = acc.CreditLimit
+		record.InterestRate = acc.InterestRate
+		record.MinimumPaymentRate = acc.MinimumPaymentRate
+		record.MinimumPaymentFloor = acc.MinimumPaymentFloor
+		record.LateFee = acc.LateFee
+		record.MinimumPaymentDue = acc.MinimumPaymentDue
+		record.Cycles = append([]CreditCycle(nil), acc.Cycles...)
+		record.CycleStart = acc.cycleStart
+		record.CycleTransactions = acc.cycleTransactions
+	case *TermDepositAccount:
+		record.Type = AccountTypeTermDeposit
+		record.InterestRate = acc.InterestRate
+		record.TermMonths = acc.TermMonths
+		record.MaturityDate = acc.MaturityDate
+		record.AllowEarlyWithdrawal = acc.AllowEarlyWithdrawal
+		record.EarlyWithdrawalPenalty = acc.EarlyWithdrawalPenalty
+		record.InterestPayments = acc.InterestPayments
+	}
+	return record
+}
+
+// restoreAccount rebuilds an account from its stored form
+func restoreAccount(record AccountRecord, ledger *Ledger) (bankAccount, error) {
+	if _, ok := transitions[record.State]; !ok {
+		return nil, fmt.Errorf("unknown account state %q", record.State)
+	}
+
+	var account bankAccount
+	switch record.Type {
+	case AccountTypeChecking:
+		account = &CheckingAccount{TransactionFee: record.TransactionFee}
+	case AccountTypeSavings:
+		account = &SavingsAccount{
+			InterestRate:         record.InterestRate,
+			WithdrawalsThisMonth: record.WithdrawalsThisMonth,
+			MaxWithdrawals:       record.MaxWithdrawals,
+			AccruedBalance:       record.AccruedBalance,
+			AccrualDays:          record.AccrualDays,
+		}
+	case AccountTypeOverdraft:
+		account = &OverdraftAccount{
+			CheckingAccount:       CheckingAccount{TransactionFee: record.TransactionFee},
+			OverdraftLimit:        record.OverdraftLimit,
+			OverdraftFee:          record.OverdraftFee,
+			OverdraftInterestRate: record.OverdraftInterestRate,
+		}
+	case AccountTypeCreditLine:
+		account = &CreditLineAccount{
+			CreditLimit:         record.CreditLimit,
+			InterestRate:        record.InterestRate,
+			MinimumPaymentRate:  record.MinimumPaymentRate,
+			MinimumPaymentFloor: record.MinimumPaymentFloor,
+			LateFee:             record.LateFee,
+			MinimumPaymentDue:   record.MinimumPaymentDue,
+			Cycles:              append([]CreditCycle(nil), record.Cycles...),
+			cycleStart:          record.CycleStart,
+			cycleTransactions:   record.CycleTransactions,
+		}
+	case AccountTypeTermDeposit:
+		account = &TermDepositAccount{
+			InterestRate:           record.InterestRate,
+			TermMonths:             record.TermMonths,
+			MaturityDate:           record.MaturityDate,
+			AllowEarlyWithdrawal:   record.AllowEarlyWithdrawal,
+			EarlyWithdrawalPenalty: record.EarlyWithdrawalPenalty,
+			InterestPayments:       record.InterestPayments,
+		}
+	default:
+		return nil, fmt.Errorf("unknown account type %q", record.Type)
+	}
+
+	base := account.base()
+	base.AccountNumber = record.AccountNumber
+	base.Balance = record.Balance
+	base.OpenDate = record.OpenDate
+	base.OwnerID = record.OwnerID
+	base.State = record.State
+	base.LastActivity = record.LastActivity
+	base.JobPeriods = record.JobPeriods
+	base.ledger = ledger
+	return account, nil
+}
+
+// LoadBank creates a bank with the default configuration and restores the
+// state stored in a repository. It fails if the stored balances don't match
+// the stored transactions, so a damaged store is never silently used.
+func LoadBank(name string, repo Repository) (*Bank, error) {
+	snapshot, err := repo.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	b := NewBank(name)
+	if snapshot.Currency != "" {
+		b.Currency = snapshot.Currency
+	}
+	b.ScheduledThrough = snapshot.ScheduledThrough
+	if snapshot.Counters.NextCustomerID != 0 {
+		b.NextCustomerID = snapshot.Counters.NextCustomerID
+	}
+	if snapshot.Counters.NextAccountNumber != 0 {
+		b.NextAccountNumber = snapshot.Counters.NextAccountNumber
+	}
+
+	for _, record := range snapshot.Accounts {
+		if _, exists := b.Accounts[record.AccountNumber]; exists {
+			return nil, fmt.Errorf("duplicate account %s", record.AccountNumber)
+		}
+		account, err := restoreAccount(record, b.Ledger)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", record.AccountNumber, err)
+		}
+		account.base().rules = b.Rules
+		b.Accounts[record.AccountNumber] = account
+	}
+
+	for _, record := range snapshot.Customers {
+		if _, exists := b.Customers[record.ID]; exists {
+			return nil, fmt.Errorf("duplicate customer %s", record.ID)
+		}
+		for _, accountNumber := range record.AccountNumbers {
+			if _, exists := b.Accounts[accountNumber]; !exists {
+				return nil, fmt.Errorf("customer %s: %w: %s", record.ID, ErrAccountNotFound, accountNumber)
+			}
+		}
+		b.Customers[record.ID] = &Customer{
+			ID:             record.ID,
+			FirstName:      record.FirstName,
+			LastName:       record.LastName,
+			Address:        record.Address,
+			JoinDate:       record.JoinDate,
+			AccountNumbers: append([]string{}, record.AccountNumbers...),
+		}
+	}
+
+	b.Ledger.restore(snapshot.Transactions, snapshot.Audit)
+	b.Rules.restore(snapshot.Decisions)
+
+	discrepancies, err := b.Reconcile()
+	if err != nil {
+		return nil, err
+	}
+	if len(discrepancies) > 0 {
+		d := discrepancies[0]
+		return nil, fmt.Errorf("stored balance of account %s is %v but its transactions add up to %v",
+			d.AccountNumber, d.Balance, d.LedgerBalance)
+	}
+	return b, nil
+}
+
+// snapshot returns copies of the ledger's transactions and audit trail
+func (l *Ledger) snapshot() ([]Transaction, []AuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Transaction{}, l.transactions...), append([]AuditEntry{}, l.audit...)
+}
+
+// restore replaces the ledger's contents with stored transactions and audit
+// entries, posting the transactions' entries again
+func (l *Ledger) restore(transactions []Transaction, audit []AuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.transactions = append([]Transaction{}, transactions...)
+	l.audit = append([]AuditEntry{}, audit...)
+	l.byAccount = make(map[string][]int)
+	l.entries = nil
+	for i, tx := range l.transactions {
+		l.byAccount[tx.AccountNumber] = append(l.byAccount[tx.AccountNumber], i)
+		l.postTransaction(tx)
+	}
+	l.nextID = len(l.transactions) + 1
+}
+
+// snapshot returns a copy of the engine's decisions
+func (e *RuleEngine) snapshot() []Decision {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Decision{}, e.decisions...)
+}
+
+// restore replaces the engine's decisions with stored ones
+func (e *RuleEngine) restore(decisions []Decision) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.decisions = append([]Decision{}, decisions...)
+}
+
+// MemoryRepository keeps the saved state in memory. It is useful in tests
+// and for running a bank that doesn't need to survive a restart.
+type MemoryRepository struct {
+	data []byte
+	mu   sync.Mutex
+}
+
+// NewMemoryRepository creates an empty in-memory repository
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{}
+}
+
+// Load returns a copy of the saved state
+func (r *MemoryRepository) Load() (*Snapshot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return decodeSnapshot(r.data)
+}
+
+// Save replaces the saved state. The snapshot is stored encoded, so later
+// changes to it don't leak into the repository.
+func (r *MemoryRepository) Save(snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data = data
+	return nil
+}
+
+// FileRepository keeps the saved state in a JSON file
+type FileRepository struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// NewFileRepository creates a repository stored at path. The file is created
+// by the first Save.
+func NewFileRepository(path string) *FileRepository {
+	return &FileRepository{Path: path}
+}
+
+// Load reads the file, returning an empty snapshot if it doesn't exist yet
+func (r *FileRepository) Load() (*Snapshot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := os.ReadFile(r.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Snapshot{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnapshot(data)
+}
+
+// Save writes the snapshot to a temporary file and renames it over the old
+// one, so a crash part way through leaves the previous state intact
+func (r *FileRepository) Save(snapshot *Snapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.Path), filepath.Base(r.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), r.Path); err != nil {
+		return err
+	}
+	// The rename only survives a crash once the directory entry is flushed
+	return syncDir(filepath.Dir(r.Path))
+}
+
+// syncDir flushes a directory's entries, making renames inside it durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// decodeSnapshot decodes a stored snapshot; no data is an empty snapshot
+func decodeSnapshot(data []byte) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if len(data) == 0 {
+		return snapshot, nil
+	}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("decoding stored bank state: %w", err)
+	}
+	return snapshot, nil
+}
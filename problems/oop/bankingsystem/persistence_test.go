@@ -0,0 +1,189 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newBusyBank creates a bank with every account type, some history and a
+// removed customer
+func newBusyBank(t *testing.T) (*Bank, *Customer, *fakeClock) {
+	t.Helper()
+	bank, customer, clock := newTestBank(t)
+
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("500"))
+	overdraft, _ := bank.OpenOverdraftAccount(customer.ID, usd("50"), usd("300"))
+	credit, _ := bank.OpenCreditLine(customer.ID, usd("2000"))
+	term, _ := bank.OpenTermDeposit(customer.ID, usd("1000"), 12)
+
+	clock.Advance(24 * time.Hour)
+	checking.Withdraw(usd("100"))
+	savings.Withdraw(usd("20"))
+	overdraft.Withdraw(usd("100"))
+	credit.Withdraw(usd("400"))
+	bank.Transfer(checking.AccountNumber, credit.AccountNumber, usd("50"))
+	clock.Advance(30 * 24 * time.Hour)
+	if err := bank.ApplyMonthlyInterest(); err != nil {
+		t.Fatalf("ApplyMonthlyInterest failed: %v", err)
+	}
+	bank.FreezeAccount(term.AccountNumber, "court order")
+
+	leaving, _ := bank.CreateCustomer("Sam", "Jones", "1 Exit Road")
+	old, _ := bank.OpenCheckingAccount(leaving.ID, usd("75"))
+	bank.CloseAccount(old.AccountNumber, checking.AccountNumber)
+	if err := bank.RemoveCustomer(leaving.ID); err != nil {
+		t.Fatalf("RemoveCustomer failed: %v", err)
+	}
+	return bank, customer, clock
+}
+
+func TestSaveAndLoadBank(t *testing.T) {
+	repos := map[string]Repository{
+		"memory": NewMemoryRepository(),
+		"file":   NewFileRepository(filepath.Join(t.TempDir(), "bank.json")),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			bank, customer, clock := newBusyBank(t)
+			if err := bank.Save(repo); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+
+			loaded, err := LoadBank(bank.Name, repo)
+			if err != nil {
+				t.Fatalf("LoadBank failed: %v", err)
+			}
+			loaded.SetClock(clock)
+
+			before, _ := bank.snapshot()
+			after, _ := loaded.snapshot()
+			if !reflect.DeepEqual(before.Counters, after.Counters) {
+				t.Errorf("Expected counters %+v, got %+v", before.Counters, after.Counters)
+			}
+			if len(after.Customers) != 1 || len(after.Accounts) != 6 {
+				t.Fatalf("Expected 1 customer and 6 accounts, got %d and %d", len(after.Customers), len(after.Accounts))
+			}
+			for i, account := range before.Accounts {
+				restored := after.Accounts[i]
+				if !account.Balance.Equal(restored.Balance) || account.State != restored.State ||
+					!account.LastActivity.Equal(restored.LastActivity) || account.Type != restored.Type {
+					t.Errorf("Expected account %+v, got %+v", account, restored)
+				}
+			}
+			if len(after.Transactions) != len(before.Transactions) || len(after.Audit) != len(before.Audit) {
+				t.Errorf("Expected %d transactions and %d audit entries, got %d and %d",
+					len(before.Transactions), len(before.Audit), len(after.Transactions), len(after.Audit))
+			}
+			expectReconciled(t, loaded)
+			for _, account := range before.Accounts {
+				if saved, restored := bank.Ledger.Transactions(account.AccountNumber), loaded.Ledger.Transactions(account.AccountNumber); !reflect.DeepEqual(saved, restored) {
+					t.Errorf("Expected account %s's transactions %v, got %v", account.AccountNumber, saved, restored)
+				}
+			}
+
+			restoredCustomer, err := loaded.GetCustomer(customer.ID)
+			if err != nil || !reflect.DeepEqual(restoredCustomer.GetAccountNumbers(), customer.GetAccountNumbers()) {
+				t.Errorf("Expected customer %s with accounts %v, got %v (%v)", customer.ID, customer.GetAccountNumbers(), restoredCustomer, err)
+			}
+
+			// The restored bank carries on where the saved one stopped
+			next, err := loaded.OpenCheckingAccount(customer.ID, usd("10"))
+			if err != nil {
+				t.Fatalf("OpenCheckingAccount failed: %v", err)
+			}
+			if next.AccountNumber != "AC10006" {
+				t.Errorf("Expected the next account number to be AC10006, got %s", next.AccountNumber)
+			}
+			if txs := loaded.Ledger.Transactions(next.AccountNumber); len(txs) != 1 || txs[0].ID <= before.Transactions[len(before.Transactions)-1].ID {
+				t.Errorf("Expected a new transaction ID after the saved ones, got %v", txs)
+			}
+			newCustomer, _ := loaded.CreateCustomer("Ann", "Lee", "2 New Street")
+			if newCustomer.ID != "C1002" {
+				t.Errorf("Expected the next customer ID to be C1002, got %s", newCustomer.ID)
+			}
+
+			term, _ := loaded.GetAccount("AT10004")
+			if err := term.Withdraw(usd("10")); !errors.Is(err, ErrAccountFrozen) {
+				t.Errorf("Expected the term deposit to stay frozen, got %v", err)
+			}
+			credit, _ := loaded.GetAccount("AL10003")
+			if cycles := credit.(*CreditLineAccount).Cycles; len(cycles) != 1 {
+				t.Errorf("Expected the credit line's cycle to be restored, got %v", cycles)
+			}
+			expectReconciled(t, loaded)
+		})
+	}
+}
+
+func TestLoadEmptyRepository(t *testing.T) {
+	bank, err := LoadBank("Fresh", NewFileRepository(filepath.Join(t.TempDir(), "missing.json")))
+	if err != nil {
+		t.Fatalf("LoadBank failed: %v", err)
+	}
+	if len(bank.Customers) != 0 || bank.NextAccountNumber != 10000 || bank.NextCustomerID != 1000 {
+		t.Errorf("Expected a new bank, got %d customers and counters %d/%d", len(bank.Customers), bank.NextCustomerID, bank.NextAccountNumber)
+	}
+}
+
+func TestLoadRejectsInconsistentState(t *testing.T) {
+	bank, _, _ := newBusyBank(t)
+	repo := NewMemoryRepository()
+	if err := bank.Save(repo); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	snapshot, _ := repo.Load()
+	snapshot.Accounts[0].Balance = usd("1000000")
+	repo.Save(snapshot)
+	if _, err := LoadBank(bank.Name, repo); err == nil {
+		t.Error("Expected a balance that doesn't match the transactions to be rejected")
+	}
+
+	path := filepath.Join(t.TempDir(), "bank.json")
+	os.WriteFile(path, []byte("{not json"), 0o644)
+	if _, err := LoadBank(bank.Name, NewFileRepository(path)); err == nil {
+		t.Error("Expected a corrupt file to be rejected")
+	}
+}
+
+func TestSaveDuringActivity(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+	repo := NewMemoryRepository()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			bank.Transfer(checking.AccountNumber, savings.AccountNumber, usd("1"))
+			bank.OpenCheckingAccount(customer.ID, usd("1"))
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		if err := bank.Save(repo); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		// Every saved snapshot must load, which means it reconciles
+		if _, err := LoadBank(bank.Name, repo); err != nil {
+			t.Fatalf("Snapshot %d is inconsistent: %v", i, err)
+		}
+	}
+	<-done
+}
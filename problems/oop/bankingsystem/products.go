@@ -0,0 +1,343 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+var (
+	// ErrEarlyWithdrawal is returned when money is taken out of a term deposit
+	// that doesn't allow withdrawals before maturity
+	ErrEarlyWithdrawal = errors.New("term deposit has not matured")
+	// ErrDepositNotAllowed is returned when paying into an account that only
+	// takes money when it is opened
+	ErrDepositNotAllowed = errors.New("account does not accept deposits")
+)
+
+// now returns the time on the bank's clock, or the wall clock for an account
+// outside a bank
+func (a *BaseAccount) now() time.Time {
+	if a.ledger != nil {
+		return a.ledger.now()
+	}
+	return time.Now()
+}
+
+// OverdraftAccount is a checking account that may go below zero down to its
+// overdraft limit
+type OverdraftAccount struct {
+	CheckingAccount
+	OverdraftLimit        money.Money
+	OverdraftFee          money.Money // Charged on every withdrawal that leaves the balance negative
+	OverdraftInterestRate money.Rate  // Monthly interest charged on a negative balance
+}
+
+// GetAccountType returns the account type
+func (o *OverdraftAccount) GetAccountType() string {
+	return "Overdraft Checking"
+}
+
+// Withdraw removes funds, using the overdraft if the balance runs out
+func (o *OverdraftAccount) Withdraw(amount money.Money) error {
+	return withdraw(o, amount)
+}
+
+func (o *OverdraftAccount) checkWithdrawal(amount money.Money) (money.Money, money.Money, error) {
+	if !amount.IsPositive() {
+		return money.Money{}, money.Money{}, fmt.Errorf("%w: withdrawal amount must be positive", ErrInvalidArgument)
+	}
+
+	fee := o.TransactionFee
+	remaining, err := o.Balance.Sub(amount)
+	if err == nil {
+		remaining, err = remaining.Sub(fee)
+	}
+	if err == nil && remaining.IsNegative() {
+		fee, err = fee.Add(o.OverdraftFee)
+		if err == nil {
+			remaining, err = remaining.Sub(o.OverdraftFee)
+		}
+	}
+	if err != nil {
+		return money.Money{}, money.Money{}, err
+	}
+
+	if headroom, err := remaining.Add(o.OverdraftLimit); err != nil || headroom.IsNegative() {
+		return money.Money{}, money.Money{}, fmt.Errorf("%w: overdraft limit of %v exceeded", ErrInsufficientFunds, o.OverdraftLimit)
+	}
+	return fee, remaining, nil
+}
+
+// ApplyMonthlyInterest charges interest on a negative balance. The charge may
+// take the balance past the overdraft limit.
+func (o *OverdraftAccount) ApplyMonthlyInterest() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.Balance.IsNegative() {
+		return nil
+	}
+	interest, err := o.Balance.Neg().MulRate(o.OverdraftInterestRate)
+	if err != nil || !interest.IsPositive() {
+		return err
+	}
+	return o.charge(TransactionInterestCharge, interest, "Overdraft interest")
+}
+
+// CreditCycle summarises one statement cycle of a credit line
+type CreditCycle struct {
+	Start           time.Time
+	End             time.Time
+	Payments        money.Money
+	LateFee         money.Money
+	InterestCharged money.Money
+	ClosingBalance  money.Money
+	MinimumPayment  money.Money // Due by the end of the next cycle
+}
+
+// CreditLineAccount lets a customer borrow up to a credit limit. Its balance
+// is negative while money is owed; drawing money is a withdrawal and paying it
+// back is a deposit. Each month-end closes a statement cycle.
+type CreditLineAccount struct {
+	BaseAccount
+	CreditLimit         money.Money
+	InterestRate        money.Rate  // Monthly interest charged on the balance owed
+	MinimumPaymentRate  money.Rate  // Share of the balance owed that is due each cycle
+	MinimumPaymentFloor money.Money // Smallest minimum payment, unless less is owed
+	LateFee             money.Money // Charged when a cycle's minimum payment is missed
+	MinimumPaymentDue   money.Money
+	Cycles              []CreditCycle
+	cycleStart          time.Time
+	cycleTransactions   int // Number of the account's transactions when the cycle started
+}
+
+// GetAccountType returns the account type
+func (c *CreditLineAccount) GetAccountType() string {
+	return "Credit Line"
+}
+
+// Owed returns how much the customer owes, or zero if the balance isn't negative
+func (c *CreditLineAccount) Owed() money.Money {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.owed()
+}
+
+func (c *CreditLineAccount) owed() money.Money {
+	if c.Balance.IsNegative() {
+		return c.Balance.Neg()
+	}
+	return money.Money{}
+}
+
+// AvailableCredit returns how much more can be drawn
+func (c *CreditLineAccount) AvailableCredit() money.Money {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	available, _ := c.CreditLimit.Add(c.Balance)
+	return available
+}
+
+// Withdraw draws money from the credit line
+func (c *CreditLineAccount) Withdraw(amount money.Money) error {
+	return withdraw(c, amount)
+}
+
+func (c *CreditLineAccount) checkWithdrawal(amount money.Money) (money.Money, money.Money, error) {
+	if !amount.IsPositive() {
+		return money.Money{}, money.Money{}, fmt.Errorf("%w: withdrawal amount must be positive", ErrInvalidArgument)
+	}
+
+	remaining, err := c.Balance.Sub(amount)
+	if err != nil {
+		return money.Money{}, money.Money{}, err
+	}
+	if headroom, err := remaining.Add(c.CreditLimit); err != nil || headroom.IsNegative() {
+		return money.Money{}, money.Money{}, fmt.Errorf("%w: credit limit of %v exceeded", ErrInsufficientFunds, c.CreditLimit)
+	}
+	return money.Money{}, remaining, nil
+}
+
+func (c *CreditLineAccount) applyWithdrawal(balanceAfter money.Money) {
+	c.Balance = balanceAfter
+}
+
+// ApplyMonthlyInterest closes the current statement cycle: it charges the
+// late fee if the last minimum payment wasn't met, charges interest on the
+// balance owed and sets the minimum payment due by the end of the next cycle
+func (c *CreditLineAccount) ApplyMonthlyInterest() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cycle := CreditCycle{Start: c.cycleStart, End: c.now()}
+
+	var err error
+	if cycle.Payments, err = c.paymentsThisCycle(); err != nil {
+		return err
+	}
+
+	if missed, _ := cycle.Payments.Compare(c.MinimumPaymentDue); missed < 0 && c.LateFee.IsPositive() {
+		if err := c.charge(TransactionFeeCharge, c.LateFee, "Late payment fee"); err != nil {
+			return err
+		}
+		cycle.LateFee = c.LateFee
+	}
+
+	if cycle.InterestCharged, err = c.owed().MulRate(c.InterestRate); err != nil {
+		return err
+	}
+	if cycle.InterestCharged.IsPositive() {
+		if err := c.charge(TransactionInterestCharge, cycle.InterestCharged, "Credit line interest"); err != nil {
+			return err
+		}
+	}
+
+	// The minimum payment is a share of the balance owed, at least the floor
+	// but never more than is owed
+	owed := c.owed()
+	minimum, err := owed.MulRate(c.MinimumPaymentRate)
+	if err != nil {
+		return err
+	}
+	if below, _ := minimum.Compare(c.MinimumPaymentFloor); below < 0 {
+		minimum = c.MinimumPaymentFloor
+	}
+	if above, _ := minimum.Compare(owed); above > 0 {
+		minimum = owed
+	}
+
+	cycle.ClosingBalance = c.Balance
+	cycle.MinimumPayment = minimum
+	c.MinimumPaymentDue = minimum
+	c.Cycles = append(c.Cycles, cycle)
+	c.cycleStart = cycle.End
+	if c.ledger != nil {
+		c.cycleTransactions = len(c.ledger.Transactions(c.AccountNumber))
+	}
+	return nil
+}
+
+// paymentsThisCycle totals the deposits and incoming transfers recorded since
+// the cycle started
+func (c *CreditLineAccount) paymentsThisCycle() (money.Money, error) {
+	total := money.Money{}
+	if c.ledger == nil {
+		return total, nil
+	}
+
+	for _, tx := range c.ledger.Transactions(c.AccountNumber)[c.cycleTransactions:] {
+		if tx.Type != TransactionDeposit && tx.Type != TransactionTransferIn {
+			continue
+		}
+		var err error
+		if total, err = total.Add(tx.Amount); err != nil {
+			return money.Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// TermDepositAccount holds a fixed sum for a number of months. It pays
+// monthly interest until it matures, accepts no further deposits and either
+// forbids withdrawals before maturity or keeps a penalty from them.
+type TermDepositAccount struct {
+	BaseAccount
+	InterestRate           money.Rate // Monthly interest paid until maturity
+	TermMonths             int
+	MaturityDate           time.Time
+	AllowEarlyWithdrawal   bool
+	EarlyWithdrawalPenalty money.Rate // Share of an early withdrawal charged as a fee
+	InterestPayments       int
+}
+
+// GetAccountType returns the account type
+func (t *TermDepositAccount) GetAccountType() string {
+	return "Term Deposit"
+}
+
+// IsMatured reports whether the term has ended
+func (t *TermDepositAccount) IsMatured() bool {
+	return !t.now().Before(t.MaturityDate)
+}
+
+// Deposit always fails: a term deposit is funded when it is opened
+func (t *TermDepositAccount) Deposit(amount money.Money) error {
+	return t.checkDeposit(amount)
+}
+
+func (t *TermDepositAccount) checkDeposit(amount money.Money) error {
+	return fmt.Errorf("%w: term deposits are funded when opened", ErrDepositNotAllowed)
+}
+
+// Withdraw removes funds, charging the early withdrawal penalty before maturity
+func (t *TermDepositAccount) Withdraw(amount money.Money) error {
+	return withdraw(t, amount)
+}
+
+func (t *TermDepositAccount) checkWithdrawal(amount money.Money) (money.Money, money.Money, error) {
+	if !amount.IsPositive() {
+		return money.Money{}, money.Money{}, fmt.Errorf("%w: withdrawal amount must be positive", ErrInvalidArgument)
+	}
+
+	penalty := money.Money{}
+	if !t.IsMatured() {
+		if !t.AllowEarlyWithdrawal {
+			return money.Money{}, money.Money{}, fmt.Errorf("%w: it matures on %s", ErrEarlyWithdrawal, t.MaturityDate.Format("2006-01-02"))
+		}
+
+		var err error
+		if penalty, err = amount.MulRate(t.EarlyWithdrawalPenalty); err != nil {
+			return money.Money{}, money.Money{}, err
+		}
+	}
+
+	remaining, err := subtractWithdrawal(t.Balance, amount, penalty, fmt.Errorf("%w for withdrawal and penalty", ErrInsufficientFunds))
+	if err != nil {
+		return money.Money{}, money.Money{}, err
+	}
+	return penalty, remaining, nil
+}
+
+func (t *TermDepositAccount) applyWithdrawal(balanceAfter money.Money) {
+	t.Balance = balanceAfter
+}
+
+// ApplyMonthlyInterest pays a month's interest for each month of the term;
+// once TermMonths payments have been made it does nothing
+func (t *TermDepositAccount) ApplyMonthlyInterest() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.InterestPayments >= t.TermMonths {
+		return nil
+	}
+
+	interest, err := t.Balance.MulRate(t.InterestRate)
+	if err != nil {
+		return err
+	}
+	balance, err := t.Balance.Add(interest)
+	if err != nil {
+		return err
+	}
+
+	t.Balance = balance
+	t.InterestPayments++
+	if interest.IsPositive() {
+		t.record(Transaction{Type: TransactionInterest, Amount: interest, Description: "Term deposit interest"})
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// expectBalance fails the test if an account doesn't hold the expected amount
+func expectBalance(t *testing.T, account Account, expected string) {
+	t.Helper()
+	if !account.GetBalance().Equal(usd(expected)) {
+		t.Errorf("Expected %s to hold %s, got %v", account.GetAccountNumber(), expected, account.GetBalance())
+	}
+}
+
+// expectReconciled fails the test if the ledger doesn't match the accounts
+func expectReconciled(t *testing.T, bank *Bank) {
+	t.Helper()
+	if discrepancies, err := bank.Reconcile(); err != nil || len(discrepancies) != 0 || !bank.Ledger.IsBalanced() {
+		t.Errorf("Expected the ledger to reconcile, got %v (%v)", discrepancies, err)
+	}
+}
+
+func TestOverdraftAccount(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	account, err := bank.OpenOverdraftAccount(customer.ID, usd("100"), usd("500"))
+	if err != nil {
+		t.Fatalf("OpenOverdraftAccount failed: %v", err)
+	}
+
+	// $200 plus the $5 transaction fee overdraws the account, adding the $25 overdraft fee
+	if err := account.Withdraw(usd("200")); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	expectBalance(t, account, "-130")
+
+	if err := account.Withdraw(usd("400")); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Expected the overdraft limit to be enforced, got %v", err)
+	}
+	expectBalance(t, account, "-130")
+
+	// 1.5% of $130
+	if err := bank.ApplyMonthlyInterest(); err != nil {
+		t.Fatalf("ApplyMonthlyInterest failed: %v", err)
+	}
+	expectBalance(t, account, "-131.95")
+
+	account.Deposit(usd("200"))
+	bank.ApplyMonthlyInterest()
+	expectBalance(t, account, "68.05")
+
+	if fees, _ := bank.Ledger.Balance(LedgerFeeIncome); !fees.Equal(usd("30")) {
+		t.Errorf("Expected $30 of fees, got %v", fees)
+	}
+	if interest, _ := bank.Ledger.Balance(LedgerInterestIncome); !interest.Equal(usd("1.95")) {
+		t.Errorf("Expected $1.95 of interest income, got %v", interest)
+	}
+	expectReconciled(t, bank)
+
+	if _, err := bank.OpenOverdraftAccount(customer.ID, usd("0"), usd("-1")); err == nil {
+		t.Error("Expected a negative overdraft limit to be rejected")
+	}
+}
+
+func TestCreditLineCycles(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	credit, err := bank.OpenCreditLine(customer.ID, usd("1000"))
+	if err != nil {
+		t.Fatalf("OpenCreditLine failed: %v", err)
+	}
+
+	if err := credit.Withdraw(usd("400")); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	if err := credit.Withdraw(usd("700")); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Expected the credit limit to be enforced, got %v", err)
+	}
+	if !credit.AvailableCredit().Equal(usd("600")) || !credit.Owed().Equal(usd("400")) {
+		t.Errorf("Expected $600 available and $400 owed, got %v and %v", credit.AvailableCredit(), credit.Owed())
+	}
+
+	// Each step pays something, then closes the cycle a month later
+	steps := []struct {
+		payment        string
+		lateFee        string
+		interest       string
+		closingBalance string
+		minimumPayment string
+	}{
+		// 1.5% of $400; 3% of $406 is below the $25 floor
+		{"0", "0", "6", "-406", "25"},
+		// $10 misses the $25 minimum; interest on $431 rounds 6.465 to even
+		{"10", "35", "6.46", "-437.46", "25"},
+		{"100", "0", "5.06", "-342.52", "25"},
+		// Paying in full leaves nothing owed and nothing due
+		{"342.52", "0", "0", "0", "0"},
+	}
+
+	for i, step := range steps {
+		if payment := usd(step.payment); payment.IsPositive() {
+			if err := credit.Deposit(payment); err != nil {
+				t.Fatalf("Cycle %d: payment failed: %v", i+1, err)
+			}
+		}
+		clock.Advance(30 * 24 * time.Hour)
+		if err := bank.ApplyMonthlyInterest(); err != nil {
+			t.Fatalf("Cycle %d: ApplyMonthlyInterest failed: %v", i+1, err)
+		}
+
+		cycle := credit.Cycles[len(credit.Cycles)-1]
+		if !cycle.Payments.Equal(usd(step.payment)) || !cycle.LateFee.Equal(usd(step.lateFee)) ||
+			!cycle.InterestCharged.Equal(usd(step.interest)) || !cycle.ClosingBalance.Equal(usd(step.closingBalance)) ||
+			!cycle.MinimumPayment.Equal(usd(step.minimumPayment)) {
+			t.Errorf("Cycle %d: unexpected %+v", i+1, cycle)
+		}
+	}
+
+	if len(credit.Cycles) != len(steps) || !credit.MinimumPaymentDue.IsZero() {
+		t.Errorf("Expected %d cycles and nothing due, got %d and %v", len(steps), len(credit.Cycles), credit.MinimumPaymentDue)
+	}
+	if !credit.Cycles[1].Start.Equal(credit.Cycles[0].End) {
+		t.Error("Expected each cycle to start where the last one ended")
+	}
+	expectReconciled(t, bank)
+}
+
+func TestCreditLinePaymentsByTransfer(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	bank.CheckingFeeRate = money.Money{}
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	credit, _ := bank.OpenCreditLine(customer.ID, usd("5000"))
+
+	credit.Withdraw(usd("2000"))
+	bank.ApplyMonthlyInterest()
+
+	// 3% of $2030 is due; a transfer counts as a payment
+	if !credit.MinimumPaymentDue.Equal(usd("60.90")) {
+		t.Fatalf("Expected $60.90 due, got %v", credit.MinimumPaymentDue)
+	}
+	if err := bank.Transfer(checking.AccountNumber, credit.AccountNumber, usd("60.90")); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	clock.Advance(30 * 24 * time.Hour)
+	bank.ApplyMonthlyInterest()
+
+	if cycle := credit.Cycles[1]; !cycle.LateFee.IsZero() || !cycle.Payments.Equal(usd("60.90")) {
+		t.Errorf("Expected the transfer to meet the minimum payment, got %+v", cycle)
+	}
+}
+
+func TestTermDeposit(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	bank.CheckingFeeRate = money.Money{}
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+	term, err := bank.OpenTermDeposit(customer.ID, usd("1000"), 3)
+	if err != nil {
+		t.Fatalf("OpenTermDeposit failed: %v", err)
+	}
+	if !term.MaturityDate.Equal(time.Date(2024, time.June, 1, 9, 0, 0, 0, time.UTC)) || term.IsMatured() {
+		t.Errorf("Expected an unmatured deposit maturing on 1 June, got %v", term.MaturityDate)
+	}
+
+	if err := term.Deposit(usd("10")); !errors.Is(err, ErrDepositNotAllowed) {
+		t.Errorf("Expected deposits to be refused, got %v", err)
+	}
+	if err := bank.Transfer(checking.AccountNumber, term.AccountNumber, usd("10")); !errors.Is(err, ErrDepositNotAllowed) {
+		t.Errorf("Expected transfers in to be refused, got %v", err)
+	}
+	expectBalance(t, checking, "100")
+
+	// Early withdrawals pay a 2% penalty
+	if err := term.Withdraw(usd("100")); err != nil {
+		t.Fatalf("Early withdrawal failed: %v", err)
+	}
+	expectBalance(t, term, "898")
+
+	// Interest is paid for the three months of the term only
+	for month := 0; month < 4; month++ {
+		clock.Advance(31 * 24 * time.Hour)
+		bank.ApplyMonthlyInterest()
+	}
+	if term.InterestPayments != 3 {
+		t.Errorf("Expected 3 interest payments, got %d", term.InterestPayments)
+	}
+	expectBalance(t, term, "908.82")
+
+	// After maturity the whole balance can be withdrawn without a penalty
+	if !term.IsMatured() {
+		t.Fatal("Expected the deposit to have matured")
+	}
+	if err := term.Withdraw(usd("908.82")); err != nil {
+		t.Errorf("Withdrawal after maturity failed: %v", err)
+	}
+	expectReconciled(t, bank)
+
+	if _, err := bank.OpenTermDeposit(customer.ID, usd("0"), 3); err == nil {
+		t.Error("Expected an empty term deposit to be rejected")
+	}
+	if _, err := bank.OpenTermDeposit(customer.ID, usd("100"), 0); err == nil {
+		t.Error("Expected a zero-month term to be rejected")
+	}
+}
+
+func TestTermDepositWithoutEarlyWithdrawal(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	bank.AllowEarlyWithdrawal = false
+	term, _ := bank.OpenTermDeposit(customer.ID, usd("1000"), 12)
+
+	if err := term.Withdraw(usd("1")); !errors.Is(err, ErrEarlyWithdrawal) {
+		t.Errorf("Expected early withdrawal to be refused, got %v", err)
+	}
+	expectBalance(t, term, "1000")
+}
+
+func TestServerOpensProducts(t *testing.T) {
+	client, bank, _ := newAPIClient(t)
+	customer, _ := bank.CreateCustomer("Jane", "Smith", "456 Go Lane")
+
+	var account accountView
+	resp := client.do("POST", "/accounts", `{"customerId":"`+customer.ID+`","type":"creditline","limit":"2500"}`, nil, &account)
+	client.expectStatus(resp, http.StatusCreated)
+	if account.Type != "Credit Line" {
+		t.Errorf("Unexpected account: %+v", account)
+	}
+
+	resp = client.do("POST", "/accounts", `{"customerId":"`+customer.ID+`","type":"termdeposit","initialDeposit":"500","termMonths":6}`, nil, &account)
+	client.expectStatus(resp, http.StatusCreated)
+	resp = client.do("POST", "/accounts/"+account.AccountNumber+"/deposits", `{"amount":"5"}`, nil, nil)
+	client.expectStatus(resp, http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,421 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// ErrTransactionDenied is returned when a rule denies a money movement
+var ErrTransactionDenied = errors.New("transaction denied")
+
+// MovementType is a kind of customer money movement screened by the rules
+type MovementType string
+
+const (
+	MovementDeposit MovementType = "deposit"
+	// MovementWithdrawal covers outgoing transfers too, since they also take
+	// money out of the account
+	MovementWithdrawal MovementType = "withdrawal"
+	MovementTransfer   MovementType = "transfer"
+)
+
+// transactionTypes maps each movement to the ledger transaction it records
+// on the account being screened
+var transactionTypes = map[MovementType]TransactionType{
+	MovementDeposit:    TransactionDeposit,
+	MovementWithdrawal: TransactionWithdrawal,
+	MovementTransfer:   TransactionTransferOut,
+}
+
+// RuleType selects what a rule checks
+type RuleType string
+
+const (
+	// RuleDailyLimit triggers when the day's movements, including this one,
+	// add up to more than Limit
+	RuleDailyLimit RuleType = "daily-limit"
+	// RuleVelocity triggers when this movement would be more than MaxCount
+	// within Window
+	RuleVelocity RuleType = "velocity"
+	// RuleLargeTransaction triggers on a single movement of at least Limit
+	RuleLargeTransaction RuleType = "large-transaction"
+	// RuleBlockedCounterparty triggers when either account of a movement is
+	// in Counterparties
+	RuleBlockedCounterparty RuleType = "blocked-counterparty"
+)
+
+// Outcome is the decision on a money movement
+type Outcome string
+
+const (
+	OutcomeAllow Outcome = "allow"
+	// OutcomeReview lets the movement through and flags it for a person to check
+	OutcomeReview Outcome = "review"
+	OutcomeDeny   Outcome = "deny"
+)
+
+// Rule is one check on money movements. Which fields it uses depends on its type.
+type Rule struct {
+	Name           string         `json:"name"`
+	Type           RuleType       `json:"type"`
+	Action         Outcome        `json:"action"`              // review or deny
+	Movements      []MovementType `json:"movements,omitempty"` // Movements the rule applies to, all if empty
+	Limit          money.Money    `json:"limit,omitzero"`
+	MaxCount       int            `json:"maxCount,omitzero"`
+	Window         string         `json:"window,omitempty"` // A duration such as "1h"
+	Counterparties []string       `json:"counterparties,omitempty"`
+	window         time.Duration
+}
+
+// Movement is a money movement being screened
+type Movement struct {
+	Type          MovementType
+	AccountNumber string // The account money leaves, or enters for a deposit
+	Counterparty  string // The other account of a transfer
+	Amount        money.Money
+	Timestamp     time.Time
+}
+
+// Decision records the outcome of screening a movement
+type Decision struct {
+	Timestamp     time.Time    `json:"timestamp"`
+	AccountNumber string       `json:"accountNumber"`
+	Movement      MovementType `json:"movement"`
+	Amount        money.Money  `json:"amount"`
+	Counterparty  string       `json:"counterparty,omitempty"`
+	Outcome       Outcome      `json:"outcome"`
+	Rule          string       `json:"rule,omitempty"` // The rule that decided the outcome
+	Reason        string       `json:"reason"`
+}
+
+// RuleEngine screens money movements against a set of rules and keeps every
+// decision it makes. It is safe for concurrent use.
+type RuleEngine struct {
+	rules     []Rule
+	decisions []Decision
+	convert   func(amount money.Money, currency string) (money.Money, error) // Converts limits into a movement's currency
+	mu        sync.Mutex
+}
+
+// NewRuleEngine creates an engine with the given rules
+func NewRuleEngine(rules ...Rule) (*RuleEngine, error) {
+	engine := &RuleEngine{}
+	if err := engine.SetRules(rules); err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
+
+// ParseRules reads rules from JSON of the form {"rules": [...]}
+func ParseRules(data []byte) ([]Rule, error) {
+	var config struct {
+		Rules []Rule `json:"rules"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+	return config.Rules, nil
+}
+
+// LoadRules reads rules from a JSON file
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(data)
+}
+
+// SetRules validates and replaces the engine's rules
+func (e *RuleEngine) SetRules(rules []Rule) error {
+	validated := make([]Rule, len(rules))
+	names := make(map[string]bool)
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("%w: duplicate rule %q", ErrInvalidArgument, rule.Name)
+		}
+		names[rule.Name] = true
+		validated[i] = rule
+		validated[i].Movements = append([]MovementType(nil), rule.Movements...)
+		validated[i].Counterparties = append([]string(nil), rule.Counterparties...)
+		if rule.Window != "" {
+			validated[i].window, _ = time.ParseDuration(rule.Window)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules = validated
+	return nil
+}
+
+// Rules returns the engine's rules
+func (e *RuleEngine) Rules() []Rule {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Rule{}, e.rules...)
+}
+
+// Decisions returns the decisions made about an account, oldest first
+func (e *RuleEngine) Decisions(accountNumber string) []Decision {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make([]Decision, 0)
+	for _, decision := range e.decisions {
+		if decision.AccountNumber == accountNumber {
+			result = append(result, decision)
+		}
+	}
+	return result
+}
+
+// validate checks that a rule has the settings its type needs
+func (r Rule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidArgument)
+	}
+	if r.Action != OutcomeReview && r.Action != OutcomeDeny {
+		return fmt.Errorf("%w: action must be %s or %s", ErrInvalidArgument, OutcomeReview, OutcomeDeny)
+	}
+	for _, movement := range r.Movements {
+		if _, ok := transactionTypes[movement]; !ok {
+			return fmt.Errorf("%w: unknown movement %q", ErrInvalidArgument, movement)
+		}
+	}
+
+	switch r.Type {
+	case RuleDailyLimit, RuleLargeTransaction:
+		if !r.Limit.IsPositive() {
+			return fmt.Errorf("%w: limit must be positive", ErrInvalidArgument)
+		}
+	case RuleVelocity:
+		window, err := time.ParseDuration(r.Window)
+		if err != nil || window <= 0 {
+			return fmt.Errorf("%w: window must be a positive duration such as \"1h\"", ErrInvalidArgument)
+		}
+		if r.MaxCount <= 0 {
+			return fmt.Errorf("%w: maxCount must be positive", ErrInvalidArgument)
+		}
+	case RuleBlockedCounterparty:
+		if len(r.Counterparties) == 0 {
+			return fmt.Errorf("%w: counterparties are required", ErrInvalidArgument)
+		}
+	default:
+		return fmt.Errorf("%w: unknown rule type %q", ErrInvalidArgument, r.Type)
+	}
+	return nil
+}
+
+// appliesTo reports whether the rule screens a kind of movement. Withdrawal
+// rules also screen transfers, so moving money to another account can't get
+// around them.
+func (r Rule) appliesTo(movement MovementType) bool {
+	if len(r.Movements) == 0 {
+		return true
+	}
+	for _, m := range r.Movements {
+		if m == movement || (m == MovementWithdrawal && movement == MovementTransfer) {
+			return true
+		}
+	}
+	return false
+}
+
+// check reports whether the rule triggers on a movement, and why. history
+// is the account's transactions so far. The rule's limit must already be in
+// the movement's currency.
+func (r Rule) check(m Movement, history []Transaction) (bool, string, error) {
+	switch r.Type {
+	case RuleLargeTransaction:
+		cmp, err := m.Amount.Compare(r.Limit)
+		if err != nil || cmp < 0 {
+			return false, "", err
+		}
+		return true, fmt.Sprintf("%v is at least the large transaction threshold of %v", m.Amount, r.Limit), nil
+
+	case RuleDailyLimit:
+		day := startOfDay(m.Timestamp)
+		total := m.Amount
+		for _, tx := range history {
+			if !tx.Timestamp.Before(day) && r.counts(tx) {
+				var err error
+				if total, err = total.Add(tx.Amount); err != nil {
+					return false, "", err
+				}
+			}
+		}
+		cmp, err := total.Compare(r.Limit)
+		if err != nil || cmp <= 0 {
+			return false, "", err
+		}
+		return true, fmt.Sprintf("%v today would exceed the daily limit of %v", total, r.Limit), nil
+
+	case RuleVelocity:
+		since := m.Timestamp.Add(-r.window)
+		count := 1
+		for _, tx := range history {
+			if tx.Timestamp.After(since) && r.counts(tx) {
+				count++
+			}
+		}
+		if count <= r.MaxCount {
+			return false, "", nil
+		}
+		return true, fmt.Sprintf("%d movements within %s exceed the limit of %d", count, r.Window, r.MaxCount), nil
+
+	case RuleBlockedCounterparty:
+		for _, blocked := range r.Counterparties {
+			if blocked == m.AccountNumber || blocked == m.Counterparty {
+				return true, fmt.Sprintf("account %s is blocked", blocked), nil
+			}
+		}
+	}
+	return false, "", nil
+}
+
+// counts reports whether a past transaction is a movement the rule applies to
+func (r Rule) counts(tx Transaction) bool {
+	for movement, txType := range transactionTypes {
+		if tx.Type == txType && r.appliesTo(movement) {
+			return true
+		}
+	}
+	return false
+}
+
+// limitIn converts a rule's limit into a movement's currency at the bank's rates
+func (e *RuleEngine) limitIn(limit money.Money, currency string) (money.Money, error) {
+	if limit.IsZero() || strings.EqualFold(limit.Currency(), currency) {
+		return limit, nil
+	}
+	if e.convert == nil {
+		return money.Money{}, fmt.Errorf("%w for %s/%s: the engine has no exchange rates", ErrRateNotFound, limit.Currency(), currency)
+	}
+	return e.convert(limit, currency)
+}
+
+// historySince returns how far back from now the rules look at an account's
+// transactions, and false if none of them look at past transactions
+func (e *RuleEngine) historySince(now time.Time) (time.Time, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var since time.Time
+	found := false
+	for _, rule := range e.rules {
+		var start time.Time
+		switch rule.Type {
+		case RuleDailyLimit:
+			start = startOfDay(now)
+		case RuleVelocity:
+			start = now.Add(-rule.window)
+		default:
+			continue
+		}
+		if !found || start.Before(since) {
+			since, found = start, true
+		}
+	}
+	return since, found
+}
+
+// evaluate screens a movement against every rule, records the decision and
+// returns it. A deny from any rule wins over a review. history is the
+// account's transactions so far.
+func (e *RuleEngine) evaluate(m Movement, history []Transaction) (Decision, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	decision := Decision{
+		Timestamp:     m.Timestamp,
+		AccountNumber: m.AccountNumber,
+		Movement:      m.Type,
+		Amount:        m.Amount,
+		Counterparty:  m.Counterparty,
+		Outcome:       OutcomeAllow,
+		Reason:        "no rule triggered",
+	}
+
+	reasons := make([]string, 0)
+	for _, rule := range e.rules {
+		if !rule.appliesTo(m.Type) {
+			continue
+		}
+		limit, err := e.limitIn(rule.Limit, m.Amount.Currency())
+		if err != nil {
+			return Decision{}, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		rule.Limit = limit
+		triggered, reason, err := rule.check(m, history)
+		if err != nil {
+			return Decision{}, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		if !triggered {
+			continue
+		}
+
+		reasons = append(reasons, rule.Name+": "+reason)
+		if decision.Outcome == OutcomeAllow || (rule.Action == OutcomeDeny && decision.Outcome == OutcomeReview) {
+			decision.Outcome = rule.Action
+			decision.Rule = rule.Name
+		}
+	}
+	if len(reasons) > 0 {
+		decision.Reason = strings.Join(reasons, "; ")
+	}
+
+	e.decisions = append(e.decisions, decision)
+	return decision, nil
+}
+
+// screen runs a movement on the account past the bank's rules, failing if
+// they deny it. The caller must hold the account's lock, so the history the
+// rules see can't change before the movement is applied.
+func (a *BaseAccount) screen(movementType MovementType, amount money.Money, counterparty string) error {
+	if a.rules == nil || a.ledger == nil {
+		return nil
+	}
+
+	now := a.now()
+	var history []Transaction
+	if since, ok := a.rules.historySince(now); ok {
+		history = a.ledger.transactionsSince(a.AccountNumber, since)
+	}
+	decision, err := a.rules.evaluate(Movement{
+		Type:          movementType,
+		AccountNumber: a.AccountNumber,
+		Counterparty:  counterparty,
+		Amount:        amount,
+		Timestamp:     now,
+	}, history)
+	if err != nil {
+		return err
+	}
+	if decision.Outcome == OutcomeDeny {
+		return fmt.Errorf("%w by rule %s: %s", ErrTransactionDenied, decision.Rule, decision.Reason)
+	}
+	return nil
+}
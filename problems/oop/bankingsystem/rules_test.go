@@ -0,0 +1,251 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// setRules configures the bank's rules from JSON
+func setRules(t *testing.T, bank *Bank, config string) {
+	t.Helper()
+	rules, err := ParseRules([]byte(config))
+	if err != nil {
+		t.Fatalf("ParseRules failed: %v", err)
+	}
+	if err := bank.Rules.SetRules(rules); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules([]byte(`{"rules": [
+		{"name": "daily-cash", "type": "daily-limit", "action": "deny", "movements": ["withdrawal", "transfer"],
+		 "limit": {"amount": "2000", "currency": "USD"}},
+		{"name": "rapid-fire", "type": "velocity", "action": "review", "maxCount": 5, "window": "10m"},
+		{"name": "large", "type": "large-transaction", "action": "review", "limit": {"amount": "10000", "currency": "USD"}},
+		{"name": "sanctions", "type": "blocked-counterparty", "action": "deny", "counterparties": ["AC10042"]}
+	]}`))
+	if err != nil {
+		t.Fatalf("ParseRules failed: %v", err)
+	}
+	if _, err := NewRuleEngine(rules...); err != nil || len(rules) != 4 {
+		t.Fatalf("Expected 4 valid rules, got %d (%v)", len(rules), err)
+	}
+
+	invalid := []string{
+		`{"name": "x", "type": "magic", "action": "deny"}`,
+		`{"name": "x", "type": "daily-limit", "action": "deny"}`,
+		`{"name": "x", "type": "velocity", "action": "review", "maxCount": 5, "window": "soon"}`,
+		`{"name": "x", "type": "velocity", "action": "review", "window": "1h"}`,
+		`{"name": "x", "type": "blocked-counterparty", "action": "deny"}`,
+		`{"name": "x", "type": "blocked-counterparty", "action": "allow", "counterparties": ["A"]}`,
+		`{"name": "x", "type": "blocked-counterparty", "action": "deny", "counterparties": ["A"], "movements": ["refund"]}`,
+		`{"type": "blocked-counterparty", "action": "deny", "counterparties": ["A"]}`,
+	}
+	for _, rule := range invalid {
+		rules, err := ParseRules([]byte(`{"rules": [` + rule + `]}`))
+		if err == nil {
+			_, err = NewRuleEngine(rules...)
+		}
+		if err == nil {
+			t.Errorf("Expected %s to be rejected", rule)
+		}
+	}
+
+	duplicate := `{"name": "x", "type": "blocked-counterparty", "action": "deny", "counterparties": ["A"]}`
+	rules, _ = ParseRules([]byte(`{"rules": [` + duplicate + `,` + duplicate + `]}`))
+	if _, err := NewRuleEngine(rules...); err == nil {
+		t.Error("Expected duplicate rule names to be rejected")
+	}
+	if _, err := ParseRules([]byte(`{"rules": [{"name": "x", "colour": "red"}]}`)); err == nil {
+		t.Error("Expected unknown fields to be rejected")
+	}
+}
+
+func TestDailyLimitRule(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("5000"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("100"))
+	setRules(t, bank, `{"rules": [{"name": "daily-cash", "type": "daily-limit", "action": "deny",
+		"movements": ["withdrawal", "transfer"], "limit": {"amount": "500", "currency": "USD"}}]}`)
+
+	if err := checking.Withdraw(usd("300")); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	checking.Deposit(usd("1000")) // Deposits don't count
+	if err := bank.Transfer(checking.AccountNumber, savings.AccountNumber, usd("200")); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	err := checking.Withdraw(usd("1"))
+	if !errors.Is(err, ErrTransactionDenied) || !strings.Contains(err.Error(), "daily-cash") {
+		t.Errorf("Expected the daily limit to deny the withdrawal, got %v", err)
+	}
+	expectBalance(t, checking, "5490")
+
+	clock.Advance(24 * time.Hour)
+	if err := checking.Withdraw(usd("400")); err != nil {
+		t.Errorf("Expected a new day to have a new limit, got %v", err)
+	}
+
+	outcomes := make([]Outcome, 0)
+	for _, decision := range bank.Rules.Decisions(checking.AccountNumber) {
+		outcomes = append(outcomes, decision.Outcome)
+	}
+	expected := []Outcome{OutcomeAllow, OutcomeAllow, OutcomeAllow, OutcomeDeny, OutcomeAllow}
+	if strings.Join(toStrings(outcomes), ",") != strings.Join(toStrings(expected), ",") {
+		t.Errorf("Expected decisions %v, got %v", expected, outcomes)
+	}
+	expectReconciled(t, bank)
+}
+
+func TestWithdrawalRulesCoverTransfers(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("5000"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("100"))
+	setRules(t, bank, `{"rules": [{"name": "daily-cash", "type": "daily-limit", "action": "deny",
+		"movements": ["withdrawal"], "limit": {"amount": "500", "currency": "USD"}}]}`)
+
+	if err := checking.Withdraw(usd("300")); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	// Moving the money to another account can't get around the limit
+	if err := bank.Transfer(checking.AccountNumber, savings.AccountNumber, usd("250")); !errors.Is(err, ErrTransactionDenied) {
+		t.Errorf("Expected the withdrawal limit to deny the transfer, got %v", err)
+	}
+	if err := bank.Transfer(checking.AccountNumber, savings.AccountNumber, usd("200")); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	// and the transfer counts toward later withdrawals
+	if err := checking.Withdraw(usd("1")); !errors.Is(err, ErrTransactionDenied) {
+		t.Errorf("Expected the daily limit to deny the withdrawal, got %v", err)
+	}
+	expectBalance(t, savings, "300")
+	expectReconciled(t, bank)
+}
+
+// toStrings converts outcomes for comparison
+func toStrings(outcomes []Outcome) []string {
+	result := make([]string, len(outcomes))
+	for i, outcome := range outcomes {
+		result[i] = string(outcome)
+	}
+	return result
+}
+
+func TestReviewRules(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+	setRules(t, bank, `{"rules": [
+		{"name": "rapid-fire", "type": "velocity", "action": "review", "maxCount": 2, "window": "1h"},
+		{"name": "large", "type": "large-transaction", "action": "review", "limit": {"amount": "10000", "currency": "USD"}}
+	]}`)
+
+	// The opening deposit counts towards the velocity rule until it is an hour old
+	clock.Advance(time.Hour)
+	checking.Deposit(usd("10"))
+	clock.Advance(10 * time.Minute)
+	checking.Deposit(usd("10"))
+	clock.Advance(10 * time.Minute)
+	if err := checking.Deposit(usd("10")); err != nil {
+		t.Errorf("Expected a reviewed deposit to go through, got %v", err)
+	}
+	clock.Advance(2 * time.Hour)
+	if err := checking.Deposit(usd("10000")); err != nil {
+		t.Errorf("Expected a reviewed deposit to go through, got %v", err)
+	}
+	expectBalance(t, checking, "10130")
+
+	decisions := bank.Rules.Decisions(checking.AccountNumber)
+	if len(decisions) != 4 {
+		t.Fatalf("Expected 4 decisions, got %d", len(decisions))
+	}
+	if decisions[1].Outcome != OutcomeAllow || decisions[1].Reason != "no rule triggered" {
+		t.Errorf("Expected the second deposit to be allowed, got %+v", decisions[1])
+	}
+	if decisions[2].Outcome != OutcomeReview || decisions[2].Rule != "rapid-fire" {
+		t.Errorf("Expected the third deposit within an hour to be reviewed, got %+v", decisions[2])
+	}
+	if decisions[3].Outcome != OutcomeReview || decisions[3].Rule != "large" || !decisions[3].Amount.Equal(usd("10000")) {
+		t.Errorf("Expected the large deposit to be reviewed, got %+v", decisions[3])
+	}
+}
+
+func TestBlockedCounterpartyRule(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	blocked, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	setRules(t, bank, `{"rules": [
+		{"name": "large", "type": "large-transaction", "action": "review", "limit": {"amount": "50", "currency": "USD"}},
+		{"name": "sanctions", "type": "blocked-counterparty", "action": "deny", "counterparties": ["`+blocked.AccountNumber+`"]}
+	]}`)
+
+	if err := bank.Transfer(checking.AccountNumber, blocked.AccountNumber, usd("100")); !errors.Is(err, ErrTransactionDenied) {
+		t.Errorf("Expected a transfer to a blocked account to be denied, got %v", err)
+	}
+	if err := bank.Transfer(blocked.AccountNumber, checking.AccountNumber, usd("10")); !errors.Is(err, ErrTransactionDenied) {
+		t.Errorf("Expected a transfer from a blocked account to be denied, got %v", err)
+	}
+	expectBalance(t, checking, "1000")
+	expectBalance(t, blocked, "1000")
+
+	// A deny wins over a review, and both reasons are kept
+	decision := bank.Rules.Decisions(checking.AccountNumber)[0]
+	if decision.Outcome != OutcomeDeny || decision.Rule != "sanctions" || decision.Counterparty != blocked.AccountNumber ||
+		!strings.Contains(decision.Reason, "large:") || !strings.Contains(decision.Reason, "sanctions:") {
+		t.Errorf("Unexpected decision: %+v", decision)
+	}
+}
+
+func TestDailyLimitUnderConcurrency(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("10000"))
+	checking.TransactionFee = usd("0")
+	setRules(t, bank, `{"rules": [{"name": "daily-cash", "type": "daily-limit", "action": "deny",
+		"movements": ["withdrawal"], "limit": {"amount": "1000", "currency": "USD"}}]}`)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checking.Withdraw(usd("100"))
+		}()
+	}
+	wg.Wait()
+
+	expectBalance(t, checking, "9000")
+}
+
+func TestServerRules(t *testing.T) {
+	client, bank, _ := newAPIClient(t)
+	customer, _ := bank.CreateCustomer("Ada", "Lovelace", "12 Analytical St")
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	setRules(t, bank, `{"rules": [{"name": "large", "type": "large-transaction", "action": "deny",
+		"limit": {"amount": "500", "currency": "USD"}}]}`)
+
+	resp := client.do("POST", "/accounts/"+checking.AccountNumber+"/withdrawals", `{"amount":"600"}`, nil, nil)
+	client.expectStatus(resp, http.StatusForbidden)
+
+	var decisions []Decision
+	resp = client.do("GET", "/accounts/"+checking.AccountNumber+"/decisions", "", nil, &decisions)
+	client.expectStatus(resp, http.StatusOK)
+	if len(decisions) != 1 || decisions[0].Outcome != OutcomeDeny || decisions[0].Movement != MovementWithdrawal {
+		t.Errorf("Expected one denied withdrawal, got %+v", decisions)
+	}
+
+	resp = client.do("GET", "/accounts/AC1/decisions", "", nil, nil)
+	client.expectStatus(resp, http.StatusNotFound)
+}
@@ -0,0 +1,321 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// Jobs run by the scheduler
+const (
+	JobDailyAccrual    = "daily-accrual"
+	JobMonthlyInterest = "monthly-interest"
+	JobMaintenanceFees = "maintenance-fees"
+	JobResetCounters   = "reset-counters"
+	JobDormancy        = "dormancy"
+)
+
+// JobRun records one job the scheduler ran
+type JobRun struct {
+	Job    string
+	Period time.Time // Start of the day or month the job ran for
+}
+
+// Scheduler runs the bank's periodic jobs on calendar boundaries. At the end
+// of every day it accrues savings interest; at the end of every month it pays
+// and charges interest, takes maintenance fees and resets withdrawal
+// counters. Periods missed while the scheduler wasn't running are caught up
+// in order on the next run. Each account records the last period every job
+// ran for, so a run that fails part way can be retried without posting
+// anything twice. Use one scheduler per bank.
+type Scheduler struct {
+	bank     *Bank
+	clock    Clock
+	Location *time.Location // Calendar for day and month boundaries, UTC by default
+	OnRun    func([]JobRun) // Called by Start after it runs any jobs, for example to save the bank
+	mu       sync.Mutex     // Allows one run at a time
+}
+
+// NewScheduler creates a scheduler for a bank that reads the time from clock,
+// or from the system clock if clock is nil
+func NewScheduler(bank *Bank, clock Clock) *Scheduler {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &Scheduler{bank: bank, clock: clock, Location: time.UTC}
+}
+
+// RunDue runs every job whose period has ended since the last run and
+// returns what it ran. The first run only marks the start of the current day.
+// It stops at the first failing day, which is run again next time for the
+// accounts the failed jobs didn't finish.
+func (s *Scheduler) RunDue() ([]JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := make([]JobRun, 0)
+	today := startOfDay(s.clock.Now().In(s.Location))
+	day := s.bank.scheduledThrough()
+	if day.IsZero() {
+		s.bank.setScheduledThrough(today)
+		return runs, nil
+	}
+
+	for day = day.In(s.Location); day.Before(today); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+
+		if err := s.bank.AccrueDailyInterest(end); err != nil {
+			return runs, fmt.Errorf("%s for %s: %w", JobDailyAccrual, day.Format(time.DateOnly), err)
+		}
+		runs = append(runs, JobRun{Job: JobDailyAccrual, Period: day})
+
+		if end.Day() == 1 {
+			month := day.AddDate(0, 0, 1-day.Day())
+			monthRuns, err := s.runMonthEnd(month)
+			runs = append(runs, monthRuns...)
+			if err != nil {
+				return runs, err
+			}
+		}
+
+		s.bank.setScheduledThrough(end)
+	}
+
+	if len(runs) > 0 {
+		if _, err := s.bank.MarkDormantAccounts(); err != nil {
+			return runs, fmt.Errorf("%s: %w", JobDormancy, err)
+		}
+		runs = append(runs, JobRun{Job: JobDormancy, Period: today})
+	}
+	return runs, nil
+}
+
+// runMonthEnd runs the jobs for the end of a month
+func (s *Scheduler) runMonthEnd(month time.Time) ([]JobRun, error) {
+	runs := make([]JobRun, 0, 3)
+	label := month.Format("2006-01")
+
+	if err := s.bank.applyMonthlyInterest(month); err != nil {
+		return runs, fmt.Errorf("%s for %s: %w", JobMonthlyInterest, label, err)
+	}
+	runs = append(runs, JobRun{Job: JobMonthlyInterest, Period: month})
+
+	if err := s.bank.chargeMaintenanceFees(month); err != nil {
+		return runs, fmt.Errorf("%s for %s: %w", JobMaintenanceFees, label, err)
+	}
+	runs = append(runs, JobRun{Job: JobMaintenanceFees, Period: month})
+
+	s.bank.resetMonthlyCounters(month)
+	runs = append(runs, JobRun{Job: JobResetCounters, Period: month})
+	return runs, nil
+}
+
+// Start calls RunDue every interval until the returned function is called.
+// Errors are passed to onError, which may be nil, and the jobs that ran to
+// OnRun.
+func (s *Scheduler) Start(interval time.Duration, onError func(error)) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+
+	go func() {
+		defer close(finished)
+		for {
+			select {
+			case <-ticker.C:
+				runs, err := s.RunDue()
+				if err != nil && onError != nil {
+					onError(err)
+				}
+				if len(runs) > 0 && s.OnRun != nil {
+					s.OnRun(runs)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+		<-finished
+	}
+}
+
+// startOfDay returns midnight at the start of t's day in t's location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// scheduledThrough reads the scheduler's progress
+func (b *Bank) scheduledThrough() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.ScheduledThrough
+}
+
+// setScheduledThrough records the scheduler's progress
+func (b *Bank) setScheduledThrough(t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ScheduledThrough = t
+}
+
+// runJob runs a job for a period on every account it hasn't run on for that
+// period yet, recording each account it succeeds on. A zero period runs it on
+// every account without recording anything. It returns the first error after
+// trying every account.
+func (b *Bank) runJob(job string, period time.Time, run func(Account) error) error {
+	var firstErr error
+	for _, account := range b.accountList() {
+		internal, tracked := account.(bankAccount)
+		tracked = tracked && !period.IsZero()
+		if tracked && internal.base().ranJob(job, period) {
+			continue
+		}
+		if err := run(account); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("account %s: %w", account.GetAccountNumber(), err)
+			}
+			continue
+		}
+		if tracked {
+			internal.base().markJob(job, period)
+		}
+	}
+	return firstErr
+}
+
+// ranJob reports whether a job has already run on the account for a period
+func (a *BaseAccount) ranJob(job string, period time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	last, ok := a.JobPeriods[job]
+	return ok && !last.Before(period)
+}
+
+// markJob records that a job has run on the account for a period
+func (a *BaseAccount) markJob(job string, period time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.JobPeriods == nil {
+		a.JobPeriods = make(map[string]time.Time)
+	}
+	a.JobPeriods[job] = period
+}
+
+// AccrueDailyInterest adds each open savings account's balance at endOfDay,
+// as recorded in the ledger, to the balances its next interest payment is
+// averaged over. Each account accrues a day at most once. Interest posted
+// while catching up is dated when it is posted, so it only counts towards
+// the days after that. Days that ended before an account was opened are
+// skipped, so catching up doesn't average in balances it never had.
+func (b *Bank) AccrueDailyInterest(endOfDay time.Time) error {
+	return b.runJob(JobDailyAccrual, endOfDay, func(account Account) error {
+		savings, ok := account.(*SavingsAccount)
+		if !ok {
+			return nil
+		}
+
+		balance := b.Ledger.balanceAt(savings.AccountNumber, endOfDay)
+		savings.mu.Lock()
+		defer savings.mu.Unlock()
+		if savings.State == StateClosed || !endOfDay.After(savings.OpenDate) {
+			return nil
+		}
+		accrued, err := savings.AccruedBalance.Add(balance)
+		if err != nil {
+			return err
+		}
+		savings.AccruedBalance = accrued
+		savings.AccrualDays++
+		return nil
+	})
+}
+
+// ChargeMaintenanceFees takes the monthly maintenance fee from every open
+// checking account holding less than Bank.MaintenanceFeeWaiver. The fee never
+// takes a balance below zero. Both are converted for accounts in other currencies.
+func (b *Bank) ChargeMaintenanceFees() error {
+	return b.chargeMaintenanceFees(time.Time{})
+}
+
+// chargeMaintenanceFees charges the fees for a month, skipping accounts
+// already charged for it
+func (b *Bank) chargeMaintenanceFees(month time.Time) error {
+	if !b.MaintenanceFee.IsPositive() {
+		return nil
+	}
+
+	return b.runJob(JobMaintenanceFees, month, func(account Account) error {
+		var a *BaseAccount
+		switch acc := account.(type) {
+		case *CheckingAccount:
+			a = acc.base()
+		case *OverdraftAccount:
+			a = acc.base()
+		default:
+			return nil
+		}
+
+		currency := a.GetBalance().Currency()
+		fee, err := b.feeIn(b.MaintenanceFee, currency)
+		if err != nil {
+			return err
+		}
+		waiver, err := b.feeIn(b.MaintenanceFeeWaiver, currency)
+		if err != nil {
+			return err
+		}
+		return a.chargeMaintenanceFee(fee, waiver)
+	})
+}
+
+// chargeMaintenanceFee charges the fee, or what is left of the balance if
+// that is less, unless the balance reaches the waiver
+func (a *BaseAccount) chargeMaintenanceFee(fee, waiver money.Money) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.State == StateClosed || !a.Balance.IsPositive() {
+		return nil
+	}
+	if waived, err := a.Balance.Compare(waiver); err != nil || waived >= 0 {
+		return err
+	}
+	if short, err := a.Balance.Compare(fee); err != nil {
+		return err
+	} else if short < 0 {
+		fee = a.Balance
+	}
+	return a.charge(TransactionFeeCharge, fee, "Monthly maintenance fee")
+}
+
+// ResetMonthlyCounters starts a new month of savings withdrawals on every account
+func (b *Bank) ResetMonthlyCounters() {
+	b.resetMonthlyCounters(time.Time{})
+}
+
+// resetMonthlyCounters resets the counters for the end of a month, skipping
+// accounts already reset for it
+func (b *Bank) resetMonthlyCounters(month time.Time) {
+	b.runJob(JobResetCounters, month, func(account Account) error {
+		if savings, ok := account.(*SavingsAccount); ok {
+			savings.ResetMonthlyWithdrawals()
+		}
+		return nil
+	})
+}
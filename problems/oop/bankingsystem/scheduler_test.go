@@ -0,0 +1,287 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// countRuns counts the runs of a job
+func countRuns(runs []JobRun, job string) int {
+	count := 0
+	for _, run := range runs {
+		if run.Job == job {
+			count++
+		}
+	}
+	return count
+}
+
+// advanceTo moves a fake clock to a time
+func advanceTo(clock *fakeClock, year int, month time.Month, day, hour int) {
+	clock.now = time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestSchedulerMonthEnd(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+	small, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+	tiny, _ := bank.OpenCheckingAccount(customer.ID, usd("4"))
+	large, _ := bank.OpenCheckingAccount(customer.ID, usd("2000"))
+	scheduler := NewScheduler(bank, clock)
+
+	if runs, err := scheduler.RunDue(); err != nil || len(runs) != 0 {
+		t.Fatalf("Expected the first run to only start the schedule, got %v (%v)", runs, err)
+	}
+
+	// $1,000 for 16 days and $1,310 for 15 averages $1,150 over March
+	advanceTo(clock, 2024, time.March, 17, 9)
+	savings.Deposit(usd("310"))
+	advanceTo(clock, 2024, time.March, 31, 23)
+	if runs, _ := scheduler.RunDue(); countRuns(runs, JobMonthlyInterest) != 0 {
+		t.Errorf("Expected no month-end before the month is over, got %v", runs)
+	}
+	expectBalance(t, savings, "1310")
+
+	advanceTo(clock, 2024, time.April, 1, 10)
+	runs, err := scheduler.RunDue()
+	if err != nil {
+		t.Fatalf("RunDue failed: %v", err)
+	}
+	if countRuns(runs, JobDailyAccrual) != 1 || countRuns(runs, JobMonthlyInterest) != 1 ||
+		countRuns(runs, JobMaintenanceFees) != 1 || countRuns(runs, JobResetCounters) != 1 {
+		t.Errorf("Expected one day and one month-end, got %v", runs)
+	}
+	expectBalance(t, savings, "1315.75")
+	expectBalance(t, small, "90")
+	expectBalance(t, tiny, "0")
+	expectBalance(t, large, "2000")
+	expectReconciled(t, bank)
+
+	if runs, _ := scheduler.RunDue(); len(runs) != 0 {
+		t.Errorf("Expected nothing to run twice, got %v", runs)
+	}
+}
+
+func TestSchedulerCatchUp(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	bank.DormancyPeriod = 60 * 24 * time.Hour
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+	scheduler := NewScheduler(bank, clock)
+	scheduler.RunDue()
+
+	// Three months pass without a run
+	advanceTo(clock, 2024, time.June, 1, 8)
+	runs, err := scheduler.RunDue()
+	if err != nil {
+		t.Fatalf("RunDue failed: %v", err)
+	}
+	if countRuns(runs, JobDailyAccrual) != 92 || countRuns(runs, JobMonthlyInterest) != 3 {
+		t.Errorf("Expected 92 days and 3 month-ends, got %d and %d",
+			countRuns(runs, JobDailyAccrual), countRuns(runs, JobMonthlyInterest))
+	}
+	months := make([]string, 0)
+	for _, run := range runs {
+		if run.Job == JobMonthlyInterest {
+			months = append(months, run.Period.Format("2006-01"))
+		}
+	}
+	if len(months) != 3 || months[0] != "2024-03" || months[2] != "2024-05" {
+		t.Errorf("Expected month-ends for March to May in order, got %v", months)
+	}
+
+	expectBalance(t, savings, "1015")
+	expectBalance(t, checking, "70")
+	if !bank.ScheduledThrough.Equal(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the schedule to be caught up to 1 June, got %v", bank.ScheduledThrough)
+	}
+	// Interest and fees aren't customer activity
+	if checking.GetState() != StateDormant {
+		t.Errorf("Expected the unused checking account to become dormant, got %s", checking.GetState())
+	}
+	expectReconciled(t, bank)
+}
+
+func TestSchedulerCatchUpSkipsDaysBeforeOpening(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	scheduler := NewScheduler(bank, clock)
+	scheduler.RunDue()
+
+	// Opened halfway through a month the scheduler only catches up on at its end
+	advanceTo(clock, 2024, time.March, 17, 9)
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+
+	advanceTo(clock, 2024, time.April, 1, 10)
+	runs, err := scheduler.RunDue()
+	if err != nil {
+		t.Fatalf("RunDue failed: %v", err)
+	}
+	if countRuns(runs, JobDailyAccrual) != 31 || countRuns(runs, JobMonthlyInterest) != 1 {
+		t.Errorf("Expected 31 days and a month-end, got %v", runs)
+	}
+
+	// Only the 15 days from 17 March count, all at $1,000
+	expectBalance(t, savings, "1005")
+	expectReconciled(t, bank)
+}
+
+func TestCloseAccountPaysAccruedInterest(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("3100"))
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("0"))
+	scheduler := NewScheduler(bank, clock)
+	scheduler.RunDue()
+
+	advanceTo(clock, 2024, time.March, 11, 9)
+	scheduler.RunDue()
+	if savings.AccrualDays != 10 {
+		t.Fatalf("Expected 10 days accrued, got %d", savings.AccrualDays)
+	}
+
+	// A close that fails pays nothing
+	if err := bank.CloseAccount(savings.AccountNumber, ""); err == nil {
+		t.Fatal("Expected closing without a payout account to fail")
+	}
+	expectBalance(t, savings, "3100")
+
+	// $3,100 for 10 of March's 31 days averages $1,000 over the month
+	if err := bank.CloseAccount(savings.AccountNumber, checking.AccountNumber); err != nil {
+		t.Fatalf("CloseAccount failed: %v", err)
+	}
+	expectBalance(t, savings, "0")
+	expectBalance(t, checking, "3105")
+	if savings.AccrualDays != 0 || !savings.AccruedBalance.IsZero() {
+		t.Errorf("Expected the accrual to be settled, got %d days and %v", savings.AccrualDays, savings.AccruedBalance)
+	}
+
+	txs := bank.Ledger.Transactions(savings.AccountNumber)
+	if len(txs) < 2 || txs[len(txs)-2].Type != TransactionInterest || !txs[len(txs)-2].Amount.Equal(usd("5")) {
+		t.Errorf("Expected $5 interest before the closing payout, got %+v", txs)
+	}
+	expectReconciled(t, bank)
+}
+
+func TestSchedulerResetsWithdrawals(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+	scheduler := NewScheduler(bank, clock)
+	scheduler.RunDue()
+
+	for i := 0; i < savings.MaxWithdrawals; i++ {
+		savings.Withdraw(usd("10"))
+	}
+	// Interest no longer resets the counter
+	bank.ApplyMonthlyInterest()
+	if err := savings.Withdraw(usd("10")); !errors.Is(err, ErrWithdrawalLimit) {
+		t.Errorf("Expected the withdrawal limit to still apply, got %v", err)
+	}
+
+	advanceTo(clock, 2024, time.April, 1, 0)
+	scheduler.RunDue()
+	if err := savings.Withdraw(usd("10")); err != nil {
+		t.Errorf("Expected withdrawals to be allowed in a new month, got %v", err)
+	}
+}
+
+func TestSchedulerSurvivesRestart(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+	NewScheduler(bank, clock).RunDue()
+
+	advanceTo(clock, 2024, time.March, 17, 9)
+	savings.Deposit(usd("310"))
+	NewScheduler(bank, clock).RunDue()
+
+	repo := NewMemoryRepository()
+	if err := bank.Save(repo); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	restored, err := LoadBank(bank.Name, repo)
+	if err != nil {
+		t.Fatalf("LoadBank failed: %v", err)
+	}
+	restored.SetClock(clock)
+
+	advanceTo(clock, 2024, time.April, 1, 10)
+	if _, err := NewScheduler(restored, clock).RunDue(); err != nil {
+		t.Fatalf("RunDue failed: %v", err)
+	}
+	account, _ := restored.GetAccount(savings.AccountNumber)
+	expectBalance(t, account, "1315.75")
+}
+
+func TestSchedulerRetryDoesNotDoublePost(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	bank.Rates, _ = ParseRates([]byte(`{"rates": {"USD/EUR": "0.92"}}`))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+	euros, _ := bank.OpenCheckingAccount(customer.ID, eur("100"))
+	scheduler := NewScheduler(bank, clock)
+	scheduler.RunDue()
+
+	// Without rates the euro account's maintenance fee can't be converted,
+	// so the month-end fails after interest and the dollar fee are posted
+	rates := bank.Rates
+	bank.Rates = nil
+	advanceTo(clock, 2024, time.April, 1, 10)
+	for i := 0; i < 3; i++ {
+		if _, err := scheduler.RunDue(); !errors.Is(err, ErrRateNotFound) {
+			t.Fatalf("Expected the month-end to fail, got %v", err)
+		}
+		expectBalance(t, savings, "1005")
+		expectBalance(t, checking, "90")
+		expectAmount(t, "the euro balance", euros.GetBalance(), eur("100"))
+	}
+
+	bank.Rates = rates
+	if _, err := scheduler.RunDue(); err != nil {
+		t.Fatalf("RunDue failed: %v", err)
+	}
+	expectBalance(t, savings, "1005")
+	expectBalance(t, checking, "90")
+	expectAmount(t, "the euro balance", euros.GetBalance(), eur("90.80"))
+	if savings.AccrualDays != 0 {
+		t.Errorf("Expected the last day of March to be accrued once, got %d days accrued after interest", savings.AccrualDays)
+	}
+	if !bank.ScheduledThrough.Equal(time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the schedule to advance once the retry succeeds, got %v", bank.ScheduledThrough)
+	}
+	expectReconciled(t, bank)
+}
+
+func TestSchedulerStart(t *testing.T) {
+	bank, customer, clock := newTestBank(t)
+	bank.OpenSavingsAccount(customer.ID, usd("1000"))
+	scheduler := NewScheduler(bank, clock)
+	scheduler.RunDue()
+	advanceTo(clock, 2024, time.March, 2, 9)
+
+	ran := make(chan []JobRun, 1)
+	scheduler.OnRun = func(runs []JobRun) {
+		select {
+		case ran <- runs:
+		default:
+		}
+	}
+	stop := scheduler.Start(time.Millisecond, func(err error) { t.Errorf("RunDue failed: %v", err) })
+	defer stop()
+
+	select {
+	case runs := <-ran:
+		if countRuns(runs, JobDailyAccrual) != 1 {
+			t.Errorf("Expected the day's accrual, got %v", runs)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Start to run the due jobs")
+	}
+}
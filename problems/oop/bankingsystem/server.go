@@ -0,0 +1,603 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// IdempotencyKeyHeader names the request header that makes a money-moving
+// request safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxBodyBytes limits the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// idempotencyTTL is how long the response to a request with an idempotency
+// key is kept for retries
+const idempotencyTTL = 24 * time.Hour
+
+// Server exposes a bank as a JSON REST API:
+//
+//	POST /customers                       create a customer
+//	GET  /customers/{id}                  get a customer
+//	GET  /customers/{id}/accounts         list a customer's accounts
+//	POST /accounts                        open an account of any type
+//	GET  /accounts/{number}               get an account
+//	POST /accounts/{number}/deposits      deposit into an account
+//	POST /accounts/{number}/withdrawals   withdraw from an account
+//	GET  /accounts/{number}/statement     get a statement, optionally ?from=&to= in RFC 3339
+//	POST /accounts/{number}/freeze        freeze an account
+//	POST /accounts/{number}/unfreeze      unfreeze an account
+//	POST /accounts/{number}/reactivate    reactivate a dormant account
+//	POST /accounts/{number}/close         close an account, paying out to another
+//	GET  /accounts/{number}/decisions     list the rule engine's decisions on an account
+//	GET  /audit/{subject}                 get the audit trail of an account or customer
+//	DELETE /customers/{id}                remove a customer whose accounts are closed
+//	POST /transfers                       transfer between two accounts
+//
+// Amounts in requests are decimal strings such as "12.50" in the account's
+// own currency, or the source account's for transfers. Accounts are opened in
+// the requested currency, or the bank's if none is given. Requests that open
+// accounts or move money may carry an Idempotency-Key header: retrying with
+// the same key within a day replays the first response instead of moving the
+// money again.
+type Server struct {
+	bank        *Bank
+	mux         *http.ServeMux
+	idempotency map[string]*idempotentResponse
+	expiry      []string   // Keys of finished requests, oldest first
+	mu          sync.Mutex // Guards idempotency and expiry
+}
+
+// idempotentResponse is the stored outcome of a request made with an idempotency key
+type idempotentResponse struct {
+	fingerprint string
+	done        chan struct{} // Closed once status and body are set
+	status      int
+	body        []byte
+	expires     time.Time
+}
+
+// NewServer creates an API server for a bank
+func NewServer(bank *Bank) *Server {
+	s := &Server{
+		bank:        bank,
+		mux:         http.NewServeMux(),
+		idempotency: make(map[string]*idempotentResponse),
+	}
+
+	s.mux.HandleFunc("POST /customers", s.handleCreateCustomer)
+	s.mux.HandleFunc("GET /customers/{id}", s.handleGetCustomer)
+	s.mux.HandleFunc("GET /customers/{id}/accounts", s.handleGetCustomerAccounts)
+	s.mux.HandleFunc("POST /accounts", s.idempotent(s.handleOpenAccount))
+	s.mux.HandleFunc("GET /accounts/{number}", s.handleGetAccount)
+	s.mux.HandleFunc("POST /accounts/{number}/deposits", s.idempotent(s.handleDeposit))
+	s.mux.HandleFunc("POST /accounts/{number}/withdrawals", s.idempotent(s.handleWithdrawal))
+	s.mux.HandleFunc("GET /accounts/{number}/statement", s.handleGetStatement)
+	s.mux.HandleFunc("POST /accounts/{number}/freeze", s.handleStateChange(s.bank.FreezeAccount))
+	s.mux.HandleFunc("POST /accounts/{number}/unfreeze", s.handleStateChange(s.bank.UnfreezeAccount))
+	s.mux.HandleFunc("POST /accounts/{number}/reactivate", s.handleStateChange(s.bank.ReactivateAccount))
+	s.mux.HandleFunc("POST /accounts/{number}/close", s.idempotent(s.handleCloseAccount))
+	s.mux.HandleFunc("GET /accounts/{number}/decisions", s.handleGetDecisions)
+	s.mux.HandleFunc("GET /audit/{subject}", s.handleGetAuditTrail)
+	s.mux.HandleFunc("DELETE /customers/{id}", s.handleRemoveCustomer)
+	s.mux.HandleFunc("POST /transfers", s.idempotent(s.handleTransfer))
+
+	return s
+}
+
+// ServeHTTP dispatches a request to its handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// customerView is the JSON form of a customer
+type customerView struct {
+	ID             string    `json:"id"`
+	FirstName      string    `json:"firstName"`
+	LastName       string    `json:"lastName"`
+	Address        string    `json:"address"`
+	JoinDate       time.Time `json:"joinDate"`
+	AccountNumbers []string  `json:"accountNumbers"`
+}
+
+func newCustomerView(c *Customer) customerView {
+	return customerView{
+		ID:             c.ID,
+		FirstName:      c.FirstName,
+		LastName:       c.LastName,
+		Address:        c.Address,
+		JoinDate:       c.JoinDate,
+		AccountNumbers: c.GetAccountNumbers(),
+	}
+}
+
+// accountView is the JSON form of an account
+type accountView struct {
+	AccountNumber string       `json:"accountNumber"`
+	Type          string       `json:"type"`
+	OwnerID       string       `json:"ownerId,omitempty"`
+	Balance       money.Money  `json:"balance"`
+	State         AccountState `json:"state,omitempty"`
+	OpenDate      time.Time    `json:"openDate,omitzero"`
+}
+
+func newAccountView(account Account) accountView {
+	view := accountView{
+		AccountNumber: account.GetAccountNumber(),
+		Type:          account.GetAccountType(),
+		Balance:       account.GetBalance(),
+	}
+	if internal, ok := account.(bankAccount); ok {
+		view.OwnerID = internal.base().OwnerID
+		view.OpenDate = internal.base().OpenDate
+		view.State = internal.base().GetState()
+	}
+	return view
+}
+
+func (s *Server) handleCreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+		Address   string `json:"address"`
+	}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	customer, err := s.bank.CreateCustomer(req.FirstName, req.LastName, req.Address)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newCustomerView(customer))
+}
+
+func (s *Server) handleGetCustomer(w http.ResponseWriter, r *http.Request) {
+	customer, err := s.bank.GetCustomer(r.PathValue("id"))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newCustomerView(customer))
+}
+
+func (s *Server) handleGetCustomerAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := s.bank.GetCustomerAccounts(r.PathValue("id"))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	views := make([]accountView, 0, len(accounts))
+	for _, account := range accounts {
+		views = append(views, newAccountView(account))
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+func (s *Server) handleOpenAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		CustomerID     string `json:"customerId"`
+		Type           string `json:"type"`
+		InitialDeposit string `json:"initialDeposit"`
+		Limit          string `json:"limit"`      // Overdraft or credit limit
+		TermMonths     int    `json:"termMonths"` // Length of a term deposit
+		Currency       string `json:"currency"`   // The bank's currency if empty
+	}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = s.bank.Currency
+	}
+	initialDeposit, ok := s.parseOptionalAmount(w, req.InitialDeposit, currency)
+	if !ok {
+		return
+	}
+	limit, ok := s.parseOptionalAmount(w, req.Limit, currency)
+	if !ok {
+		return
+	}
+
+	var account Account
+	var err error
+	switch req.Type {
+	case AccountTypeChecking:
+		account, err = s.bank.OpenCheckingAccount(req.CustomerID, initialDeposit)
+	case AccountTypeSavings:
+		account, err = s.bank.OpenSavingsAccount(req.CustomerID, initialDeposit)
+	case AccountTypeOverdraft:
+		account, err = s.bank.OpenOverdraftAccount(req.CustomerID, initialDeposit, limit)
+	case AccountTypeCreditLine:
+		account, err = s.bank.OpenCreditLine(req.CustomerID, limit)
+	case AccountTypeTermDeposit:
+		account, err = s.bank.OpenTermDeposit(req.CustomerID, initialDeposit, req.TermMonths)
+	default:
+		err = fmt.Errorf("%w: unknown account type %q, expected checking, savings, overdraft, creditline or termdeposit",
+			ErrInvalidArgument, req.Type)
+	}
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newAccountView(account))
+}
+
+func (s *Server) handleGetAccount(w http.ResponseWriter, r *http.Request) {
+	account, err := s.bank.GetAccount(r.PathValue("number"))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAccountView(account))
+}
+
+func (s *Server) handleDeposit(w http.ResponseWriter, r *http.Request) {
+	s.moveMoney(w, r, Account.Deposit)
+}
+
+func (s *Server) handleWithdrawal(w http.ResponseWriter, r *http.Request) {
+	s.moveMoney(w, r, Account.Withdraw)
+}
+
+// moveMoney applies a deposit or withdrawal from a request with an "amount"
+// in the account's currency and responds with the updated account
+func (s *Server) moveMoney(w http.ResponseWriter, r *http.Request, operation func(Account, money.Money) error) {
+	var req struct {
+		Amount string `json:"amount"`
+	}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	account, err := s.bank.GetAccount(r.PathValue("number"))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	amount, ok := s.parseAmount(w, req.Amount, account.GetBalance().Currency())
+	if !ok {
+		return
+	}
+
+	if err := operation(account, amount); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAccountView(account))
+}
+
+func (s *Server) handleGetStatement(w http.ResponseWriter, r *http.Request) {
+	// The statement covers everything so far unless a range is given
+	from := time.Time{}
+	to := s.bank.Ledger.now().Add(time.Nanosecond)
+
+	for name, t := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("%s must be an RFC 3339 time", name))
+			return
+		}
+		*t = parsed
+	}
+
+	statement, err := s.bank.GetStatement(r.PathValue("number"), from, to)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, statement)
+}
+
+func (s *Server) handleTransfer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		From   string `json:"from"`
+		To     string `json:"to"`
+		Amount string `json:"amount"`
+	}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	// The amount is in the source account's currency; a missing source is
+	// reported by the transfer
+	currency := s.bank.Currency
+	if from, err := s.bank.GetAccount(req.From); err == nil {
+		currency = from.GetBalance().Currency()
+	}
+	amount, ok := s.parseAmount(w, req.Amount, currency)
+	if !ok {
+		return
+	}
+
+	if err := s.bank.Transfer(req.From, req.To, amount); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	from, _ := s.bank.GetAccount(req.From)
+	to, _ := s.bank.GetAccount(req.To)
+	writeJSON(w, http.StatusOK, map[string]accountView{
+		"from": newAccountView(from),
+		"to":   newAccountView(to),
+	})
+}
+
+// handleStateChange serves a request that moves an account to another state,
+// with an optional "reason" in the body
+func (s *Server) handleStateChange(change func(accountNumber, reason string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Reason string `json:"reason"`
+		}
+		if !decodeOptionalRequest(w, r, &req) {
+			return
+		}
+
+		number := r.PathValue("number")
+		if err := change(number, req.Reason); err != nil {
+			writeError(w, statusFor(err), err)
+			return
+		}
+		account, _ := s.bank.GetAccount(number)
+		writeJSON(w, http.StatusOK, newAccountView(account))
+	}
+}
+
+func (s *Server) handleCloseAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PayoutTo string `json:"payoutTo"`
+	}
+	if !decodeOptionalRequest(w, r, &req) {
+		return
+	}
+
+	number := r.PathValue("number")
+	if err := s.bank.CloseAccount(number, req.PayoutTo); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	account, _ := s.bank.GetAccount(number)
+	writeJSON(w, http.StatusOK, newAccountView(account))
+}
+
+func (s *Server) handleGetDecisions(w http.ResponseWriter, r *http.Request) {
+	number := r.PathValue("number")
+	if _, err := s.bank.GetAccount(number); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.bank.Rules.Decisions(number))
+}
+
+func (s *Server) handleGetAuditTrail(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.bank.Ledger.AuditTrail(r.PathValue("subject")))
+}
+
+func (s *Server) handleRemoveCustomer(w http.ResponseWriter, r *http.Request) {
+	if err := s.bank.RemoveCustomer(r.PathValue("id")); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseAmount reads a decimal amount in a currency, writing a 400 response
+// if it is invalid
+func (s *Server) parseAmount(w http.ResponseWriter, amount, currency string) (money.Money, bool) {
+	parsed, err := money.Parse(amount, currency)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid amount: %w", err))
+		return money.Money{}, false
+	}
+	return parsed, true
+}
+
+// parseOptionalAmount is like parseAmount but reads a missing amount as zero
+// in the currency
+func (s *Server) parseOptionalAmount(w http.ResponseWriter, amount, currency string) (money.Money, bool) {
+	if amount == "" {
+		zero, err := money.Zero(currency)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid currency: %w", err))
+			return money.Money{}, false
+		}
+		return zero, true
+	}
+	return s.parseAmount(w, amount, currency)
+}
+
+// idempotent wraps a handler so a request carrying an Idempotency-Key runs
+// at most once. A retry with the same key and request gets the stored
+// response; reusing a key for a different request is rejected with 422, and
+// retrying while the first request is still running is rejected with 409.
+// Responses are kept for idempotencyTTL. A request whose handler panics
+// keeps no response, so it can be retried.
+func (s *Server) idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("reading request body: %w", err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.Path)
+		hash.Write(body)
+		fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+		s.mu.Lock()
+		s.expireResponses(s.bank.Ledger.now())
+		stored, exists := s.idempotency[key]
+		if !exists {
+			stored = &idempotentResponse{fingerprint: fingerprint, done: make(chan struct{})}
+			s.idempotency[key] = stored
+		}
+		s.mu.Unlock()
+
+		if exists {
+			if stored.fingerprint != fingerprint {
+				writeError(w, http.StatusUnprocessableEntity, errors.New("idempotency key was already used for a different request"))
+				return
+			}
+			select {
+			case <-stored.done:
+			default:
+				writeError(w, http.StatusConflict, errors.New("a request with this idempotency key is still in progress"))
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(stored.status)
+			w.Write(stored.body)
+			return
+		}
+
+		finished := false
+		defer func() {
+			if !finished {
+				// The handler panicked: forget the key so the request can be retried
+				s.mu.Lock()
+				delete(s.idempotency, key)
+				s.mu.Unlock()
+				stored.status, stored.body = http.StatusInternalServerError, []byte(`{"error":"internal server error"}`)
+			}
+			close(stored.done)
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(recorder, r)
+		stored.status, stored.body = recorder.status, recorder.body.Bytes()
+		finished = true
+
+		s.mu.Lock()
+		stored.expires = s.bank.Ledger.now().Add(idempotencyTTL)
+		s.expiry = append(s.expiry, key)
+		s.mu.Unlock()
+	}
+}
+
+// expireResponses forgets the stored responses that have expired. Responses
+// all live equally long, so they expire in the order they finished. Callers
+// must hold s.mu.
+func (s *Server) expireResponses(now time.Time) {
+	for len(s.expiry) > 0 {
+		stored := s.idempotency[s.expiry[0]]
+		if stored != nil && now.Before(stored.expires) {
+			return
+		}
+		delete(s.idempotency, s.expiry[0])
+		s.expiry = s.expiry[1:]
+	}
+}
+
+// responseRecorder passes a response through while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+// statusFor maps an error from the bank to an HTTP status code
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrCustomerNotFound), errors.Is(err, ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTransactionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, ErrAccountFrozen), errors.Is(err, ErrAccountDormant), errors.Is(err, ErrAccountClosed),
+		errors.Is(err, ErrInvalidTransition):
+		return http.StatusConflict
+	case errors.Is(err, ErrInsufficientFunds), errors.Is(err, ErrWithdrawalLimit), errors.Is(err, ErrEarlyWithdrawal),
+		errors.Is(err, ErrDepositNotAllowed), errors.Is(err, money.ErrOverflow), errors.Is(err, ErrRateNotFound),
+		errors.Is(err, money.ErrCurrencyMismatch):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrInvalidArgument):
+		return http.StatusBadRequest
+	default:
+		// Anything unexpected is the server's fault, not the client's
+		return http.StatusInternalServerError
+	}
+}
+
+// decodeRequest reads a JSON request body into v, writing a 400 response if
+// it is malformed
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	return decodeBody(w, r, v, false)
+}
+
+// decodeOptionalRequest is decodeRequest for a body that may be left out. An
+// empty body leaves v unchanged, whether or not the request declares its length.
+func decodeOptionalRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	return decodeBody(w, r, v, true)
+}
+
+// decodeBody does the work of decodeRequest and decodeOptionalRequest
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}, optional bool) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err == io.EOF && optional {
+		return true
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error as a JSON response of the form {"error": "..."}
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
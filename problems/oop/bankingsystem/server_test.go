@@ -0,0 +1,347 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// apiClient sends JSON requests to a test server
+type apiClient struct {
+	t      *testing.T
+	server *httptest.Server
+}
+
+func newAPIClient(t *testing.T) (*apiClient, *Bank, *fakeClock) {
+	t.Helper()
+
+	clock := &fakeClock{now: time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)}
+	bank := NewBank("Test Bank")
+	bank.SetClock(clock)
+
+	server := httptest.NewServer(NewServer(bank))
+	t.Cleanup(server.Close)
+	return &apiClient{t: t, server: server}, bank, clock
+}
+
+// do sends a request and decodes the JSON response into out, if given
+func (c *apiClient) do(method, path, body string, headers map[string]string, out interface{}) *http.Response {
+	c.t.Helper()
+
+	req, err := http.NewRequest(method, c.server.URL+path, strings.NewReader(body))
+	if err != nil {
+		c.t.Fatalf("NewRequest failed: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			c.t.Fatalf("Decoding %s %s response failed: %v", method, path, err)
+		}
+	}
+	return resp
+}
+
+func (c *apiClient) expectStatus(resp *http.Response, status int) {
+	c.t.Helper()
+	if resp.StatusCode != status {
+		c.t.Errorf("%s %s: expected status %d, got %d", resp.Request.Method, resp.Request.URL.Path, status, resp.StatusCode)
+	}
+}
+
+func TestServerCustomersAndAccounts(t *testing.T) {
+	client, _, _ := newAPIClient(t)
+
+	var customer customerView
+	resp := client.do("POST", "/customers", `{"firstName":"Jane","lastName":"Smith","address":"456 Go Lane"}`, nil, &customer)
+	client.expectStatus(resp, http.StatusCreated)
+	if customer.ID == "" || customer.FirstName != "Jane" {
+		t.Fatalf("Unexpected customer: %+v", customer)
+	}
+
+	var account accountView
+	resp = client.do("POST", "/accounts", `{"customerId":"`+customer.ID+`","type":"checking","initialDeposit":"100.50"}`, nil, &account)
+	client.expectStatus(resp, http.StatusCreated)
+	if account.Type != "Checking" || account.Balance.Decimal() != "100.50" || account.OwnerID != customer.ID {
+		t.Errorf("Unexpected account: %+v", account)
+	}
+
+	var fetched customerView
+	client.expectStatus(client.do("GET", "/customers/"+customer.ID, "", nil, &fetched), http.StatusOK)
+	if len(fetched.AccountNumbers) != 1 || fetched.AccountNumbers[0] != account.AccountNumber {
+		t.Errorf("Expected the customer to list the new account, got %+v", fetched)
+	}
+
+	var accounts []accountView
+	client.expectStatus(client.do("GET", "/customers/"+customer.ID+"/accounts", "", nil, &accounts), http.StatusOK)
+	if len(accounts) != 1 {
+		t.Errorf("Expected 1 account, got %d", len(accounts))
+	}
+
+	tests := []struct {
+		name         string
+		method, path string
+		body         string
+		status       int
+	}{
+		{"invalid customer", "POST", "/customers", `{"firstName":"","lastName":"Smith","address":"x"}`, http.StatusBadRequest},
+		{"malformed JSON", "POST", "/customers", `{"firstName":`, http.StatusBadRequest},
+		{"unknown field", "POST", "/customers", `{"name":"Jane"}`, http.StatusBadRequest},
+		{"unknown customer", "GET", "/customers/C1", "", http.StatusNotFound},
+		{"unknown customer's accounts", "GET", "/customers/C1/accounts", "", http.StatusNotFound},
+		{"unknown account type", "POST", "/accounts", `{"customerId":"` + customer.ID + `","type":"crypto"}`, http.StatusBadRequest},
+		{"account for unknown customer", "POST", "/accounts", `{"customerId":"C1","type":"savings"}`, http.StatusNotFound},
+		{"negative initial deposit", "POST", "/accounts", `{"customerId":"` + customer.ID + `","type":"savings","initialDeposit":"-5"}`, http.StatusBadRequest},
+		{"unknown account", "GET", "/accounts/AC1", "", http.StatusNotFound},
+		{"wrong method", "DELETE", "/accounts/" + account.AccountNumber, "", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mux answers 405 itself, without a JSON body
+			if tt.status == http.StatusMethodNotAllowed {
+				client.expectStatus(client.do(tt.method, tt.path, tt.body, nil, nil), tt.status)
+				return
+			}
+
+			var body map[string]string
+			client.expectStatus(client.do(tt.method, tt.path, tt.body, nil, &body), tt.status)
+			if body["error"] == "" {
+				t.Error("Expected an error message in the response")
+			}
+		})
+	}
+}
+
+func TestServerMoneyMovement(t *testing.T) {
+	client, bank, clock := newAPIClient(t)
+	customer, _ := bank.CreateCustomer("Jane", "Smith", "456 Go Lane")
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("500"))
+	clock.Advance(time.Hour)
+
+	var account accountView
+	path := "/accounts/" + checking.AccountNumber
+	client.expectStatus(client.do("POST", path+"/deposits", `{"amount":"250.25"}`, nil, &account), http.StatusOK)
+	client.expectStatus(client.do("POST", path+"/withdrawals", `{"amount":"100"}`, nil, &account), http.StatusOK)
+	if account.Balance.Decimal() != "1145.25" {
+		t.Errorf("Expected balance 1145.25 after the $5 fee, got %v", account.Balance)
+	}
+
+	var transfer map[string]accountView
+	resp := client.do("POST", "/transfers", `{"from":"`+savings.AccountNumber+`","to":"`+checking.AccountNumber+`","amount":"50"}`, nil, &transfer)
+	client.expectStatus(resp, http.StatusOK)
+	if transfer["from"].Balance.Decimal() != "450.00" || transfer["to"].Balance.Decimal() != "1195.25" {
+		t.Errorf("Unexpected transfer result: %+v", transfer)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+	}{
+		{"insufficient funds", path + "/withdrawals", `{"amount":"5000"}`, http.StatusUnprocessableEntity},
+		{"non-positive amount", path + "/deposits", `{"amount":"0"}`, http.StatusBadRequest},
+		{"too many decimals", path + "/deposits", `{"amount":"1.005"}`, http.StatusBadRequest},
+		{"not a number", path + "/deposits", `{"amount":"ten"}`, http.StatusBadRequest},
+		{"unknown account", "/accounts/AC1/deposits", `{"amount":"10"}`, http.StatusNotFound},
+		{"transfer to self", "/transfers", `{"from":"` + savings.AccountNumber + `","to":"` + savings.AccountNumber + `","amount":"1"}`, http.StatusBadRequest},
+		{"transfer from unknown account", "/transfers", `{"from":"AC1","to":"` + savings.AccountNumber + `","amount":"1"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.expectStatus(client.do("POST", tt.path, tt.body, nil, nil), tt.status)
+		})
+	}
+
+	// Savings transfers count towards the monthly withdrawal limit
+	for i := 1; i < savings.MaxWithdrawals; i++ {
+		savings.Withdraw(usd("1"))
+	}
+	resp = client.do("POST", "/accounts/"+savings.AccountNumber+"/withdrawals", `{"amount":"1"}`, nil, nil)
+	client.expectStatus(resp, http.StatusUnprocessableEntity)
+
+	var statement Statement
+	resp = client.do("GET", path+"/statement?from=2024-03-01T09:30:00Z", "", nil, &statement)
+	client.expectStatus(resp, http.StatusOK)
+	if len(statement.Transactions) != 3 || statement.OpeningBalance.Decimal() != "1000.00" || statement.ClosingBalance.Decimal() != "1195.25" {
+		t.Errorf("Unexpected statement: %+v", statement)
+	}
+	if statement.Transactions[1].Fee.Decimal() != "5.00" {
+		t.Errorf("Expected the withdrawal fee on the statement, got %+v", statement.Transactions[1])
+	}
+
+	client.expectStatus(client.do("GET", path+"/statement?from=yesterday", "", nil, nil), http.StatusBadRequest)
+	client.expectStatus(client.do("GET", path+"/statement?from=2024-04-01T00:00:00Z&to=2024-03-01T00:00:00Z", "", nil, nil), http.StatusBadRequest)
+
+	if discrepancies, err := bank.Reconcile(); err != nil || len(discrepancies) != 0 {
+		t.Errorf("Expected the ledger to reconcile, got %v (%v)", discrepancies, err)
+	}
+}
+
+func TestServerIdempotency(t *testing.T) {
+	client, bank, _ := newAPIClient(t)
+	customer, _ := bank.CreateCustomer("Jane", "Smith", "456 Go Lane")
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+	path := "/accounts/" + checking.AccountNumber + "/deposits"
+	key := map[string]string{IdempotencyKeyHeader: "deposit-1"}
+
+	var first, retry accountView
+	client.expectStatus(client.do("POST", path, `{"amount":"40"}`, key, &first), http.StatusOK)
+	resp := client.do("POST", path, `{"amount":"40"}`, key, &retry)
+	client.expectStatus(resp, http.StatusOK)
+
+	if resp.Header.Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected the retry to be marked as replayed")
+	}
+	if !checking.GetBalance().Equal(usd("140")) || !retry.Balance.Equal(first.Balance) {
+		t.Errorf("Expected the deposit to happen once, balance is %v", checking.GetBalance())
+	}
+
+	// The same key can't be reused for a different request
+	resp = client.do("POST", path, `{"amount":"41"}`, key, nil)
+	client.expectStatus(resp, http.StatusUnprocessableEntity)
+
+	// Failures are replayed too, so a retry can't succeed after the account is funded
+	failKey := map[string]string{IdempotencyKeyHeader: "withdraw-1"}
+	withdrawals := "/accounts/" + checking.AccountNumber + "/withdrawals"
+	client.expectStatus(client.do("POST", withdrawals, `{"amount":"500"}`, failKey, nil), http.StatusUnprocessableEntity)
+	checking.Deposit(usd("1000"))
+	client.expectStatus(client.do("POST", withdrawals, `{"amount":"500"}`, failKey, nil), http.StatusUnprocessableEntity)
+
+	// Requests without a key are not deduplicated
+	client.do("POST", path, `{"amount":"40"}`, nil, nil)
+	client.do("POST", path, `{"amount":"40"}`, nil, nil)
+	if !checking.GetBalance().Equal(usd("1220")) {
+		t.Errorf("Expected two more deposits without a key, balance is %v", checking.GetBalance())
+	}
+}
+
+func TestServerIdempotencyExpires(t *testing.T) {
+	client, bank, clock := newAPIClient(t)
+	customer, _ := bank.CreateCustomer("Jane", "Smith", "456 Go Lane")
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("100"))
+	path := "/accounts/" + checking.AccountNumber + "/deposits"
+	key := map[string]string{IdempotencyKeyHeader: "deposit-1"}
+
+	client.expectStatus(client.do("POST", path, `{"amount":"40"}`, key, nil), http.StatusOK)
+	clock.Advance(23 * time.Hour)
+	resp := client.do("POST", path, `{"amount":"40"}`, key, nil)
+	if resp.Header.Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected a retry within a day to be replayed")
+	}
+
+	// After a day the key is forgotten and the request runs again
+	clock.Advance(2 * time.Hour)
+	resp = client.do("POST", path, `{"amount":"40"}`, key, nil)
+	client.expectStatus(resp, http.StatusOK)
+	if resp.Header.Get("Idempotent-Replayed") != "" || !checking.GetBalance().Equal(usd("180")) {
+		t.Errorf("Expected the expired key to deposit again, balance is %v", checking.GetBalance())
+	}
+}
+
+func TestServerIdempotencyAfterPanic(t *testing.T) {
+	bank := NewBank("Test Bank")
+	server := NewServer(bank)
+	request := func() *http.Request {
+		r := httptest.NewRequest("POST", "/transfers", strings.NewReader(`{}`))
+		r.Header.Set(IdempotencyKeyHeader, "transfer-1")
+		return r
+	}
+
+	panicking := server.idempotent(func(http.ResponseWriter, *http.Request) { panic("handler failed") })
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the handler's panic to propagate")
+			}
+		}()
+		panicking(httptest.NewRecorder(), request())
+	}()
+
+	// The key isn't stuck in progress, so a retry runs
+	ran := false
+	retry := httptest.NewRecorder()
+	server.idempotent(func(w http.ResponseWriter, r *http.Request) {
+		ran = true
+		w.WriteHeader(http.StatusOK)
+	})(retry, request())
+	if !ran || retry.Code != http.StatusOK {
+		t.Errorf("Expected the retry to run, got status %d", retry.Code)
+	}
+}
+
+func TestServerChunkedEmptyBody(t *testing.T) {
+	bank := NewBank("Test Bank")
+	server := NewServer(bank)
+	customer, _ := bank.CreateCustomer("Jane", "Smith", "456 Go Lane")
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("0"))
+	path := "/accounts/" + checking.AccountNumber
+
+	// A chunked request has no declared length, even when its body is empty
+	send := func(route, body string) int {
+		r := httptest.NewRequest("POST", path+route, io.NopCloser(strings.NewReader(body)))
+		r.ContentLength = -1
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := send("/freeze", ""); code != http.StatusOK {
+		t.Errorf("Expected freeze without a body to succeed, got %d", code)
+	}
+	if code := send("/unfreeze", `{"reason":"found card"}`); code != http.StatusOK {
+		t.Errorf("Expected unfreeze with a chunked body to succeed, got %d", code)
+	}
+	if code := send("/close", ""); code != http.StatusOK {
+		t.Errorf("Expected close without a body to succeed, got %d", code)
+	}
+	if checking.GetState() != StateClosed {
+		t.Errorf("Expected a closed account, got %s", checking.GetState())
+	}
+	if code := send("/reactivate", "{"); code != http.StatusBadRequest {
+		t.Errorf("Expected a malformed body to be rejected, got %d", code)
+	}
+}
+
+func TestStatusFor(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{ErrAccountNotFound, http.StatusNotFound},
+		{fmt.Errorf("%w: amount must be positive", ErrInvalidArgument), http.StatusBadRequest},
+		{ErrInvalidTransition, http.StatusConflict},
+		{errors.New("disk full"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if status := statusFor(tt.err); status != tt.status {
+			t.Errorf("statusFor(%v) = %d, expected %d", tt.err, status, tt.status)
+		}
+	}
+}
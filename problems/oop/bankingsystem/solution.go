@@ -9,12 +9,18 @@
 
 package bankingsystem
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
 
 // RunExample demonstrates the banking system implementation
 func RunExample() {
 	// Create a bank
 	bank := NewBank("Go Banking")
+	usd := func(amount string) money.Money { return money.MustParse(amount, "USD") }
 
 	// Add customers with accounts
 	customer, err := bank.CreateCustomer("John", "Doe", "123 Go Street")
@@ -23,13 +29,13 @@ func RunExample() {
 		return
 	}
 
-	checkingAccount, err := bank.OpenCheckingAccount(customer.ID, 1000.0)
+	checkingAccount, err := bank.OpenCheckingAccount(customer.ID, usd("1000"))
 	if err != nil {
 		fmt.Printf("Error creating checking account: %v\n", err)
 		return
 	}
 
-	savingsAccount, err := bank.OpenSavingsAccount(customer.ID, 5000.0)
+	savingsAccount, err := bank.OpenSavingsAccount(customer.ID, usd("5000"))
 	if err != nil {
 		fmt.Printf("Error creating savings account: %v\n", err)
 		return
@@ -38,43 +44,81 @@ func RunExample() {
 	fmt.Println("Banking System Example:")
 	fmt.Println("------------------------")
 	fmt.Printf("Customer: %s (ID: %s)\n", customer.GetFullName(), customer.ID)
-	fmt.Printf("Initial Checking Balance: $%.2f\n", checkingAccount.GetBalance())
-	fmt.Printf("Initial Savings Balance: $%.2f\n\n", savingsAccount.GetBalance())
+	fmt.Printf("Initial Checking Balance: %v\n", checkingAccount.GetBalance())
+	fmt.Printf("Initial Savings Balance: %v\n\n", savingsAccount.GetBalance())
 
 	// Perform operations
 	fmt.Println("Performing transactions:")
 	fmt.Println("- Depositing $500 to Checking")
-	checkingAccount.Deposit(500.0)
+	checkingAccount.Deposit(usd("500"))
 
 	fmt.Println("- Withdrawing $1000 from Savings")
-	if err := savingsAccount.Withdraw(1000.0); err != nil {
+	if err := savingsAccount.Withdraw(usd("1000")); err != nil {
 		fmt.Printf("  Error: %v\n", err)
 	}
 
 	fmt.Println("- Withdrawing $200 from Checking (plus $5 fee)")
-	if err := checkingAccount.Withdraw(200.0); err != nil {
+	if err := checkingAccount.Withdraw(usd("200")); err != nil {
 		fmt.Printf("  Error: %v\n", err)
 	}
 
 	// Show updated balances
 	fmt.Printf("\nUpdated Balances:\n")
-	fmt.Printf("Checking balance: $%.2f\n", checkingAccount.GetBalance())
-	fmt.Printf("Savings balance: $%.2f\n", savingsAccount.GetBalance())
+	fmt.Printf("Checking balance: %v\n", checkingAccount.GetBalance())
+	fmt.Printf("Savings balance: %v\n", savingsAccount.GetBalance())
 
 	// Apply monthly interest
 	fmt.Println("\nApplying monthly interest to Savings account...")
-	savingsAccount.ApplyMonthlyInterest()
-	fmt.Printf("Savings balance after interest: $%.2f\n", savingsAccount.GetBalance())
+	if err := savingsAccount.ApplyMonthlyInterest(); err != nil {
+		fmt.Printf("  Error: %v\n", err)
+	}
+	fmt.Printf("Savings balance after interest: %v\n", savingsAccount.GetBalance())
+
+	// A new month starts with a fresh withdrawal allowance
+	bank.ResetMonthlyCounters()
 
 	// Try exceeding withdrawal limits
 	fmt.Println("\nTesting withdrawal limits on Savings account:")
 	for i := 1; i <= 7; i++ {
-		err := savingsAccount.Withdraw(10.0)
+		err := savingsAccount.Withdraw(usd("10"))
 		if err != nil {
 			fmt.Printf("Withdrawal #%d: Error - %v\n", i, err)
 			break
 		}
-		fmt.Printf("Withdrawal #%d: $10 successfully withdrawn. New balance: $%.2f\n",
+		fmt.Printf("Withdrawal #%d: $10 successfully withdrawn. New balance: %v\n",
 			i, savingsAccount.GetBalance())
 	}
+
+	// Move money between the customer's accounts
+	fmt.Println("\nTransferring $100 from Checking to Savings (plus $5 fee)")
+	if err := bank.Transfer(checkingAccount.GetAccountNumber(), savingsAccount.GetAccountNumber(), usd("100")); err != nil {
+		fmt.Printf("  Error: %v\n", err)
+	}
+
+	// Print the checking account statement from the ledger
+	statement, err := bank.GetStatement(checkingAccount.GetAccountNumber(), time.Time{}, time.Now().Add(time.Minute))
+	if err != nil {
+		fmt.Printf("Error creating statement: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\nChecking statement for %s:\n", statement.AccountNumber)
+	for _, tx := range statement.Transactions {
+		fmt.Printf("  %s %-12s %9v fee %v balance %v\n", tx.ID, tx.Type, tx.Amount, tx.Fee, tx.BalanceAfter)
+	}
+	// Borrow on a credit line and close its first statement cycle
+	fmt.Println("\nDrawing $500 on a $2000 credit line")
+	creditLine, err := bank.OpenCreditLine(customer.ID, usd("2000"))
+	if err != nil {
+		fmt.Printf("Error opening credit line: %v\n", err)
+		return
+	}
+	creditLine.Withdraw(usd("500"))
+	if err := bank.ApplyMonthlyInterest(); err != nil {
+		fmt.Printf("  Error: %v\n", err)
+	}
+	fmt.Printf("Owed after month-end: %v, minimum payment due: %v\n", creditLine.Owed(), creditLine.MinimumPaymentDue)
+
+	discrepancies, err := bank.Reconcile()
+	fmt.Printf("Ledger reconciles: %v\n", err == nil && len(discrepancies) == 0)
 }
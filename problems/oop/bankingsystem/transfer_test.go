@@ -0,0 +1,123 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package bankingsystem
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+func TestTransfer(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("500"))
+
+	if err := bank.Transfer(checking.AccountNumber, savings.AccountNumber, usd("300")); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+
+	// The checking fee is charged on the source leg only
+	if !checking.GetBalance().Equal(usd("695")) || !savings.GetBalance().Equal(usd("800")) {
+		t.Errorf("Expected balances 695 and 800, got %v and %v", checking.GetBalance(), savings.GetBalance())
+	}
+
+	txs := bank.Ledger.Transactions(savings.AccountNumber)
+	in := txs[len(txs)-1]
+	if in.Type != TransactionTransferIn || in.Counterparty != checking.AccountNumber || !in.BalanceAfter.Equal(usd("800")) {
+		t.Errorf("Unexpected transfer record: %+v", in)
+	}
+	clearing, _ := bank.Ledger.Balance(LedgerTransferClearing)
+	if discrepancies, err := bank.Reconcile(); !clearing.IsZero() || err != nil || len(discrepancies) != 0 {
+		t.Error("Expected the ledger to reconcile after a transfer")
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		amount   money.Money
+	}{
+		{"insufficient funds including fee", checking.AccountNumber, savings.AccountNumber, usd("692")},
+		{"same account", checking.AccountNumber, checking.AccountNumber, usd("10")},
+		{"non-positive amount", checking.AccountNumber, savings.AccountNumber, usd("0")},
+		{"different currency", checking.AccountNumber, savings.AccountNumber, money.MustParse("10", "EUR")},
+		{"unknown source", "AC1", savings.AccountNumber, usd("10")},
+		{"unknown destination", checking.AccountNumber, "AC1", usd("10")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := bank.Transfer(tt.from, tt.to, tt.amount); err == nil {
+				t.Error("Expected transfer to fail")
+			}
+			if !checking.GetBalance().Equal(usd("695")) || !savings.GetBalance().Equal(usd("800")) {
+				t.Error("A failed transfer must not change any balance")
+			}
+		})
+	}
+}
+
+func TestTransferRespectsSavingsLimit(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	checking, _ := bank.OpenCheckingAccount(customer.ID, usd("0"))
+	savings, _ := bank.OpenSavingsAccount(customer.ID, usd("1000"))
+
+	for i := 0; i < savings.MaxWithdrawals; i++ {
+		if err := bank.Transfer(savings.AccountNumber, checking.AccountNumber, usd("10")); err != nil {
+			t.Fatalf("Transfer #%d failed: %v", i+1, err)
+		}
+	}
+
+	if err := bank.Transfer(savings.AccountNumber, checking.AccountNumber, usd("10")); err == nil {
+		t.Error("Expected the savings withdrawal limit to block the transfer")
+	}
+	if expected, _ := usd("10").Mul(int64(savings.MaxWithdrawals)); !checking.GetBalance().Equal(expected) {
+		t.Errorf("Unexpected checking balance %v", checking.GetBalance())
+	}
+}
+
+func TestConcurrentTransfers(t *testing.T) {
+	bank, customer, _ := newTestBank(t)
+	bank.CheckingFeeRate = usd("0")
+
+	accounts := make([]string, 4)
+	for i := range accounts {
+		account, _ := bank.OpenCheckingAccount(customer.ID, usd("1000"))
+		accounts[i] = account.AccountNumber
+	}
+
+	// Transfers run in both directions between every pair of accounts
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				from := accounts[(worker+i)%len(accounts)]
+				to := accounts[(worker+i+1+worker%3)%len(accounts)]
+				bank.Transfer(from, to, usd("7.01"))
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	total := money.Money{}
+	for _, number := range accounts {
+		account, _ := bank.GetAccount(number)
+		total, _ = total.Add(account.GetBalance())
+	}
+	if !total.Equal(usd("4000")) {
+		t.Errorf("Expected money to be conserved at 4000, got %v", total)
+	}
+	if discrepancies, err := bank.Reconcile(); err != nil || len(discrepancies) != 0 || !bank.Ledger.IsBalanced() {
+		t.Error("Expected the ledger to reconcile after concurrent transfers")
+	}
+}
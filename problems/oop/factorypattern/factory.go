@@ -12,157 +12,136 @@ package factorypattern
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
 )
 
-// PaymentFactory creates payment objects based on the requested type
+// DefaultCurrency is used when the parameters don't include a "currency"
+const DefaultCurrency = "USD"
+
+// PaymentFactory creates payment objects of the types in its registry
 type PaymentFactory struct {
-	// Could contain configuration or dependencies here
+	registry *Registry
 }
 
-// NewPaymentFactory creates a new payment factory
+// NewPaymentFactory creates a payment factory for the types in DefaultRegistry
 func NewPaymentFactory() *PaymentFactory {
-	return &PaymentFactory{}
+	return NewPaymentFactoryWithRegistry(DefaultRegistry)
+}
+
+// NewPaymentFactoryWithRegistry creates a payment factory for the types in a registry
+func NewPaymentFactoryWithRegistry(registry *Registry) *PaymentFactory {
+	return &PaymentFactory{registry: registry}
 }
 
 // CreatePayment creates a payment of the specified type with the given parameters
 func (f *PaymentFactory) CreatePayment(paymentType string, params map[string]interface{}) (Payment, error) {
-	paymentType = strings.ToLower(paymentType)
-
-	switch paymentType {
-	case "creditcard":
-		return f.createCreditCardPayment(params)
-	case "paypal":
-		return f.createPayPalPayment(params)
-	case "banktransfer":
-		return f.createBankTransferPayment(params)
-	default:
-		return nil, fmt.Errorf("unknown payment type: %s", paymentType)
-	}
+	return f.registry.Create(paymentType, params)
 }
 
-// createCreditCardPayment creates a credit card payment with parameters
-func (f *PaymentFactory) createCreditCardPayment(params map[string]interface{}) (Payment, error) {
-	payment := &CreditCardPayment{
-		BasePayment: BasePayment{},
-	}
+// SupportedTypes returns the payment types the factory can create, sorted by name
+func (f *PaymentFactory) SupportedTypes() []PaymentType {
+	return f.registry.Types()
+}
 
-	// Extract required parameters
-	for k, v := range params {
-		switch k {
-		case "amount":
-			if amount, ok := v.(float64); ok {
-				payment.Amount = amount
-			} else {
-				return nil, errors.New("amount must be a float64")
-			}
-		case "cardNumber":
-			if cardNumber, ok := v.(string); ok {
-				payment.CardNumber = cardNumber
-			} else {
-				return nil, errors.New("cardNumber must be a string")
-			}
-		case "expiryDate":
-			if expiryDate, ok := v.(string); ok {
-				payment.ExpiryDate = expiryDate
-			} else {
-				return nil, errors.New("expiryDate must be a string")
-			}
-		case "cvv":
-			if cvv, ok := v.(string); ok {
-				payment.CVV = cvv
-			} else {
-				return nil, errors.New("cvv must be a string")
-			}
-		case "cardHolder":
-			if cardHolder, ok := v.(string); ok {
-				payment.CardHolder = cardHolder
-			} else {
-				return nil, errors.New("cardHolder must be a string")
-			}
-		}
-	}
+// The built-in payment types
+func init() {
+	MustRegister(PaymentType{
+		Name:        "creditcard",
+		Description: "Credit card payment",
+		Params: []Param{
+			{Name: "cardNumber", Type: ParamString, Required: true, Description: "13 to 19 digits, spaces and dashes allowed"},
+			{Name: "expiryDate", Type: ParamString, Required: true, Description: "MM/YYYY"},
+			{Name: "cvv", Type: ParamString, Required: true, Description: "3 or 4 digits"},
+			{Name: "cardHolder", Type: ParamString, Description: "Name on the card"},
+		},
+		New: newCreditCardPayment,
+	})
+	MustRegister(PaymentType{
+		Name:        "paypal",
+		Description: "PayPal payment",
+		Params: []Param{
+			{Name: "email", Type: ParamString, Required: true, Description: "PayPal account email"},
+		},
+		New: newPayPalPayment,
+	})
+	MustRegister(PaymentType{
+		Name:        "banktransfer",
+		Description: "Bank transfer",
+		Params: []Param{
+			{Name: "accountName", Type: ParamString, Required: true, Description: "Name on the receiving account"},
+			{Name: "accountNumber", Type: ParamString, Required: true, Description: "5 to 20 characters"},
+			{Name: "bankCode", Type: ParamString, Required: true, Description: "3 to 11 characters"},
+		},
+		New: newBankTransferPayment,
+	})
+}
 
-	// Validate the payment
-	if err := payment.Validate(); err != nil {
-		return nil, err
+// newCreditCardPayment creates a credit card payment with parameters
+func newCreditCardPayment(amount money.Money, params map[string]interface{}) (Payment, error) {
+	payment := &CreditCardPayment{
+		BasePayment: BasePayment{Amount: amount},
 	}
-
+	payment.CardNumber, _ = params["cardNumber"].(string)
+	payment.ExpiryDate, _ = params["expiryDate"].(string)
+	payment.CVV, _ = params["cvv"].(string)
+	payment.CardHolder, _ = params["cardHolder"].(string)
 	return payment, nil
 }
 
-// createPayPalPayment creates a PayPal payment with parameters
-func (f *PaymentFactory) createPayPalPayment(params map[string]interface{}) (Payment, error) {
+// newPayPalPayment creates a PayPal payment with parameters
+func newPayPalPayment(amount money.Money, params map[string]interface{}) (Payment, error) {
 	payment := &PayPalPayment{
-		BasePayment: BasePayment{},
-	}
-
-	// Extract required parameters
-	for k, v := range params {
-		switch k {
-		case "amount":
-			if amount, ok := v.(float64); ok {
-				payment.Amount = amount
-			} else {
-				return nil, errors.New("amount must be a float64")
-			}
-		case "email":
-			if email, ok := v.(string); ok {
-				payment.Email = email
-			} else {
-				return nil, errors.New("email must be a string")
-			}
-		}
+		BasePayment: BasePayment{Amount: amount},
 	}
-
-	// Validate the payment
-	if err := payment.Validate(); err != nil {
-		return nil, err
-	}
-
+	payment.Email, _ = params["email"].(string)
 	return payment, nil
 }
 
-// createBankTransferPayment creates a bank transfer payment with parameters
-func (f *PaymentFactory) createBankTransferPayment(params map[string]interface{}) (Payment, error) {
+// newBankTransferPayment creates a bank transfer payment with parameters
+func newBankTransferPayment(amount money.Money, params map[string]interface{}) (Payment, error) {
 	payment := &BankTransferPayment{
-		BasePayment: BasePayment{},
+		BasePayment: BasePayment{Amount: amount},
 	}
+	payment.AccountName, _ = params["accountName"].(string)
+	payment.AccountNumber, _ = params["accountNumber"].(string)
+	payment.BankCode, _ = params["bankCode"].(string)
+	return payment, nil
+}
 
-	// Extract required parameters
-	for k, v := range params {
-		switch k {
-		case "amount":
-			if amount, ok := v.(float64); ok {
-				payment.Amount = amount
-			} else {
-				return nil, errors.New("amount must be a float64")
-			}
-		case "accountName":
-			if accountName, ok := v.(string); ok {
-				payment.AccountName = accountName
-			} else {
-				return nil, errors.New("accountName must be a string")
-			}
-		case "accountNumber":
-			if accountNumber, ok := v.(string); ok {
-				payment.AccountNumber = accountNumber
-			} else {
-				return nil, errors.New("accountNumber must be a string")
-			}
-		case "bankCode":
-			if bankCode, ok := v.(string); ok {
-				payment.BankCode = bankCode
-			} else {
-				return nil, errors.New("bankCode must be a string")
-			}
+// amountParam reads the "amount" parameter in the currency named by the
+// "currency" parameter. The amount may be a money.Money, a decimal string
+// such as "75.50", an int number of whole units or a float64, which is
+// rounded to the nearest minor unit. These are the same numbers a ParamNumber
+// parameter accepts.
+func amountParam(params map[string]interface{}) (money.Money, error) {
+	currency := DefaultCurrency
+	if v, ok := params["currency"]; ok {
+		code, ok := v.(string)
+		if !ok {
+			return money.Money{}, errors.New("currency must be a string")
 		}
+		currency = code
 	}
 
-	// Validate the payment
-	if err := payment.Validate(); err != nil {
-		return nil, err
+	switch amount := params["amount"].(type) {
+	case nil:
+		return money.Money{}, nil
+	case money.Money:
+		// An explicit currency must agree with the amount's own
+		if _, ok := params["currency"]; ok && !strings.EqualFold(amount.Currency(), currency) {
+			return money.Money{}, fmt.Errorf("amount is in %s but currency is %s", amount.Currency(), currency)
+		}
+		return amount, nil
+	case string:
+		return money.Parse(amount, currency)
+	case int:
+		return money.Parse(strconv.Itoa(amount), currency)
+	case float64:
+		return money.FromFloat(amount, currency)
+	default:
+		return money.Money{}, errors.New("amount must be a money.Money, decimal string or number")
 	}
-
-	return payment, nil
 }
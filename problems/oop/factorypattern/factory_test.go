@@ -11,6 +11,8 @@ package factorypattern
 
 import (
 	"testing"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
 )
 
 func TestPaymentFactory(t *testing.T) {
@@ -35,8 +37,8 @@ func TestPaymentFactory(t *testing.T) {
 			t.Fatal("Expected payment to be created, got nil")
 		}
 
-		if payment.GetAmount() != 100.0 {
-			t.Errorf("Expected amount 100.0, got: %.2f", payment.GetAmount())
+		if !payment.GetAmount().Equal(money.MustParse("100", "USD")) {
+			t.Errorf("Expected amount 100.0, got: %v", payment.GetAmount())
 		}
 	})
 
@@ -71,8 +73,8 @@ func TestPaymentFactory(t *testing.T) {
 			t.Fatal("Expected payment to be created, got nil")
 		}
 
-		if payment.GetAmount() != 75.50 {
-			t.Errorf("Expected amount 75.50, got: %.2f", payment.GetAmount())
+		if !payment.GetAmount().Equal(money.MustParse("75.50", "USD")) {
+			t.Errorf("Expected amount 75.50, got: %v", payment.GetAmount())
 		}
 	})
 
@@ -107,8 +109,8 @@ func TestPaymentFactory(t *testing.T) {
 			t.Fatal("Expected payment to be created, got nil")
 		}
 
-		if payment.GetAmount() != 500.0 {
-			t.Errorf("Expected amount 500.0, got: %.2f", payment.GetAmount())
+		if !payment.GetAmount().Equal(money.MustParse("500", "USD")) {
+			t.Errorf("Expected amount 500.0, got: %v", payment.GetAmount())
 		}
 	})
 
@@ -123,3 +125,44 @@ func TestPaymentFactory(t *testing.T) {
 		}
 	})
 }
+
+func TestPaymentAmounts(t *testing.T) {
+	factory := NewPaymentFactory()
+
+	tests := []struct {
+		name     string
+		params   map[string]interface{}
+		expected money.Money
+		wantErr  bool
+	}{
+		{"float is rounded to cents", map[string]interface{}{"amount": 0.1 + 0.2}, money.MustParse("0.30", "USD"), false},
+		{"decimal string", map[string]interface{}{"amount": "19.99"}, money.MustParse("19.99", "USD"), false},
+		{"explicit currency", map[string]interface{}{"amount": "1500", "currency": "JPY"}, money.MustParse("1500", "JPY"), false},
+		{"money value", map[string]interface{}{"amount": money.MustParse("12.34", "EUR")}, money.MustParse("12.34", "EUR"), false},
+		{"too many decimals", map[string]interface{}{"amount": "1.005"}, money.Money{}, true},
+		{"unknown currency", map[string]interface{}{"amount": "10", "currency": "XYZ"}, money.Money{}, true},
+		{"conflicting currency", map[string]interface{}{"amount": money.MustParse("10", "EUR"), "currency": "USD"}, money.Money{}, true},
+		{"whole number", map[string]interface{}{"amount": 10}, money.MustParse("10", "USD"), false},
+		{"wrong type", map[string]interface{}{"amount": true}, money.Money{}, true},
+		{"missing amount", map[string]interface{}{}, money.Money{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params["email"] = "user@example.com"
+			payment, err := factory.CreatePayment("paypal", tt.params)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got payment of %v", payment.GetAmount())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !payment.GetAmount().Equal(tt.expected) {
+				t.Errorf("Expected amount %v, got: %v", tt.expected, payment.GetAmount())
+			}
+		})
+	}
+}
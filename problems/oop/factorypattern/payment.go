@@ -14,22 +14,24 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
 )
 
 // Payment interface defines common methods for all payment types
 type Payment interface {
 	Validate() error
 	Process() error
-	GetAmount() float64
+	GetAmount() money.Money
 }
 
 // BasePayment contains common fields and methods for all payment types
 type BasePayment struct {
-	Amount float64
+	Amount money.Money
 }
 
 // GetAmount returns the payment amount
-func (p *BasePayment) GetAmount() float64 {
+func (p *BasePayment) GetAmount() money.Money {
 	return p.Amount
 }
 
@@ -44,7 +46,7 @@ type CreditCardPayment struct {
 
 // Validate checks if the credit card payment information is valid
 func (p *CreditCardPayment) Validate() error {
-	if p.Amount <= 0 {
+	if !p.Amount.IsPositive() {
 		return errors.New("payment amount must be positive")
 	}
 
@@ -82,7 +84,7 @@ func (p *CreditCardPayment) Process() error {
 	cardNumber = strings.ReplaceAll(cardNumber, " ", "")
 	maskedCard := cardNumber[:4] + "-****-****-" + cardNumber[len(cardNumber)-4:]
 
-	fmt.Printf("Processing Credit Card payment of $%.2f with card number: %s\n",
+	fmt.Printf("Processing Credit Card payment of %v with card number: %s\n",
 		p.Amount, maskedCard)
 
 	// In a real system, we would integrate with a payment gateway here
@@ -97,7 +99,7 @@ type PayPalPayment struct {
 
 // Validate checks if the PayPal payment information is valid
 func (p *PayPalPayment) Validate() error {
-	if p.Amount <= 0 {
+	if !p.Amount.IsPositive() {
 		return errors.New("payment amount must be positive")
 	}
 
@@ -112,7 +114,7 @@ func (p *PayPalPayment) Validate() error {
 
 // Process handles the PayPal payment processing
 func (p *PayPalPayment) Process() error {
-	fmt.Printf("Processing PayPal payment of $%.2f with email: %s\n",
+	fmt.Printf("Processing PayPal payment of %v with email: %s\n",
 		p.Amount, p.Email)
 
 	// In a real system, we would integrate with PayPal's API here
@@ -129,7 +131,7 @@ type BankTransferPayment struct {
 
 // Validate checks if the bank transfer information is valid
 func (p *BankTransferPayment) Validate() error {
-	if p.Amount <= 0 {
+	if !p.Amount.IsPositive() {
 		return errors.New("payment amount must be positive")
 	}
 
@@ -152,7 +154,7 @@ func (p *BankTransferPayment) Validate() error {
 
 // Process handles the bank transfer payment processing
 func (p *BankTransferPayment) Process() error {
-	fmt.Printf("Processing Bank Transfer of $%.2f to account: %s (Account number: %s, Bank code: %s)\n",
+	fmt.Printf("Processing Bank Transfer of %v to account: %s (Account number: %s, Bank code: %s)\n",
 		p.Amount, p.AccountName, p.AccountNumber, p.BankCode)
 
 	// In a real system, we would integrate with a banking API here
@@ -0,0 +1,247 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package factorypattern
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+var (
+	// ErrUnknownPaymentType is returned when no payment type has the requested name
+	ErrUnknownPaymentType = errors.New("unknown payment type")
+	// ErrDuplicatePaymentType is returned when registering a name that is already taken
+	ErrDuplicatePaymentType = errors.New("payment type already registered")
+	// ErrInvalidParams is returned when parameters don't match a payment type's schema
+	ErrInvalidParams = errors.New("invalid payment parameters")
+)
+
+// ParamType is the kind of value a payment parameter holds
+type ParamType string
+
+const (
+	ParamString ParamType = "string"
+	ParamNumber ParamType = "number" // A float64 or int
+	ParamBool   ParamType = "bool"
+)
+
+// Param describes one parameter a payment type accepts
+type Param struct {
+	Name        string
+	Type        ParamType
+	Required    bool
+	Description string
+}
+
+// Constructor builds a payment from its amount and parameters. The registry
+// has already checked the parameters against the schema and validates the
+// payment afterwards.
+type Constructor func(amount money.Money, params map[string]interface{}) (Payment, error)
+
+// PaymentType is a payment method that can be registered with a Registry.
+// Every type also accepts the common "amount" and "currency" parameters.
+type PaymentType struct {
+	Name        string
+	Description string
+	Params      []Param
+	New         Constructor
+}
+
+// commonParams are accepted by every payment type and can't be redeclared
+var commonParams = map[string]bool{"amount": true, "currency": true}
+
+// Registry holds the payment types a factory can create. It is safe for
+// concurrent use.
+type Registry struct {
+	types map[string]PaymentType
+	mu    sync.RWMutex
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{types: make(map[string]PaymentType)}
+}
+
+// DefaultRegistry holds the built-in payment types and any registered with Register
+var DefaultRegistry = NewRegistry()
+
+// Register adds a payment type to the default registry. Packages providing
+// payment methods typically call it from an init function.
+func Register(paymentType PaymentType) error {
+	return DefaultRegistry.Register(paymentType)
+}
+
+// MustRegister is like Register but panics if the type can't be registered
+func MustRegister(paymentType PaymentType) {
+	if err := Register(paymentType); err != nil {
+		panic(err)
+	}
+}
+
+// Register adds a payment type. Names are case-insensitive.
+func (r *Registry) Register(paymentType PaymentType) error {
+	if err := paymentType.validate(); err != nil {
+		return fmt.Errorf("payment type %q: %w", paymentType.Name, err)
+	}
+
+	name := strings.ToLower(paymentType.Name)
+	paymentType.Name = name
+	paymentType.Params = append([]Param(nil), paymentType.Params...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.types[name]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicatePaymentType, name)
+	}
+	r.types[name] = paymentType
+	return nil
+}
+
+// Lookup returns the payment type with a name
+func (r *Registry) Lookup(name string) (PaymentType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	paymentType, exists := r.types[strings.ToLower(name)]
+	if !exists {
+		return PaymentType{}, fmt.Errorf("%w: %s", ErrUnknownPaymentType, name)
+	}
+	paymentType.Params = append([]Param(nil), paymentType.Params...)
+	return paymentType, nil
+}
+
+// Types returns every registered payment type, sorted by name
+func (r *Registry) Types() []PaymentType {
+	r.mu.RLock()
+	types := make([]PaymentType, 0, len(r.types))
+	for _, paymentType := range r.types {
+		paymentType.Params = append([]Param(nil), paymentType.Params...)
+		types = append(types, paymentType)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+	return types
+}
+
+// Create checks the parameters against the payment type's schema, builds the
+// payment and validates it
+func (r *Registry) Create(name string, params map[string]interface{}) (Payment, error) {
+	paymentType, err := r.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := paymentType.checkParams(params); err != nil {
+		return nil, err
+	}
+	amount, err := amountParam(params)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := paymentType.New(amount, params)
+	if err != nil {
+		return nil, err
+	}
+	if isNil(payment) {
+		return nil, fmt.Errorf("payment type %q: constructor returned no payment", paymentType.Name)
+	}
+	if err := payment.Validate(); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// isNil reports whether a constructor returned no payment, including a nil
+// pointer of a payment type
+func isNil(payment Payment) bool {
+	if payment == nil {
+		return true
+	}
+	value := reflect.ValueOf(payment)
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
+
+// validate checks that a payment type can be registered
+func (t PaymentType) validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("name is required")
+	}
+	if t.New == nil {
+		return errors.New("constructor is required")
+	}
+
+	names := make(map[string]bool)
+	for _, param := range t.Params {
+		switch {
+		case param.Name == "":
+			return errors.New("parameter name is required")
+		case commonParams[param.Name]:
+			return fmt.Errorf("parameter %q is common to every payment type", param.Name)
+		case names[param.Name]:
+			return fmt.Errorf("duplicate parameter %q", param.Name)
+		}
+		switch param.Type {
+		case ParamString, ParamNumber, ParamBool:
+		default:
+			return fmt.Errorf("parameter %q has unknown type %q", param.Name, param.Type)
+		}
+		names[param.Name] = true
+	}
+	return nil
+}
+
+// checkParams rejects missing required parameters, values of the wrong type
+// and parameters the payment type doesn't declare
+func (t PaymentType) checkParams(params map[string]interface{}) error {
+	declared := make(map[string]Param, len(t.Params))
+	for _, param := range t.Params {
+		declared[param.Name] = param
+
+		value, present := params[param.Name]
+		if !present {
+			if param.Required {
+				return fmt.Errorf("%w: %s is required", ErrInvalidParams, param.Name)
+			}
+			continue
+		}
+		if !param.Type.matches(value) {
+			return fmt.Errorf("%w: %s must be a %s", ErrInvalidParams, param.Name, param.Type)
+		}
+	}
+
+	for name := range params {
+		if _, ok := declared[name]; !ok && !commonParams[name] {
+			return fmt.Errorf("%w: %s payments don't take %s", ErrInvalidParams, t.Name, name)
+		}
+	}
+	return nil
+}
+
+// matches reports whether a value has the parameter type
+func (p ParamType) matches(value interface{}) bool {
+	switch value.(type) {
+	case string:
+		return p == ParamString
+	case float64, int:
+		return p == ParamNumber
+	case bool:
+		return p == ParamBool
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package factorypattern
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yasin-yalcin-dev/Backend-Interview-Challenges/problems/oop/money"
+)
+
+// giftCardPayment is a payment method defined outside the factory
+type giftCardPayment struct {
+	BasePayment
+	Code    string
+	Balance float64
+	Partial bool
+}
+
+func (p *giftCardPayment) Validate() error {
+	if len(p.Code) != 8 {
+		return errors.New("gift card code must be 8 characters")
+	}
+	return nil
+}
+
+func (p *giftCardPayment) Process() error { return nil }
+
+// giftCardType describes giftCardPayment for registration
+var giftCardType = PaymentType{
+	Name:        "GiftCard",
+	Description: "Store gift card",
+	Params: []Param{
+		{Name: "code", Type: ParamString, Required: true},
+		{Name: "balance", Type: ParamNumber},
+		{Name: "partial", Type: ParamBool},
+	},
+	New: func(amount money.Money, params map[string]interface{}) (Payment, error) {
+		payment := &giftCardPayment{BasePayment: BasePayment{Amount: amount}}
+		payment.Code, _ = params["code"].(string)
+		payment.Partial, _ = params["partial"].(bool)
+		switch balance := params["balance"].(type) {
+		case float64:
+			payment.Balance = balance
+		case int:
+			payment.Balance = float64(balance)
+		}
+		return payment, nil
+	},
+}
+
+func TestRegisterPaymentType(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(giftCardType); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	factory := NewPaymentFactoryWithRegistry(registry)
+
+	payment, err := factory.CreatePayment("giftcard", map[string]interface{}{
+		"code": "GC123456", "balance": 50, "partial": true, "amount": "20",
+	})
+	if err != nil {
+		t.Fatalf("CreatePayment failed: %v", err)
+	}
+	giftCard, ok := payment.(*giftCardPayment)
+	if !ok || giftCard.Code != "GC123456" || giftCard.Balance != 50 || !giftCard.Partial {
+		t.Errorf("Unexpected payment: %+v", payment)
+	}
+	if !payment.GetAmount().Equal(money.MustParse("20", "USD")) {
+		t.Errorf("Expected amount $20, got: %v", payment.GetAmount())
+	}
+
+	// The payment's own validation still runs
+	if _, err := factory.CreatePayment("GIFTCARD", map[string]interface{}{"code": "short", "amount": "20"}); err == nil {
+		t.Error("Expected an invalid gift card code to be rejected")
+	}
+	// Types registered elsewhere aren't in this registry
+	if _, err := factory.CreatePayment("paypal", map[string]interface{}{"email": "user@example.com", "amount": "1"}); !errors.Is(err, ErrUnknownPaymentType) {
+		t.Errorf("Expected ErrUnknownPaymentType, got: %v", err)
+	}
+}
+
+func TestRegisterRejectsInvalidTypes(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(giftCardType)
+
+	if err := registry.Register(giftCardType); !errors.Is(err, ErrDuplicatePaymentType) {
+		t.Errorf("Expected ErrDuplicatePaymentType, got: %v", err)
+	}
+
+	invalid := map[string]PaymentType{
+		"missing name":        {New: giftCardType.New},
+		"missing constructor": {Name: "crypto"},
+		"reserved parameter":  {Name: "crypto", New: giftCardType.New, Params: []Param{{Name: "amount", Type: ParamNumber}}},
+		"duplicate parameter": {Name: "crypto", New: giftCardType.New, Params: []Param{{Name: "wallet", Type: ParamString}, {Name: "wallet", Type: ParamString}}},
+		"unknown type":        {Name: "crypto", New: giftCardType.New, Params: []Param{{Name: "wallet", Type: "address"}}},
+	}
+	for name, paymentType := range invalid {
+		if err := registry.Register(paymentType); err == nil {
+			t.Errorf("Expected a type with a %s to be rejected", name)
+		}
+	}
+	if len(registry.Types()) != 1 {
+		t.Errorf("Expected only the gift card type to be registered, got %v", registry.Types())
+	}
+}
+
+func TestCreateRejectsNilPayment(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(PaymentType{
+		Name: "broken",
+		New:  func(money.Money, map[string]interface{}) (Payment, error) { return nil, nil },
+	})
+	registry.Register(PaymentType{
+		Name: "brokenpointer",
+		New: func(money.Money, map[string]interface{}) (Payment, error) {
+			var payment *giftCardPayment
+			return payment, nil
+		},
+	})
+
+	for _, name := range []string{"broken", "brokenpointer"} {
+		if payment, err := registry.Create(name, map[string]interface{}{"amount": "10"}); err == nil {
+			t.Errorf("Expected %s to fail, got %v", name, payment)
+		}
+	}
+}
+
+func TestParamsSchema(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(giftCardType)
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		message string
+	}{
+		{"missing required", map[string]interface{}{"amount": "10"}, "code is required"},
+		{"wrong type", map[string]interface{}{"code": 12345678, "amount": "10"}, "code must be a string"},
+		{"wrong number type", map[string]interface{}{"code": "GC123456", "balance": "50", "amount": "10"}, "balance must be a number"},
+		{"undeclared", map[string]interface{}{"code": "GC123456", "pin": "1234", "amount": "10"}, "don't take pin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := registry.Create("giftcard", tt.params)
+			if !errors.Is(err, ErrInvalidParams) || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected ErrInvalidParams mentioning %q, got: %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestSupportedTypes(t *testing.T) {
+	names := make([]string, 0)
+	for _, paymentType := range NewPaymentFactory().SupportedTypes() {
+		names = append(names, paymentType.Name)
+	}
+	if strings.Join(names, ",") != "banktransfer,creditcard,paypal" {
+		t.Errorf("Expected the built-in types in order, got %v", names)
+	}
+
+	creditCard, err := DefaultRegistry.Lookup("CreditCard")
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	required := make([]string, 0)
+	for _, param := range creditCard.Params {
+		if param.Required {
+			required = append(required, param.Name)
+		}
+	}
+	if strings.Join(required, ",") != "cardNumber,expiryDate,cvv" {
+		t.Errorf("Expected the credit card's required parameters, got %v", required)
+	}
+
+	// Changing a returned schema doesn't change the registry's
+	creditCard.Params[0].Required = false
+	if again, _ := DefaultRegistry.Lookup("creditcard"); !again.Params[0].Required {
+		t.Error("Expected the registered schema to be unchanged")
+	}
+}
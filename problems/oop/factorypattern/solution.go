@@ -9,7 +9,10 @@
 
 package factorypattern
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // RunExample demonstrates the factory pattern implementation
 func RunExample() {
@@ -19,6 +22,18 @@ func RunExample() {
 	// Create a payment factory
 	paymentFactory := NewPaymentFactory()
 
+	fmt.Println("Supported payment types:")
+	for _, paymentType := range paymentFactory.SupportedTypes() {
+		params := make([]string, 0, len(paymentType.Params))
+		for _, param := range paymentType.Params {
+			if param.Required {
+				params = append(params, param.Name)
+			}
+		}
+		fmt.Printf("  %-13s %s (requires %s)\n", paymentType.Name, paymentType.Description, strings.Join(params, ", "))
+	}
+	fmt.Println()
+
 	// Create and process a credit card payment
 	creditCardParams := map[string]interface{}{
 		"cardNumber": "4111-1111-1111-1111",
@@ -39,7 +54,7 @@ func RunExample() {
 	// Create and process a PayPal payment
 	payPalParams := map[string]interface{}{
 		"email":  "user@example.com",
-		"amount": 75.50,
+		"amount": "75.50", // Decimal strings are exact; floats are rounded to cents
 	}
 
 	fmt.Println("\nCreating a PayPal payment...")
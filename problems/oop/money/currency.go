@@ -0,0 +1,55 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package money
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Currency describes an ISO 4217 currency
+type Currency struct {
+	Code       string
+	MinorUnits int // Number of decimal places, such as 2 for cents
+	Symbol     string
+}
+
+// currencies lists the supported currencies by code
+var currencies = map[string]Currency{
+	"USD": {Code: "USD", MinorUnits: 2, Symbol: "$"},
+	"EUR": {Code: "EUR", MinorUnits: 2, Symbol: "€"},
+	"GBP": {Code: "GBP", MinorUnits: 2, Symbol: "£"},
+	"JPY": {Code: "JPY", MinorUnits: 0, Symbol: "¥"},
+	"TRY": {Code: "TRY", MinorUnits: 2, Symbol: "₺"},
+	"CHF": {Code: "CHF", MinorUnits: 2},
+	"CAD": {Code: "CAD", MinorUnits: 2},
+	"AUD": {Code: "AUD", MinorUnits: 2},
+	"KWD": {Code: "KWD", MinorUnits: 3},
+}
+
+// LookupCurrency returns the currency with the given code
+func LookupCurrency(code string) (Currency, error) {
+	c, exists := currencies[strings.ToUpper(code)]
+	if !exists {
+		return Currency{}, fmt.Errorf("unknown currency %q", code)
+	}
+	return c, nil
+}
+
+// Currencies returns the supported currency codes in sorted order
+func Currencies() []string {
+	codes := make([]string, 0, len(currencies))
+	for code := range currencies {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
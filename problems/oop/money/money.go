@@ -0,0 +1,432 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when combining amounts in different currencies
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// ErrOverflow is returned when a result doesn't fit in 64-bit minor units
+var ErrOverflow = errors.New("money amount overflow")
+
+// Money is an exact amount in the minor units (such as cents) of a currency.
+// The zero value is a currency-less zero that can be added to or compared
+// with an amount in any currency.
+type Money struct {
+	amount   int64
+	currency string
+}
+
+// New creates an amount from minor units, so New(1050, "USD") is $10.50
+func New(minorUnits int64, currency string) (Money, error) {
+	if _, err := LookupCurrency(currency); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minorUnits, currency: strings.ToUpper(currency)}, nil
+}
+
+// MustNew is like New but panics on an unknown currency. It is meant for
+// constants in code and tests.
+func MustNew(minorUnits int64, currency string) Money {
+	m, err := New(minorUnits, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Zero returns a zero amount in a currency
+func Zero(currency string) (Money, error) {
+	return New(0, currency)
+}
+
+// Parse reads a decimal amount such as "1295.5" or "-0.05". Amounts with
+// more decimal places than the currency allows are rejected rather than
+// rounded.
+func Parse(s, currency string) (Money, error) {
+	c, err := LookupCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	text := strings.TrimSpace(s)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(text, ".")
+	if whole == "" && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if len(fraction) > c.MinorUnits {
+		return Money{}, fmt.Errorf("amount %q has more than %d decimal places for %s", s, c.MinorUnits, c.Code)
+	}
+	fraction += strings.Repeat("0", c.MinorUnits-len(fraction))
+
+	units, ok := new(big.Int).SetString("0"+whole+fraction, 10)
+	if !ok || !units.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+
+	amount := units.Int64()
+	if negative {
+		amount = -amount
+	}
+	return Money{amount: amount, currency: c.Code}, nil
+}
+
+// MustParse is like Parse but panics on error. It is meant for constants in code and tests.
+func MustParse(s, currency string) Money {
+	m, err := Parse(s, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// isDigits reports whether s contains only ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FromFloat converts a float amount such as 10.5, rounding to the nearest
+// minor unit with ties to even. Use it only at boundaries where amounts
+// arrive as floats.
+func FromFloat(f float64, currency string) (Money, error) {
+	c, err := LookupCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Money{}, fmt.Errorf("invalid amount %v", f)
+	}
+
+	exact := new(big.Rat)
+	exact.SetFloat64(f)
+	exact.Mul(exact, new(big.Rat).SetInt(pow10(c.MinorUnits)))
+
+	units, err := roundHalfEven(exact)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: units, currency: c.Code}, nil
+}
+
+// MinorUnits returns the amount in minor units
+func (m Money) MinorUnits() int64 {
+	return m.amount
+}
+
+// Currency returns the ISO 4217 currency code, or "" for the zero value
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// IsNegative reports whether the amount is less than zero
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// common returns the currency two amounts share. A currency-less zero
+// takes the currency of the other amount.
+func common(a, b Money) (string, error) {
+	switch {
+	case a.currency == b.currency:
+		return a.currency, nil
+	case a.currency == "" && a.amount == 0:
+		return b.currency, nil
+	case b.currency == "" && b.amount == 0:
+		return a.currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, a.currency, b.currency)
+	}
+}
+
+// Add returns m + other
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := common(m, other)
+	if err != nil {
+		return Money{}, err
+	}
+
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: sum, currency: currency}, nil
+}
+
+// Sub returns m - other
+func (m Money) Sub(other Money) (Money, error) {
+	if other.amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(other.Neg())
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Abs returns the absolute value of m
+func (m Money) Abs() Money {
+	if m.amount < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Mul returns m multiplied by a whole number
+func (m Money) Mul(n int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(n))
+	if !product.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: product.Int64(), currency: m.currency}, nil
+}
+
+// MulRate returns m multiplied by a rate, rounded to the nearest minor unit
+// with ties to even (banker's rounding), so repeated rounding doesn't drift
+// in one direction
+func (m Money) MulRate(rate Rate) (Money, error) {
+	product := new(big.Rat).SetInt64(m.amount)
+	product.Mul(product, rate.rat())
+
+	units, err := roundHalfEven(product)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: units, currency: m.currency}, nil
+}
+
+// Convert returns m in another currency at an exchange rate giving units of
+// that currency per unit of m's currency, rounded to the nearest minor unit
+// with ties to even. A currency-less zero converts to a zero in the currency.
+func (m Money) Convert(currency string, rate Rate) (Money, error) {
+	to, err := LookupCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	if m.currency == "" {
+		return Money{currency: to.Code}, nil
+	}
+	from, err := LookupCurrency(m.currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	// Scale from the source's minor units to the target's
+	value := new(big.Rat).SetInt64(m.amount)
+	value.Mul(value, rate.rat())
+	value.Mul(value, new(big.Rat).SetFrac(pow10(to.MinorUnits), pow10(from.MinorUnits)))
+
+	units, err := roundHalfEven(value)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: units, currency: to.Code}, nil
+}
+
+// Div returns m divided by a whole number, rounded to the nearest minor unit
+// with ties to even
+func (m Money) Div(n int64) (Money, error) {
+	if n == 0 {
+		return Money{}, errors.New("division by zero")
+	}
+
+	units, err := roundHalfEven(new(big.Rat).SetFrac(big.NewInt(m.amount), big.NewInt(n)))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: units, currency: m.currency}, nil
+}
+
+// Allocate splits m in proportion to the given ratios without losing any
+// minor units: the remainder is handed out one unit at a time from the first
+// share onwards
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	total := int64(0)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, errors.New("allocation ratios must not be negative")
+		}
+		total += r
+	}
+	if total == 0 {
+		return nil, errors.New("allocation ratios must not all be zero")
+	}
+
+	shares := make([]Money, len(ratios))
+	remainder := m.amount
+	for i, r := range ratios {
+		share := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(r))
+		share.Quo(share, big.NewInt(total))
+		shares[i] = Money{amount: share.Int64(), currency: m.currency}
+		remainder -= share.Int64()
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].amount += step
+		remainder -= step
+	}
+
+	return shares, nil
+}
+
+// Compare returns -1, 0 or 1 as m is less than, equal to or greater than other
+func (m Money) Compare(other Money) (int, error) {
+	if _, err := common(m, other); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Equal reports whether two amounts are the same amount in the same currency
+func (m Money) Equal(other Money) bool {
+	cmp, err := m.Compare(other)
+	return err == nil && cmp == 0
+}
+
+// Decimal returns the amount as a plain decimal string such as "-1295.50"
+func (m Money) Decimal() string {
+	digits := 2
+	if c, err := LookupCurrency(m.currency); err == nil {
+		digits = c.MinorUnits
+	}
+
+	sign := ""
+	units := new(big.Int).SetInt64(m.amount)
+	if units.Sign() < 0 {
+		sign = "-"
+		units.Neg(units)
+	}
+	if digits == 0 {
+		return sign + units.String()
+	}
+
+	whole, fraction := new(big.Int).QuoRem(units, pow10(digits), new(big.Int))
+	return fmt.Sprintf("%s%s.%0*d", sign, whole, digits, fraction)
+}
+
+// String formats the amount with its currency symbol, such as "$1295.50" or "-€5.00"
+func (m Money) String() string {
+	decimal := m.Decimal()
+	sign := ""
+	if strings.HasPrefix(decimal, "-") {
+		sign, decimal = "-", decimal[1:]
+	}
+
+	c, err := LookupCurrency(m.currency)
+	if err != nil {
+		return sign + decimal
+	}
+	if c.Symbol == "" {
+		return sign + decimal + " " + c.Code
+	}
+	return sign + c.Symbol + decimal
+}
+
+// jsonMoney is the JSON form of an amount; the decimal is a string so it
+// survives JSON number parsing exactly
+type jsonMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as {"amount": "12.34", "currency": "USD"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.Decimal(), Currency: m.currency})
+}
+
+// UnmarshalJSON decodes the form written by MarshalJSON
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v jsonMoney
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Currency == "" {
+		if v.Amount != "" && strings.Trim(v.Amount, "-+0.") != "" {
+			return errors.New("money amount requires a currency")
+		}
+		*m = Money{}
+		return nil
+	}
+
+	parsed, err := Parse(v.Amount, v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// pow10 returns 10^n as a big integer
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundHalfEven rounds an exact value to the nearest integer, ties to even
+func roundHalfEven(x *big.Rat) (int64, error) {
+	quotient, remainder := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+
+	// Compare twice the remainder with the denominator to find the nearest integer
+	twice := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	switch cmp := twice.Cmp(x.Denom()); {
+	case cmp > 0, cmp == 0 && quotient.Bit(0) == 1:
+		if x.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return quotient.Int64(), nil
+}
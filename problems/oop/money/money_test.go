@@ -0,0 +1,246 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseAndFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency string
+		units    int64
+		decimal  string
+		display  string
+	}{
+		{"1295", "USD", 129500, "1295.00", "$1295.00"},
+		{"10.5", "usd", 1050, "10.50", "$10.50"},
+		{"-0.05", "EUR", -5, "-0.05", "-€0.05"},
+		{".75", "GBP", 75, "0.75", "£0.75"},
+		{"1500", "JPY", 1500, "1500", "¥1500"},
+		{"1.234", "KWD", 1234, "1.234", "1.234 KWD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input+tt.currency, func(t *testing.T) {
+			m, err := Parse(tt.input, tt.currency)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if m.MinorUnits() != tt.units || m.Decimal() != tt.decimal || m.String() != tt.display {
+				t.Errorf("Expected %d / %s / %s, got %d / %s / %s",
+					tt.units, tt.decimal, tt.display, m.MinorUnits(), m.Decimal(), m.String())
+			}
+		})
+	}
+
+	for _, bad := range [][2]string{{"1.005", "USD"}, {"1.5", "JPY"}, {"abc", "USD"}, {"", "USD"}, {"1", "XXX"}, {"99999999999999999999", "USD"}} {
+		if _, err := Parse(bad[0], bad[1]); err == nil {
+			t.Errorf("Parse(%q, %q) should fail", bad[0], bad[1])
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := MustParse("10.25", "USD")
+	b := MustParse("0.75", "USD")
+
+	sum, err := a.Add(b)
+	if err != nil || !sum.Equal(MustParse("11", "USD")) {
+		t.Errorf("Expected $11.00, got %v (err: %v)", sum, err)
+	}
+	diff, _ := b.Sub(a)
+	if !diff.Equal(MustParse("-9.5", "USD")) || !diff.IsNegative() || !diff.Abs().IsPositive() {
+		t.Errorf("Expected -$9.50, got %v", diff)
+	}
+	product, _ := b.Mul(3)
+	if product.MinorUnits() != 225 {
+		t.Errorf("Expected 225 cents, got %d", product.MinorUnits())
+	}
+
+	if _, err := a.Add(MustParse("1", "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+	if _, err := a.Compare(MustParse("1", "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+
+	// The zero value works with any currency
+	var zero Money
+	if total, err := zero.Add(a); err != nil || !total.Equal(a) {
+		t.Errorf("Expected zero + a = a, got %v (err: %v)", total, err)
+	}
+	if cmp, err := zero.Compare(a); err != nil || cmp != -1 {
+		t.Errorf("Expected zero < a, got %d (err: %v)", cmp, err)
+	}
+
+	huge := MustNew(math.MaxInt64, "USD")
+	if _, err := huge.Add(MustNew(1, "USD")); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow on Add, got %v", err)
+	}
+	if _, err := huge.Mul(2); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow on Mul, got %v", err)
+	}
+	if _, err := MustNew(0, "USD").Sub(MustNew(math.MinInt64, "USD")); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow on Sub, got %v", err)
+	}
+}
+
+func TestBankersRounding(t *testing.T) {
+	tests := []struct {
+		amount   string
+		rate     string
+		expected int64
+	}{
+		{"0.50", "0.05", 2},   // 2.5 cents rounds to even 2
+		{"0.70", "0.05", 4},   // 3.5 cents rounds to even 4
+		{"0.90", "0.05", 4},   // 4.5 cents rounds to even 4
+		{"1.00", "0.005", 0},  // 0.5 cents rounds to even 0
+		{"3.00", "0.005", 2},  // 1.5 cents rounds to even 2
+		{"2.00", "0.0051", 1}, // 1.02 cents rounds down
+		{"-0.70", "0.05", -4}, // ties to even for negatives too
+		{"4000", "0.005", 2000},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.amount, "USD").MulRate(MustParseRate(tt.rate))
+		if err != nil || got.MinorUnits() != tt.expected {
+			t.Errorf("%s * %s: expected %d cents, got %d (err: %v)", tt.amount, tt.rate, tt.expected, got.MinorUnits(), err)
+		}
+	}
+
+	f, _ := FromFloat(0.125, "USD") // exactly representable tie
+	if f.MinorUnits() != 12 {
+		t.Errorf("Expected FromFloat(0.125) to round to 12 cents, got %d", f.MinorUnits())
+	}
+	f, _ = FromFloat(75.5, "USD")
+	if f.MinorUnits() != 7550 {
+		t.Errorf("Expected 7550 cents, got %d", f.MinorUnits())
+	}
+	if _, err := FromFloat(math.NaN(), "USD"); err == nil {
+		t.Error("FromFloat should reject NaN")
+	}
+}
+
+func TestDiv(t *testing.T) {
+	tests := []struct {
+		amount   string
+		divisor  int64
+		expected int64
+	}{
+		{"100", 4, 2500},
+		{"100", 3, 3333},
+		{"0.05", 2, 2}, // 2.5 cents rounds to even
+		{"0.07", 2, 4}, // 3.5 cents rounds to even
+		{"-0.05", 2, -2},
+		{"10", -4, -250},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.amount, "USD").Div(tt.divisor)
+		if err != nil || got.MinorUnits() != tt.expected || got.Currency() != "USD" {
+			t.Errorf("%s / %d: expected %d cents, got %v (err: %v)", tt.amount, tt.divisor, tt.expected, got, err)
+		}
+	}
+
+	if _, err := MustParse("1", "USD").Div(0); err == nil {
+		t.Error("Expected division by zero to fail")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		amount, from, rate, to string
+		expected               string
+	}{
+		{"100", "USD", "0.92", "EUR", "92.00"},
+		{"10.005", "KWD", "3.25", "USD", "32.52"}, // 32.51625
+		{"100", "USD", "151.235", "JPY", "15124"},
+		{"1000", "JPY", "0.006612", "USD", "6.61"},
+		{"-50", "EUR", "1.0875", "USD", "-54.38"}, // -54.375 rounds to even
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.amount, tt.from).Convert(tt.to, MustParseRate(tt.rate))
+		if err != nil || got.Currency() != tt.to || got.Decimal() != tt.expected {
+			t.Errorf("%s %s at %s: expected %s %s, got %v (err: %v)", tt.amount, tt.from, tt.rate, tt.expected, tt.to, got, err)
+		}
+	}
+
+	if zero, err := (Money{}).Convert("EUR", MustParseRate("0.92")); err != nil || zero.Currency() != "EUR" || !zero.IsZero() {
+		t.Errorf("Expected a zero in EUR, got %v (err: %v)", zero, err)
+	}
+	if _, err := MustParse("1", "USD").Convert("XYZ", MustParseRate("1")); err == nil {
+		t.Error("Expected an unknown currency to fail")
+	}
+
+	inverse, err := MustParseRate("0.92").Inverse()
+	if err != nil || inverse.String() != "1.086956522" {
+		t.Errorf("Expected 1/0.92 to be 1.086956522, got %v (err: %v)", inverse, err)
+	}
+	if _, err := (Rate{}).Inverse(); err == nil {
+		t.Error("Expected a zero rate to have no inverse")
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	shares, err := MustParse("100", "USD").Allocate(1, 1, 1)
+	if err != nil {
+		t.Fatalf("Allocate failed: %v", err)
+	}
+
+	expected := []int64{3334, 3333, 3333}
+	for i, share := range shares {
+		if share.MinorUnits() != expected[i] {
+			t.Errorf("Share %d: expected %d, got %d", i, expected[i], share.MinorUnits())
+		}
+	}
+
+	shares, _ = MustParse("-0.05", "USD").Allocate(0, 1, 1)
+	if shares[0].MinorUnits() != 0 || shares[1].MinorUnits()+shares[2].MinorUnits() != -5 {
+		t.Errorf("Unexpected negative allocation %v", shares)
+	}
+
+	if _, err := MustParse("1", "USD").Allocate(0, 0); err == nil {
+		t.Error("Allocate should reject all-zero ratios")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type account struct {
+		Balance Money `json:"balance"`
+		Rate    Rate  `json:"rate"`
+	}
+
+	original := account{Balance: MustParse("-12.34", "EUR"), Rate: MustParseRate("0.005")}
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"balance":{"amount":"-12.34","currency":"EUR"},"rate":"0.005"}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	var decoded account
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !decoded.Balance.Equal(original.Balance) || decoded.Rate != original.Rate {
+		t.Errorf("Expected %v, got %v", original, decoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"balance":{"amount":"5","currency":""}}`), &decoded); err == nil {
+		t.Error("Unmarshal should reject a non-zero amount without a currency")
+	}
+}
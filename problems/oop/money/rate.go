@@ -0,0 +1,112 @@
+/*
+ ** ** ** ** ** **
+  \ \ / / \ \ / /
+   \ V /   \ V /
+    | |     | |
+    |_|     |_|
+   Yasin   Yalcin
+*/
+
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// rateScale is the number of decimal places a Rate keeps
+const rateScale = 9
+
+// Rate is an exact decimal ratio such as an interest rate or a fee
+// percentage, stored with nine decimal places
+type Rate struct {
+	nanos int64
+}
+
+// ParseRate reads a decimal rate such as "0.005" for 0.5%
+func ParseRate(s string) (Rate, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return Rate{}, fmt.Errorf("invalid rate %q", s)
+	}
+
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(rateScale)))
+	if !scaled.IsInt() {
+		return Rate{}, fmt.Errorf("rate %q has more than %d decimal places", s, rateScale)
+	}
+	if !scaled.Num().IsInt64() {
+		return Rate{}, ErrOverflow
+	}
+	return Rate{nanos: scaled.Num().Int64()}, nil
+}
+
+// MustParseRate is like ParseRate but panics on error. It is meant for constants in code and tests.
+func MustParseRate(s string) Rate {
+	r, err := ParseRate(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// rat returns the rate as an exact fraction
+func (r Rate) rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(r.nanos), pow10(rateScale))
+}
+
+// Inverse returns 1/r rounded to nine decimal places with ties to even, such
+// as the EUR to USD rate from the USD to EUR rate
+func (r Rate) Inverse() (Rate, error) {
+	if r.nanos == 0 {
+		return Rate{}, errors.New("zero rate has no inverse")
+	}
+
+	inverse := new(big.Rat).Inv(r.rat())
+	nanos, err := roundHalfEven(inverse.Mul(inverse, new(big.Rat).SetInt(pow10(rateScale))))
+	if err != nil {
+		return Rate{}, err
+	}
+	return Rate{nanos: nanos}, nil
+}
+
+// IsZero reports whether the rate is zero
+func (r Rate) IsZero() bool {
+	return r.nanos == 0
+}
+
+// Float64 returns the nearest float to the rate, for display only
+func (r Rate) Float64() float64 {
+	f, _ := r.rat().Float64()
+	return f
+}
+
+// String returns the rate as a decimal without trailing zeros, such as "0.005"
+func (r Rate) String() string {
+	s := r.rat().FloatString(rateScale)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// MarshalJSON encodes the rate as a decimal string
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON decodes a decimal string
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}